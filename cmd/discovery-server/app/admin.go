@@ -0,0 +1,87 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+
+	"github.com/go-logr/logr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/gardener/gardener-discovery-server/cmd/discovery-server/app/options"
+	"github.com/gardener/gardener-discovery-server/internal/handler"
+	"github.com/gardener/gardener-discovery-server/internal/handler/admin"
+	"github.com/gardener/gardener-discovery-server/internal/store"
+	"github.com/gardener/gardener-discovery-server/internal/tokenverify"
+)
+
+// newAdminServer returns the admin API server and its listener.
+// The server uses TLS if clients are authenticated, otherwise it must listen on a loopback address.
+func newAdminServer(
+	conf *options.Config,
+	stores map[string]store.Inspector,
+	breakers map[string]admin.Breaker,
+	verifier *tokenverify.Verifier,
+	getCertificate func(*tls.ClientHelloInfo) (*tls.Certificate, error),
+	c client.Client,
+	log logr.Logger,
+) (*http.Server, serverListener, error) {
+	adminHandler := admin.New(stores, breakers, log)
+
+	mux := http.NewServeMux()
+	mux.Handle("/admin/stores", adminHandler.HandleStores())
+	mux.Handle("/admin/stores/{store}/entries", adminHandler.HandleEntries())
+	mux.Handle("/admin/stores/{store}/entries/{key}", adminHandler.HandleEntry())
+	mux.Handle("/admin/stores/{store}/rejections", adminHandler.HandleRejections())
+	mux.Handle("/admin/breakers", adminHandler.HandleBreakers())
+	mux.Handle("/admin/breakers/{store}/release", adminHandler.HandleReleaseBreaker())
+	if conf.Admin.VerifyToken {
+		mux.Handle("/admin/verify-token", adminHandler.HandleVerifyToken(verifier))
+	}
+	mux.Handle("/", handler.NotFound(log))
+
+	var (
+		h          http.Handler = mux
+		tlsConfig  *tls.Config
+		authn      []admin.Authenticator
+		serveHTTPS = len(conf.Admin.ClientCA) > 0 || conf.Admin.TokenReview
+	)
+	if serveHTTPS {
+		tlsConfig = newTLSConfig(conf.Serving, getCertificate)
+	}
+	if len(conf.Admin.ClientCA) > 0 {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(conf.Admin.ClientCA) {
+			return nil, serverListener{}, errors.New("admin client CA bundle does not contain any certificate")
+		}
+		tlsConfig.ClientCAs = pool
+		tlsConfig.ClientAuth = tls.RequireAndVerifyClientCert
+		authn = append(authn, admin.ClientCertificateAuthenticator{})
+	}
+	if conf.Admin.TokenReview {
+		if tlsConfig.ClientAuth == tls.RequireAndVerifyClientCert {
+			tlsConfig.ClientAuth = tls.VerifyClientCertIfGiven
+		}
+		authn = append(authn, &admin.TokenReviewAuthenticator{Client: c})
+	}
+	if len(authn) > 0 {
+		h = admin.WithAuthentication(h, log, authn, conf.Admin.AllowedUsers, conf.Admin.AllowedGroups)
+	}
+	h = handler.RequestID(h)
+
+	ln, err := net.Listen("tcp", conf.Admin.Address)
+	if err != nil {
+		return nil, serverListener{}, fmt.Errorf("failed to listen on %s: %w", conf.Admin.Address, err)
+	}
+
+	srv := newServer(conf.Serving, h, tlsConfig)
+	srv.Addr = conf.Admin.Address
+	return srv, serverListener{name: "admin", listener: ln, tls: serveHTTPS}, nil
+}
@@ -7,50 +7,57 @@ package app
 import (
 	"context"
 	"crypto/tls"
+	"crypto/x509"
 	"errors"
 	"flag"
 	"fmt"
+	"maps"
 	"net"
 	"net/http"
+	"net/netip"
 	"slices"
+	"strconv"
+	"sync"
 	"time"
 
-	"github.com/gardener/gardener/pkg/client/kubernetes"
-	gardenerhealthz "github.com/gardener/gardener/pkg/healthz"
 	"github.com/gardener/gardener/pkg/logger"
 	"github.com/go-logr/logr"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
-	corev1 "k8s.io/api/core/v1"
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/component-base/version"
 	"k8s.io/component-base/version/verflag"
-	"k8s.io/utils/ptr"
-	ctrl "sigs.k8s.io/controller-runtime"
-	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/client"
-	controllerconfig "sigs.k8s.io/controller-runtime/pkg/config"
-	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
 
 	"github.com/gardener/gardener-discovery-server/cmd/discovery-server/app/options"
 	"github.com/gardener/gardener-discovery-server/internal/dynamiccert"
 	"github.com/gardener/gardener-discovery-server/internal/handler"
-	certhandler "github.com/gardener/gardener-discovery-server/internal/handler/certificate"
-	oidhandler "github.com/gardener/gardener-discovery-server/internal/handler/openidmeta"
+	"github.com/gardener/gardener-discovery-server/internal/handler/admin"
+	"github.com/gardener/gardener-discovery-server/internal/handler/federation"
+	"github.com/gardener/gardener-discovery-server/internal/handler/signingkeys"
 	"github.com/gardener/gardener-discovery-server/internal/handler/workloadidentity"
+	"github.com/gardener/gardener-discovery-server/internal/listener"
 	"github.com/gardener/gardener-discovery-server/internal/metrics"
-	certificatereconciler "github.com/gardener/gardener-discovery-server/internal/reconciler/certificate"
-	oidreconciler "github.com/gardener/gardener-discovery-server/internal/reconciler/openidmeta"
+	"github.com/gardener/gardener-discovery-server/internal/sharding"
+	"github.com/gardener/gardener-discovery-server/internal/signing"
 	"github.com/gardener/gardener-discovery-server/internal/store"
-	"github.com/gardener/gardener-discovery-server/internal/store/certificate"
 	"github.com/gardener/gardener-discovery-server/internal/store/openidmeta"
+	"github.com/gardener/gardener-discovery-server/internal/tokenverify"
+	"github.com/gardener/gardener-discovery-server/internal/tracing"
 )
 
 // AppName is the name of the application.
 const AppName = "gardener-discovery-server"
 
+const (
+	// workloadIdentityIssuerPath is the path of the workload identity issuer of the garden.
+	workloadIdentityIssuerPath = "/garden/workload-identity/issuer"
+	// workloadIdentityStoreName is the name of the store of the workload identity documents in the admin API.
+	workloadIdentityStoreName = "workloadidentity"
+)
+
 // NewCommand is the root command for Gardener discovery server.
 func NewCommand() *cobra.Command {
 	opt := options.NewOptions()
@@ -88,189 +95,560 @@ func NewCommand() *cobra.Command {
 	opt.AddFlags(fs)
 	fs.AddGoFlagSet(flag.CommandLine)
 
+	cmd.AddCommand(newCheckCommand(), newVerifyTokenCommand())
+
 	return cmd
 }
 
-func run(ctx context.Context, log logr.Logger, conf *options.Config) error {
-	cfg, err := ctrl.GetConfig()
+func run(ctx context.Context, log logr.Logger, conf *options.Config) (err error) {
+	shutdownTracing, err := tracing.Setup(ctx, tracing.Config{
+		Endpoint:       conf.Tracing.Endpoint,
+		SamplingRatio:  conf.Tracing.SamplingRatio,
+		ServiceName:    AppName,
+		ServiceVersion: version.Get().GitVersion,
+	})
 	if err != nil {
-		return err
+		return fmt.Errorf("failed to set up tracing: %w", err)
 	}
+	defer func() {
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		err = errors.Join(err, shutdownTracing(shutdownCtx))
+	}()
 
-	mgr, err := ctrl.NewManager(cfg, ctrl.Options{
-		Logger: log.WithName("manager"),
-		Scheme: kubernetes.GardenScheme,
-		Metrics: metricsserver.Options{
-			BindAddress: net.JoinHostPort("", "8080"),
-		},
-		GracefulShutdownTimeout: ptr.To(10 * time.Second),
-		LeaderElection:          false,
-		PprofBindAddress:        "",
-		HealthProbeBindAddress:  net.JoinHostPort("", "8081"),
-		Cache: cache.Options{
-			ByObject: map[client.Object]cache.ByObject{
-				&corev1.Secret{}: {
-					Namespaces: map[string]cache.Config{
-						"gardener-system-shoot-issuer": {},
-					},
-				},
-			},
-		},
-		Controller: controllerconfig.Controller{
-			RecoverPanic: ptr.To(true),
-		},
-	})
+	ds, err := newDiscoveryServer(conf, log)
 	if err != nil {
-		return fmt.Errorf("unable to create manager: %w", err)
+		return err
 	}
 
-	if err := mgr.AddHealthzCheck("ping", healthz.Ping); err != nil {
+	// Only the peer listener accepts requests forwarded by other replicas.
+	srv := newServer(conf.Serving, sharding.StripForwardedBy(ds.handler), newTLSConfig(conf.Serving, ds.cert.GetCertificate))
+	listeners, err := newListeners(conf.Serving, log.WithName("listener"))
+	if err != nil {
 		return err
 	}
-	if err := mgr.AddReadyzCheck("informer-sync", gardenerhealthz.NewCacheSyncHealthz(mgr.GetCache())); err != nil {
-		return err
+
+	servers := []func(context.Context) error{
+		func(ctx context.Context) error { return runServer(ctx, log, srv, listeners...) },
 	}
 
-	oidStore := store.MustNewStore(openidmeta.Copy)
-	if err := (&oidreconciler.Reconciler{
-		ResyncPeriod: conf.Resync.Duration,
-		Store:        oidStore,
-	}).SetupWithManager(mgr); err != nil {
-		return fmt.Errorf("unable to create oid controller: %w", err)
+	if conf.Sharding.Enabled {
+		peerSrv, peerListener, err := newPeerServer(conf, ds.handler, ds.cert.GetCertificate)
+		if err != nil {
+			return err
+		}
+		servers = append(servers, func(ctx context.Context) error {
+			return runServer(ctx, log.WithName("peer"), peerSrv, peerListener)
+		})
 	}
 
-	oidHandler := oidhandler.New(oidStore, log.WithName("oid-meta-handler"))
+	if conf.Admin.Address != "" {
+		adminSrv, adminListener, err := newAdminServer(conf, ds.stores, ds.breakers, ds.verifier, ds.cert.GetCertificate, ds.adminClient, log.WithName("admin"))
+		if err != nil {
+			return err
+		}
+		servers = append(servers, func(ctx context.Context) error {
+			return runServer(ctx, log.WithName("admin"), adminSrv, adminListener)
+		})
+	}
 
-	certStore := store.MustNewStore(certificate.Copy)
-	if err := (&certificatereconciler.Reconciler{
-		ResyncPeriod: conf.Resync.Duration,
-		Store:        certStore,
-	}).SetupWithManager(mgr); err != nil {
-		return fmt.Errorf("unable to create cert controller: %w", err)
+	metricsSrv, err := metricsserver.NewServer(metricsserver.Options{BindAddress: conf.Serving.MetricsAddress}, nil, nil)
+	if err != nil {
+		return fmt.Errorf("unable to create metrics server: %w", err)
 	}
+	servers = append(servers, metricsSrv.Start)
 
-	certhandlerHandler := certhandler.New(certStore, log.WithName("cluster-ca-handler"))
+	healthListener, err := net.Listen("tcp", conf.Serving.HealthAddress)
+	if err != nil {
+		return fmt.Errorf("failed to listen on health probe address: %w", err)
+	}
+	healthSrv := newServer(conf.Serving, newHealthHandler(ds.gardens), nil)
+	servers = append(servers, func(ctx context.Context) error {
+		return runServer(ctx, log.WithName("health"), healthSrv, serverListener{name: "health", listener: healthListener})
+	})
 
-	mux := http.NewServeMux()
-	const (
-		oidConfigPath = "/projects/{projectName}/shoots/{shootUID}/issuer/.well-known/openid-configuration"
-		jwksPath      = "/projects/{projectName}/shoots/{shootUID}/issuer/jwks"
-	)
-	mux.Handle(
-		oidConfigPath,
-		metrics.InstrumentHandler(oidConfigPath, oidHandler.HandleOpenIDConfiguration()),
-	)
-	mux.Handle(
-		jwksPath,
-		metrics.InstrumentHandler(jwksPath, oidHandler.HandleJWKS()),
-	)
+	return runGardens(ctx, log, ds.gardens, servers...)
+}
+
+// discoveryServer holds the handler serving the published documents and the gardens they are read from.
+type discoveryServer struct {
+	handler  http.Handler
+	cert     *dynamiccert.SNICertificate
+	gardens  []*garden
+	stores   map[string]store.Inspector
+	breakers map[string]admin.Breaker
+	// verifier diagnoses tokens against the documents in the stores.
+	verifier *tokenverify.Verifier
+	// adminClient is used to review the tokens of admin requests, it is the client of the default or the first garden.
+	adminClient client.Client
+}
 
-	const (
-		caPath = "/projects/{projectName}/shoots/{shootUID}/cluster-ca"
+// newDiscoveryServer creates the gardens and registers the routes of all documents served by the discovery server.
+func newDiscoveryServer(conf *options.Config, log logr.Logger) (*discoveryServer, error) {
+	// signer stays nil if signing is disabled, so that the documents are served without signatures.
+	var (
+		signer     signing.Signer
+		signingKey *signing.DynamicKey
+		err        error
 	)
-	mux.Handle(
-		caPath,
-		metrics.InstrumentHandler(caPath, certhandlerHandler.HandleCABundle()),
+	if conf.Signing.KeyFile != "" {
+		signingKey, err = signing.New(
+			conf.Signing.KeyFile,
+			signing.WithLogger(log.WithName("signing-key")),
+			signing.WithRefreshInterval(5*time.Minute),
+			// Verifiers may cache the published keys for the max-age of the jwks endpoint type and revalidate them in the background afterwards.
+			signing.WithPublishDelay(conf.Cache.JWKS.MaxAge+conf.Cache.JWKS.StaleWhileRevalidate),
+		)
+		if err != nil {
+			return nil, fmt.Errorf("unable to load signing key: %w", err)
+		}
+		signer = signingKey
+	}
+
+	cert, err := newCertificate(conf.Serving, log)
+	if err != nil {
+		return nil, err
+	}
+
+	var peerTransport http.RoundTripper
+	if conf.Sharding.Enabled {
+		if peerTransport, err = newPeerTransport(conf.Sharding, log); err != nil {
+			return nil, err
+		}
+	}
+
+	mux := http.NewServeMux()
+	var (
+		gardens  []*garden
+		stores   = map[string]store.Inspector{}
+		breakers = map[string]admin.Breaker{}
+		// adminClient is used to review the tokens of admin requests, it is the client of the default or the first garden.
+		adminClient client.Client
 	)
+	for _, g := range conf.Garden.Gardens {
+		gdn, err := newGarden(g, gardenDeps{
+			conf:          conf,
+			signer:        signer,
+			certificates:  cert,
+			peerTransport: peerTransport,
+			mux:           mux,
+			log:           log,
+		})
+		if err != nil {
+			return nil, err
+		}
+		gardens = append(gardens, gdn)
+		maps.Copy(stores, gdn.stores)
+		maps.Copy(breakers, gdn.breakers)
+		if adminClient == nil || gdn.isDefault {
+			adminClient = gdn.client
+		}
+	}
+	resolver := &issuerResolver{gardens: gardens}
+
+	// gardenRoute registers the documents of the garden itself, they are served with the CORS policy of the garden.
+	corsLog := log.WithName("cors")
+	gardenRoute := func(path string, h http.Handler) {
+		mux.Handle(path, instrument(path, handler.CORS(h, corsLog, corsPolicies(conf.CORS).Garden, http.MethodGet, http.MethodHead)))
+	}
 
 	if conf.WorkloadIdentity.Enabled {
 		const (
-			workloadIdentityOpenIDConfigPath = "/garden/workload-identity/issuer/.well-known/openid-configuration"
-			workloadIdentityJWKSPath         = "/garden/workload-identity/issuer/jwks"
+			workloadIdentityOpenIDConfigPath = workloadIdentityIssuerPath + "/.well-known/openid-configuration"
+			workloadIdentityJWKSPath         = workloadIdentityIssuerPath + "/jwks"
+			workloadIdentityFederationPath   = workloadIdentityIssuerPath + "/federation"
 		)
-		workloadIdentityHandler, err := workloadidentity.New(conf.WorkloadIdentity.OpenIDConfig, conf.WorkloadIdentity.JWKS, log.WithName("workload-identity"))
+		workloadIdentityHandler, err := workloadidentity.New(conf.WorkloadIdentity.OpenIDConfig, conf.WorkloadIdentity.JWKS, signer, cachePolicies(conf.Cache), log.WithName("workload-identity"))
 		if err != nil {
-			return fmt.Errorf("failed to create workload identity handler: %w", err)
+			return nil, fmt.Errorf("failed to create workload identity handler: %w", err)
 		}
 
-		mux.Handle(
-			workloadIdentityOpenIDConfigPath,
-			metrics.InstrumentHandler(workloadIdentityOpenIDConfigPath, workloadIdentityHandler.HandleOpenIDConfiguration()),
-		)
-		mux.Handle(
-			workloadIdentityJWKSPath,
-			metrics.InstrumentHandler(workloadIdentityJWKSPath, workloadIdentityHandler.HandleJWKS()),
+		// The workload identity documents are static, the store only exposes them to the metrics and the admin API.
+		workloadIdentityStore := store.MustNewStore(openidmeta.Copy)
+		workloadIdentityStore.Write("garden", openidmeta.Data{
+			Config: conf.WorkloadIdentity.OpenIDConfig,
+			JWKS:   conf.WorkloadIdentity.JWKS,
+		})
+		stores[workloadIdentityStoreName] = workloadIdentityStore
+		resolver.workloadIdentity = workloadIdentityStore
+		metrics.RegisterStaticStore(conf.Garden.Default, workloadIdentityStoreName, workloadIdentityStore)
+
+		gardenRoute(workloadIdentityOpenIDConfigPath, workloadIdentityHandler.HandleOpenIDConfiguration())
+		gardenRoute(workloadIdentityJWKSPath, workloadIdentityHandler.HandleJWKS())
+
+		// The federation document of the workload identity issuer does not read from the stores of the gardens.
+		federationHandler := federation.New(nil, cert, signer, cachePolicies(conf.Cache), log.WithName("federation-handler"))
+		workloadIdentityFederationHandler, err := federationHandler.HandleWorkloadIdentity(conf.WorkloadIdentity.OpenIDConfig)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create workload identity federation handler: %w", err)
+		}
+		gardenRoute(workloadIdentityFederationPath, workloadIdentityFederationHandler)
+	}
+
+	if signingKey != nil {
+		const (
+			signingKeysPath = "/garden/discovery-server/signing-keys"
 		)
+		gardenRoute(signingKeysPath, signingkeys.New(signingKey, cachePolicies(conf.Cache), log.WithName("signing-keys")).HandleJWKS())
+	}
+
+	mux.Handle("/", handler.SetHSTS(handler.RequestID(handler.NotFound(log))))
+
+	return &discoveryServer{
+		handler:     mux,
+		cert:        cert,
+		gardens:     gardens,
+		stores:      stores,
+		breakers:    breakers,
+		verifier:    &tokenverify.Verifier{Resolver: resolver},
+		adminClient: adminClient,
+	}, nil
+}
+
+// runGardens runs the managers of the gardens together with the servers.
+// A garden whose manager fails is restarted while the others keep running, see [garden.run],
+// the servers are shut down once all managers stopped or failed. The managers are stopped if a server fails.
+func runGardens(ctx context.Context, log logr.Logger, gardens []*garden, servers ...func(context.Context) error) error {
+	serverCtx, cancelSrv := context.WithCancel(ctx)
+	defer cancelSrv()
+	mgrCtx, cancelMgr := context.WithCancel(ctx)
+	defer cancelMgr()
+
+	var (
+		mgrWG   sync.WaitGroup
+		mgrErrs = make(chan error, len(gardens))
+	)
+	for _, g := range gardens {
+		mgrWG.Go(func() {
+			if err := g.run(mgrCtx, log); err != nil {
+				mgrErrs <- err
+			}
+		})
+	}
+	go func() {
+		mgrWG.Wait()
+		cancelSrv()
+	}()
+
+	srvErrs := make(chan error, len(servers))
+	var srvWG sync.WaitGroup
+	for _, run := range servers {
+		srvWG.Go(func() {
+			if err := run(serverCtx); err != nil {
+				srvErrs <- err
+				cancelMgr()
+				cancelSrv()
+			}
+		})
+	}
+
+	srvWG.Wait()
+	cancelMgr()
+	mgrWG.Wait()
+	close(srvErrs)
+	close(mgrErrs)
+
+	var errs []error
+	for err := range srvErrs {
+		errs = append(errs, err)
+	}
+	for err := range mgrErrs {
+		errs = append(errs, err)
+	}
+	return errors.Join(errs...)
+}
+
+// instrument wraps the handler with metrics and tracing instrumentation.
+func instrument(path string, h http.Handler) http.Handler {
+	return metrics.InstrumentHandler(path, tracing.InstrumentHandler(path, handler.RequestID(h)))
+}
+
+// cachePolicies converts the configured cache policies to the ones of the handlers.
+func cachePolicies(conf options.CacheConfig) handler.CachePolicies {
+	policy := func(p options.CachePolicy) handler.CachePolicy {
+		return handler.CachePolicy{
+			MaxAge:               p.MaxAge,
+			StaleWhileRevalidate: p.StaleWhileRevalidate,
+			StaleIfError:         p.StaleIfError,
+			Expires:              p.Expires,
+		}
+	}
+	return handler.CachePolicies{
+		OpenIDConfiguration: policy(conf.OpenIDConfiguration),
+		JWKS:                policy(conf.JWKS),
+		CABundle:            policy(conf.CABundle),
+		SPIFFEBundle:        policy(conf.SPIFFEBundle),
+	}
+}
+
+// corsPolicies converts the configured CORS policies to the ones of the handlers.
+func corsPolicies(conf options.CORSConfig) handler.CORSPolicies {
+	policy := func(p options.CORSPolicy) handler.CORSPolicy {
+		return handler.CORSPolicy{
+			AllowedOrigins: p.AllowedOrigins,
+			ExposedHeaders: p.ExposedHeaders,
+			MaxAge:         p.MaxAge,
+		}
 	}
+	return handler.CORSPolicies{
+		Shoot:  policy(conf.Shoot),
+		Garden: policy(conf.Garden),
+	}
+}
 
-	mux.Handle("/", handler.SetHSTS(handler.NotFound(log)))
+// guard wraps the store in a deletion breaker unless the safeguard is disabled.
+// It returns the writer of the store and the function clearing the store together with its breaker.
+func guard[T any](conf options.DeletionBreakerConfig, name string, s *store.Store[T], breakers map[string]admin.Breaker, log logr.Logger) store.Writer[T] {
+	if conf.Threshold == 0 {
+		return s
+	}
+	b := store.NewDeletionBreaker(s, store.BreakerConfig{
+		Threshold:    conf.Threshold,
+		Window:       conf.Window,
+		MinDeletions: conf.MinDeletions,
+	}, log.WithName("deletion-breaker").WithValues("store", name))
+	breakers[name] = b
+	return b
+}
 
-	cert, err := dynamiccert.New(
-		conf.Serving.TLSCertFile,
-		conf.Serving.TLSKeyFile,
-		dynamiccert.WithLogger(log.WithName("dynamic-cert")),
+// newPeerTransport returns the transport used to forward requests to other replicas.
+// It authenticates with the peer client certificate and verifies the replicas with the peer CA bundle.
+func newPeerTransport(conf options.ShardingConfig, log logr.Logger) (*http.Transport, error) {
+	clientCert, err := dynamiccert.New(
+		conf.PeerCertFile,
+		conf.PeerKeyFile,
+		dynamiccert.WithLogger(log.WithName("peer-cert")),
 		dynamiccert.WithRefreshInterval(5*time.Minute),
+		dynamiccert.WithName("peer"),
 	)
 	if err != nil {
-		return fmt.Errorf("failed to parse discovery server certificates: %w", err)
+		return nil, fmt.Errorf("failed to parse sharding peer certificate: %w", err)
 	}
 
-	srv := &http.Server{
-		Addr:    conf.Serving.Address,
-		Handler: mux,
-		TLSConfig: &tls.Config{
-			GetCertificate: cert.GetCertificate,
-			// TODO: remove in the future
-			// gosec complains although 1.2 is the current default
-			MinVersion:   tls.VersionTLS12,
-			CipherSuites: getCipherSuiteIDs(),
+	rootCAs, err := peerCertPool(conf)
+	if err != nil {
+		return nil, err
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = &tls.Config{
+		ServerName: conf.PeerServerName,
+		MinVersion: tls.VersionTLS12,
+		RootCAs:    rootCAs,
+		GetClientCertificate: func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+			return clientCert.GetCertificate(nil)
 		},
-		ReadTimeout:  10 * time.Second,
-		WriteTimeout: 10 * time.Second,
 	}
+	transport.ResponseHeaderTimeout = 5 * time.Second
+	return transport, nil
+}
 
-	srvCh := make(chan error)
-	serverCtx, cancelSrv := context.WithCancel(ctx)
+// newPeerServer returns the server receiving the requests forwarded by other replicas.
+// It serves the same handler as the discovery server, but only to clients presenting a certificate signed by the peer CA bundle.
+func newPeerServer(conf *options.Config, handler http.Handler, getCertificate func(*tls.ClientHelloInfo) (*tls.Certificate, error)) (*http.Server, serverListener, error) {
+	clientCAs, err := peerCertPool(conf.Sharding)
+	if err != nil {
+		return nil, serverListener{}, err
+	}
+	tlsConfig := newTLSConfig(conf.Serving, getCertificate)
+	tlsConfig.ClientAuth = tls.RequireAndVerifyClientCert
+	tlsConfig.ClientCAs = clientCAs
 
-	mgrCh := make(chan error)
-	mgrCtx, cancelMgr := context.WithCancel(ctx)
+	host, _, err := net.SplitHostPort(conf.Serving.Address)
+	if err != nil {
+		return nil, serverListener{}, fmt.Errorf("failed to parse serving address: %w", err)
+	}
+	address := net.JoinHostPort(host, strconv.FormatUint(uint64(conf.Sharding.PeerPort), 10))
+	ln, err := net.Listen("tcp", address)
+	if err != nil {
+		return nil, serverListener{}, fmt.Errorf("failed to listen on peer address %s: %w", address, err)
+	}
 
-	go func() {
-		defer cancelSrv()
-		mgrCh <- mgr.Start(mgrCtx)
-	}()
+	return newServer(conf.Serving, handler, tlsConfig), serverListener{name: "peer", listener: ln, tls: true}, nil
+}
 
-	go func() {
-		defer cancelMgr()
-		srvCh <- runServer(serverCtx, log, srv)
-	}()
+func peerCertPool(conf options.ShardingConfig) (*x509.CertPool, error) {
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(conf.PeerCA) {
+		return nil, errors.New("sharding peer CA bundle does not contain any certificate")
+	}
+	return pool, nil
+}
 
-	select {
-	case err := <-mgrCh:
-		return errors.Join(err, <-srvCh)
-	case err := <-srvCh:
-		return errors.Join(err, <-mgrCh)
+// newServer returns the discovery server configured with the timeouts and limits of the serving configuration.
+// Completed TLS handshakes are recorded as metrics.
+func newServer(conf options.ServingConfig, handler http.Handler, tlsConfig *tls.Config) *http.Server {
+	srv := &http.Server{
+		Addr:              conf.Address,
+		Handler:           handler,
+		TLSConfig:         tlsConfig,
+		ReadTimeout:       conf.ReadTimeout,
+		ReadHeaderTimeout: conf.ReadHeaderTimeout,
+		WriteTimeout:      conf.WriteTimeout,
+		IdleTimeout:       conf.IdleTimeout,
+		MaxHeaderBytes:    conf.MaxHeaderBytes,
+	}
+	if tlsConfig != nil {
+		srv.ConnState = recordTLSHandshakes()
+	}
+	return srv
+}
+
+// recordTLSHandshakes returns a connection state hook recording the negotiated version and cipher suite
+// once the handshake of a TLS connection completed. The server performs the handshake before a
+// connection becomes active for the first time, idle connections becoming active again are not recorded.
+func recordTLSHandshakes() func(net.Conn, http.ConnState) {
+	var pending sync.Map
+	return func(conn net.Conn, state http.ConnState) {
+		switch state {
+		case http.StateNew:
+			pending.Store(conn, struct{}{})
+		case http.StateActive:
+			tlsConn, ok := conn.(*tls.Conn)
+			if _, first := pending.LoadAndDelete(conn); !first || !ok {
+				return
+			}
+			if cs := tlsConn.ConnectionState(); cs.HandshakeComplete {
+				metrics.RecordTLSHandshake(cs.Version, cs.CipherSuite)
+			}
+		case http.StateClosed, http.StateHijacked:
+			pending.Delete(conn)
+		}
 	}
 }
 
-// runServer starts the discovery server. It returns if the context is canceled or the server cannot start initially.
-func runServer(ctx context.Context, log logr.Logger, srv *http.Server) error {
+// newCertificate loads the default and the SNI certificates of the discovery server.
+func newCertificate(conf options.ServingConfig, log logr.Logger) (*dynamiccert.SNICertificate, error) {
+	log = log.WithName("dynamic-cert")
+	defaultCert, err := dynamiccert.New(
+		conf.TLSCertFile,
+		conf.TLSKeyFile,
+		dynamiccert.WithLogger(log),
+		dynamiccert.WithRefreshInterval(5*time.Minute),
+		dynamiccert.WithName("default"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse discovery server certificates: %w", err)
+	}
+
+	named := make([]dynamiccert.NamedCertificate, 0, len(conf.TLSSNICertKeys))
+	for _, sni := range conf.TLSSNICertKeys {
+		cert, err := dynamiccert.New(
+			sni.CertFile,
+			sni.KeyFile,
+			dynamiccert.WithLogger(log.WithValues("certificate", sni.CertFile)),
+			dynamiccert.WithRefreshInterval(5*time.Minute),
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse discovery server SNI certificate %q: %w", sni.CertFile, err)
+		}
+		named = append(named, dynamiccert.NamedCertificate{Names: sni.Names, Certificate: cert})
+	}
+
+	return dynamiccert.NewSNI(defaultCert, named...), nil
+}
+
+// newTLSConfig returns the TLS configuration of the discovery server.
+func newTLSConfig(conf options.ServingConfig, getCertificate func(*tls.ClientHelloInfo) (*tls.Certificate, error)) *tls.Config {
+	cipherSuites := conf.TLSCipherSuites
+	if len(cipherSuites) == 0 {
+		cipherSuites = getCipherSuiteIDs()
+	}
+
+	return &tls.Config{
+		GetCertificate:   getCertificate,
+		MinVersion:       conf.TLSMinVersion, // #nosec G402 -- validated to be at least TLS 1.2
+		CipherSuites:     cipherSuites,
+		CurvePreferences: conf.TLSCurvePreferences,
+	}
+}
+
+// serverListener is a listener the discovery server serves on.
+type serverListener struct {
+	name     string
+	listener net.Listener
+	tls      bool
+}
+
+// newListeners returns the HTTPS listener and, if configured, the plain HTTP listener of the discovery server.
+func newListeners(conf options.ServingConfig, log logr.Logger) ([]serverListener, error) {
+	httpsListener, err := newListener(conf, conf.Address, nil, log.WithValues("listener", "https"))
+	if err != nil {
+		return nil, err
+	}
+	listeners := []serverListener{{name: "https", listener: httpsListener, tls: true}}
+
+	if conf.HTTPAddress != "" {
+		httpListener, err := newListener(conf, conf.HTTPAddress, conf.HTTPAllowedCIDRs, log.WithValues("listener", "http"))
+		if err != nil {
+			return nil, errors.Join(err, httpsListener.Close())
+		}
+		listeners = append(listeners, serverListener{name: "http", listener: httpListener})
+	}
+
+	return listeners, nil
+}
+
+// newListener listens on the address and applies the source restrictions, the PROXY protocol
+// and the connection limits of the serving configuration.
+// The total limit also counts connections whose PROXY protocol header is not yet read,
+// the per IP limit is applied to the client address conveyed by the PROXY protocol.
+func newListener(conf options.ServingConfig, address string, allowedCIDRs []netip.Prefix, log logr.Logger) (net.Listener, error) {
+	ln, err := net.Listen("tcp", address)
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen on %s: %w", address, err)
+	}
+
+	ln = listener.AllowCIDRs(ln, allowedCIDRs, log)
+	if !conf.ProxyProtocol {
+		return listener.LimitListener(ln, conf.MaxConnections, conf.MaxConnectionsPerIP, log), nil
+	}
+	ln = listener.LimitListener(listener.AllowCIDRs(ln, conf.ProxyProtocolAllowedCIDRs, log), conf.MaxConnections, 0, log)
+	ln = listener.ProxyProtocol(ln, conf.ReadHeaderTimeout, log)
+	return listener.LimitListener(ln, 0, conf.MaxConnectionsPerIP, log), nil
+}
+
+// runServer starts the discovery server on the given listeners. It returns if the context is canceled or
+// the server cannot serve on one of the listeners. All listeners are shut down gracefully together.
+func runServer(ctx context.Context, log logr.Logger, srv *http.Server, listeners ...serverListener) error {
 	log = log.WithName("discovery-server")
-	errCh := make(chan error)
-	go func(errCh chan<- error) {
-		log.Info("Starts listening", "address", srv.Addr)
-		defer close(errCh)
-		if err := srv.ListenAndServeTLS("", ""); err != nil && !errors.Is(err, http.ErrServerClosed) {
-			errCh <- fmt.Errorf("failed serving content: %w", err)
-		} else {
+	errCh := make(chan error, len(listeners))
+	for _, l := range listeners {
+		go func() {
+			log := log.WithValues("listener", l.name)
+			log.Info("Starts listening", "address", l.listener.Addr().String())
+
+			var err error
+			if l.tls {
+				err = srv.ServeTLS(l.listener, "", "")
+			} else {
+				err = srv.Serve(l.listener)
+			}
+			if err != nil && !errors.Is(err, http.ErrServerClosed) {
+				errCh <- fmt.Errorf("failed serving content on %s listener: %w", l.name, err)
+				return
+			}
 			log.Info("Server stopped listening")
-		}
-	}(errCh)
+		}()
+	}
 
+	var serveErr error
 	select {
-	case err := <-errCh:
-		return err
+	case serveErr = <-errCh:
 	case <-ctx.Done():
-		log.Info("Shutting down")
-		cancelCtx, cancel := context.WithTimeout(context.Background(), time.Second*10)
-		defer cancel()
-		err := srv.Shutdown(cancelCtx)
-		if err != nil {
-			return fmt.Errorf("discovery server failed graceful shutdown: %w", err)
-		}
-		log.Info("Shutdown successful")
-		return nil
 	}
+
+	log.Info("Shutting down")
+	cancelCtx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+	if err := srv.Shutdown(cancelCtx); err != nil {
+		return errors.Join(serveErr, fmt.Errorf("discovery server failed graceful shutdown: %w", err))
+	}
+	if serveErr != nil {
+		return serveErr
+	}
+	log.Info("Shutdown successful")
+	return nil
 }
 
 // getCipherSuiteIDs returns the default cipher suite IDs excluding:
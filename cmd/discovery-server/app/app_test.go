@@ -5,10 +5,32 @@
 package app
 
 import (
+	"bufio"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
 	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"errors"
+	"io"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"strings"
+	"syscall"
+	"time"
 
+	"github.com/go-logr/logr"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	"github.com/gardener/gardener-discovery-server/cmd/discovery-server/app/options"
+	"github.com/gardener/gardener-discovery-server/internal/store"
 )
 
 var _ = Describe("App", func() {
@@ -22,4 +44,438 @@ var _ = Describe("App", func() {
 			Expect(cipherIDs).ToNot(BeEmpty())
 		})
 	})
+
+	Context("server hardening", func() {
+		var (
+			conf options.ServingConfig
+			addr string
+
+			startServer = func() {
+				srv := newServer(conf, http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+					w.WriteHeader(http.StatusOK)
+				}), nil)
+				ln, err := newListener(conf, conf.Address, nil, logr.Discard())
+				Expect(err).ToNot(HaveOccurred())
+				addr = ln.Addr().String()
+
+				go func() {
+					defer GinkgoRecover()
+					if err := srv.Serve(ln); !errors.Is(err, http.ErrServerClosed) {
+						Expect(err).ToNot(HaveOccurred())
+					}
+				}()
+				DeferCleanup(srv.Close)
+			}
+
+			dial = func() net.Conn {
+				conn, err := net.Dial("tcp", addr)
+				Expect(err).ToNot(HaveOccurred())
+				DeferCleanup(conn.Close)
+				return conn
+			}
+
+			expectClosedByServer = func(conn net.Conn, within time.Duration) {
+				Expect(conn.SetReadDeadline(time.Now().Add(within))).To(Succeed())
+				_, err := io.ReadAll(conn)
+				// the connection is reset if the server closes it while the client still sends data
+				if !errors.Is(err, syscall.ECONNRESET) {
+					Expect(err).ToNot(HaveOccurred(), "server did not close the connection in time")
+				}
+			}
+		)
+
+		BeforeEach(func() {
+			conf = options.ServingConfig{
+				Address:           "127.0.0.1:0",
+				ReadTimeout:       2 * time.Second,
+				ReadHeaderTimeout: 300 * time.Millisecond,
+				WriteTimeout:      2 * time.Second,
+				IdleTimeout:       300 * time.Millisecond,
+				MaxHeaderBytes:    1 << 10,
+			}
+		})
+
+		It("should close connections of clients sending headers slowly", func() {
+			startServer()
+			conn := dial()
+
+			start := time.Now()
+			go func() {
+				// trickle header bytes slower than the header timeout allows
+				_, _ = conn.Write([]byte("GET / HTTP/1.1\r\nHost: localhost\r\n"))
+				for range 20 {
+					time.Sleep(50 * time.Millisecond)
+					if _, err := conn.Write([]byte("X")); err != nil {
+						return
+					}
+				}
+			}()
+
+			expectClosedByServer(conn, 2*time.Second)
+			Expect(time.Since(start)).To(BeNumerically("<", time.Second))
+		})
+
+		It("should close connections that do not send a request", func() {
+			startServer()
+			conn := dial()
+			expectClosedByServer(conn, 2*time.Second)
+		})
+
+		It("should close idle keep-alive connections", func() {
+			startServer()
+			conn := dial()
+
+			_, err := conn.Write([]byte("GET / HTTP/1.1\r\nHost: localhost\r\n\r\n"))
+			Expect(err).ToNot(HaveOccurred())
+			resp, err := http.ReadResponse(bufio.NewReader(conn), nil)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(resp.StatusCode).To(Equal(http.StatusOK))
+
+			expectClosedByServer(conn, 2*time.Second)
+		})
+
+		It("should reject requests with too large headers", func() {
+			startServer()
+			conn := dial()
+
+			_, err := conn.Write([]byte("GET / HTTP/1.1\r\nHost: localhost\r\nX-Large: " + strings.Repeat("a", 8<<10) + "\r\n\r\n"))
+			Expect(err).ToNot(HaveOccurred())
+			resp, err := http.ReadResponse(bufio.NewReader(conn), nil)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(resp.StatusCode).To(Equal(http.StatusRequestHeaderFieldsTooLarge))
+		})
+
+		It("should limit the connections per IP held open by slow clients", func() {
+			conf.ReadHeaderTimeout = 2 * time.Second
+			conf.MaxConnectionsPerIP = 2
+			startServer()
+
+			for range 2 {
+				_, err := dial().Write([]byte("GET / HTTP/1.1\r\n"))
+				Expect(err).ToNot(HaveOccurred())
+			}
+
+			conn := dial()
+			start := time.Now()
+			expectClosedByServer(conn, time.Second)
+			Expect(time.Since(start)).To(BeNumerically("<", 500*time.Millisecond))
+		})
+
+		It("should count connections with pending PROXY protocol header towards the total limit", func() {
+			conf.ReadHeaderTimeout = 2 * time.Second
+			conf.ProxyProtocol = true
+			conf.MaxConnections = 2
+			startServer()
+
+			for range 2 {
+				_, err := dial().Write([]byte("PROXY"))
+				Expect(err).ToNot(HaveOccurred())
+			}
+
+			conn := dial()
+			start := time.Now()
+			expectClosedByServer(conn, time.Second)
+			Expect(time.Since(start)).To(BeNumerically("<", 500*time.Millisecond))
+		})
+	})
+
+	Context("newTLSConfig", func() {
+		var (
+			cert *tls.Certificate
+			addr string
+
+			startTLSServer = func(conf options.ServingConfig) {
+				ln, err := tls.Listen("tcp", "127.0.0.1:0", newTLSConfig(conf, func(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+					return cert, nil
+				}))
+				Expect(err).ToNot(HaveOccurred())
+				DeferCleanup(ln.Close)
+				addr = ln.Addr().String()
+
+				go func() {
+					for {
+						conn, err := ln.Accept()
+						if err != nil {
+							return
+						}
+						go func() {
+							defer conn.Close()
+							_ = conn.(*tls.Conn).Handshake()
+						}()
+					}
+				}()
+			}
+
+			handshake = func(clientConf *tls.Config) (tls.ConnectionState, error) {
+				clientConf.InsecureSkipVerify = true // #nosec G402 -- test only
+				conn, err := tls.Dial("tcp", addr, clientConf)
+				if err != nil {
+					return tls.ConnectionState{}, err
+				}
+				defer conn.Close()
+				return conn.ConnectionState(), nil
+			}
+
+			handshakeCount = func(version, cipherSuite string) float64 {
+				families, err := metrics.Registry.Gather()
+				Expect(err).ToNot(HaveOccurred())
+				for _, f := range families {
+					if f.GetName() != "gardener_discovery_server_tls_handshakes_total" {
+						continue
+					}
+					for _, m := range f.GetMetric() {
+						labels := map[string]string{}
+						for _, l := range m.GetLabel() {
+							labels[l.GetName()] = l.GetValue()
+						}
+						if labels["version"] == version && labels["cipher_suite"] == cipherSuite {
+							return m.GetCounter().GetValue()
+						}
+					}
+				}
+				return 0
+			}
+		)
+
+		BeforeEach(func() {
+			key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+			Expect(err).ToNot(HaveOccurred())
+			template := &x509.Certificate{
+				SerialNumber: big.NewInt(1),
+				Subject:      pkix.Name{CommonName: "localhost"},
+				NotBefore:    time.Now().Add(-time.Hour),
+				NotAfter:     time.Now().Add(time.Hour),
+			}
+			der, err := x509.CreateCertificate(rand.Reader, template, template, key.Public(), key)
+			Expect(err).ToNot(HaveOccurred())
+			cert = &tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key}
+		})
+
+		It("should use the default cipher suites if none are configured", func() {
+			tlsConfig := newTLSConfig(options.ServingConfig{TLSMinVersion: tls.VersionTLS12}, nil)
+			Expect(tlsConfig.MinVersion).To(Equal(uint16(tls.VersionTLS12)))
+			Expect(tlsConfig.CipherSuites).To(Equal(getCipherSuiteIDs()))
+			Expect(tlsConfig.CurvePreferences).To(BeEmpty())
+		})
+
+		It("should reject TLS 1.2 clients in TLS 1.3-only mode", func() {
+			startTLSServer(options.ServingConfig{TLSMinVersion: tls.VersionTLS13})
+
+			_, err := handshake(&tls.Config{MaxVersion: tls.VersionTLS12})
+			Expect(err).To(HaveOccurred())
+
+			state, err := handshake(&tls.Config{MinVersion: tls.VersionTLS13})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(state.Version).To(Equal(uint16(tls.VersionTLS13)))
+		})
+
+		It("should only negotiate the configured cipher suites and curves", func() {
+			startTLSServer(options.ServingConfig{
+				TLSMinVersion:       tls.VersionTLS12,
+				TLSCipherSuites:     []uint16{tls.TLS_ECDHE_ECDSA_WITH_CHACHA20_POLY1305_SHA256},
+				TLSCurvePreferences: []tls.CurveID{tls.CurveP384},
+			})
+
+			_, err := handshake(&tls.Config{
+				MaxVersion:   tls.VersionTLS12,
+				CipherSuites: []uint16{tls.TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256},
+			})
+			Expect(err).To(HaveOccurred())
+
+			_, err = handshake(&tls.Config{
+				MaxVersion:       tls.VersionTLS12,
+				CurvePreferences: []tls.CurveID{tls.X25519},
+			})
+			Expect(err).To(HaveOccurred())
+
+			state, err := handshake(&tls.Config{MaxVersion: tls.VersionTLS12})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(state.CipherSuite).To(Equal(tls.TLS_ECDHE_ECDSA_WITH_CHACHA20_POLY1305_SHA256))
+		})
+
+		It("should record completed handshakes once per connection", func() {
+			conf := options.ServingConfig{TLSMinVersion: tls.VersionTLS13, ReadHeaderTimeout: time.Second}
+			srv := newServer(conf, http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			}), newTLSConfig(conf, func(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+				return cert, nil
+			}))
+			ln, err := net.Listen("tcp", "127.0.0.1:0")
+			Expect(err).ToNot(HaveOccurred())
+			go func() { _ = srv.ServeTLS(ln, "", "") }()
+			DeferCleanup(srv.Close)
+			addr = ln.Addr().String()
+			before := handshakeCount("TLS 1.3", "TLS_AES_128_GCM_SHA256")
+			beforeRejected := handshakeCount("TLS 1.2", "TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256")
+
+			_, err = handshake(&tls.Config{MaxVersion: tls.VersionTLS12})
+			Expect(err).To(HaveOccurred())
+
+			client := &http.Client{Transport: &http.Transport{TLSClientConfig: &tls.Config{
+				InsecureSkipVerify: true, // #nosec G402 -- test only
+				MinVersion:         tls.VersionTLS13,
+				CipherSuites:       []uint16{tls.TLS_AES_128_GCM_SHA256},
+			}}}
+			DeferCleanup(client.CloseIdleConnections)
+			for range 3 {
+				resp, err := client.Get("https://" + addr)
+				Expect(err).ToNot(HaveOccurred())
+				_, _ = io.Copy(io.Discard, resp.Body)
+				Expect(resp.Body.Close()).To(Succeed())
+			}
+
+			Expect(handshakeCount("TLS 1.3", "TLS_AES_128_GCM_SHA256")).To(Equal(before + 1))
+			Expect(handshakeCount("TLS 1.2", "TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256")).To(Equal(beforeRejected))
+		})
+	})
+
+	Context("runServer", func() {
+		It("should serve on all listeners with the client address from the PROXY protocol and shut them down together", func() {
+			conf := options.ServingConfig{
+				Address:           "127.0.0.1:0",
+				HTTPAddress:       "127.0.0.1:0",
+				HTTPAllowedCIDRs:  []netip.Prefix{netip.MustParsePrefix("127.0.0.0/8")},
+				ProxyProtocol:     true,
+				ReadTimeout:       2 * time.Second,
+				ReadHeaderTimeout: time.Second,
+				WriteTimeout:      2 * time.Second,
+				IdleTimeout:       time.Second,
+				MaxHeaderBytes:    1 << 10,
+			}
+			srv := newServer(conf, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_, _ = w.Write([]byte(r.RemoteAddr))
+			}), &tls.Config{
+				MinVersion: tls.VersionTLS12,
+				GetCertificate: func(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+					return nil, errors.New("not used")
+				},
+			})
+
+			listeners, err := newListeners(conf, logr.Discard())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(listeners).To(HaveLen(2))
+			Expect(listeners[0].tls).To(BeTrue())
+			Expect(listeners[1].tls).To(BeFalse())
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			done := make(chan error)
+			go func() {
+				done <- runServer(ctx, logr.Discard(), srv, listeners...)
+			}()
+
+			conn, err := net.Dial("tcp", listeners[1].listener.Addr().String())
+			Expect(err).ToNot(HaveOccurred())
+			defer conn.Close()
+			_, err = conn.Write([]byte("PROXY TCP4 192.0.2.1 198.51.100.1 56324 80\r\nGET / HTTP/1.1\r\nHost: localhost\r\n\r\n"))
+			Expect(err).ToNot(HaveOccurred())
+			resp, err := http.ReadResponse(bufio.NewReader(conn), nil)
+			Expect(err).ToNot(HaveOccurred())
+			body, err := io.ReadAll(io.LimitReader(resp.Body, 64))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(body)).To(Equal("192.0.2.1:56324"))
+
+			cancel()
+			Eventually(done).Should(Receive(BeNil()))
+			for _, l := range listeners {
+				_, err := net.DialTimeout("tcp", l.listener.Addr().String(), time.Second)
+				Expect(err).To(HaveOccurred(), "listener %s should be closed", l.name)
+			}
+		})
+	})
+
+	Context("newAdminServer", func() {
+		It("should serve the admin API over plain HTTP on a loopback address", func() {
+			s, err := store.NewStore(func(s string) string { return s })
+			Expect(err).ToNot(HaveOccurred())
+			s.Write("foo--bar--uid", "data")
+			conf := &options.Config{
+				Serving: options.ServingConfig{ReadHeaderTimeout: time.Second},
+				Admin:   options.AdminConfig{Address: "127.0.0.1:0"},
+			}
+
+			srv, ln, err := newAdminServer(conf, map[string]store.Inspector{"test": s}, nil, nil, nil, nil, logr.Discard())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(ln.tls).To(BeFalse())
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			done := make(chan error)
+			go func() {
+				done <- runServer(ctx, logr.Discard(), srv, ln)
+			}()
+
+			resp, err := http.Get("http://" + ln.listener.Addr().String() + "/admin/stores/test/entries?project=foo")
+			Expect(err).ToNot(HaveOccurred())
+			defer resp.Body.Close()
+			Expect(resp.StatusCode).To(Equal(http.StatusOK))
+			body, err := io.ReadAll(resp.Body)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(body)).To(ContainSubstring(`"key":"foo--bar--uid"`))
+
+			cancel()
+			Eventually(done).Should(Receive(BeNil()))
+		})
+
+		It("should fail if the client CA bundle does not contain a certificate", func() {
+			conf := &options.Config{Admin: options.AdminConfig{Address: "127.0.0.1:0", ClientCA: []byte("foo")}}
+			_, _, err := newAdminServer(conf, nil, nil, nil, nil, nil, logr.Discard())
+			Expect(err).To(MatchError(ContainSubstring("does not contain any certificate")))
+		})
+	})
+	Context("newHealthHandler", func() {
+		var (
+			foo, bar *garden
+			h        http.Handler
+		)
+
+		BeforeEach(func() {
+			synced := func(*http.Request) error { return nil }
+			foo = &garden{name: "foo", synced: synced}
+			bar = &garden{name: "bar", synced: synced}
+			h = newHealthHandler([]*garden{foo, bar})
+		})
+
+		get := func(path string) int {
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+			return rec.Code
+		}
+
+		It("should be ready if the caches of all gardens are synced", func() {
+			Expect(get("/healthz")).To(Equal(http.StatusOK))
+			Expect(get("/readyz")).To(Equal(http.StatusOK))
+			Expect(get("/readyz/foo")).To(Equal(http.StatusOK))
+			Expect(get("/readyz/bar")).To(Equal(http.StatusOK))
+		})
+
+		It("should not be ready if the cache of a garden is not synced", func() {
+			bar.synced = func(*http.Request) error { return errors.New("not synced") }
+			Expect(get("/readyz")).To(Equal(http.StatusInternalServerError))
+			Expect(get("/readyz/foo")).To(Equal(http.StatusOK))
+			Expect(get("/readyz/bar")).To(Equal(http.StatusInternalServerError))
+		})
+
+		It("should ignore restarting and failed gardens in the overall readiness", func() {
+			bar.setState(gardenRestarting)
+			Expect(get("/readyz")).To(Equal(http.StatusOK))
+			Expect(get("/readyz/bar")).To(Equal(http.StatusInternalServerError))
+
+			bar.setState(gardenFailed)
+			Expect(get("/readyz")).To(Equal(http.StatusOK))
+			Expect(get("/readyz/bar")).To(Equal(http.StatusInternalServerError))
+		})
+
+		It("should report restarting and failed gardens in the garden health", func() {
+			Expect(get("/gardenz")).To(Equal(http.StatusOK))
+
+			bar.setState(gardenRestarting)
+			Expect(get("/gardenz")).To(Equal(http.StatusInternalServerError))
+
+			bar.setState(gardenFailed)
+			Expect(get("/gardenz")).To(Equal(http.StatusInternalServerError))
+			Expect(get("/gardenz/foo")).To(Equal(http.StatusOK))
+			Expect(get("/gardenz/bar")).To(Equal(http.StatusInternalServerError))
+		})
+	})
 })
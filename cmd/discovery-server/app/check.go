@@ -0,0 +1,315 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gardener/gardener/pkg/logger"
+	"github.com/go-logr/logr"
+	"github.com/spf13/cobra"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/wait"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/gardener/gardener-discovery-server/cmd/discovery-server/app/options"
+	"github.com/gardener/gardener-discovery-server/internal/conformance"
+	"github.com/gardener/gardener-discovery-server/internal/handler/admin"
+	"github.com/gardener/gardener-discovery-server/internal/store"
+	"github.com/gardener/gardener-discovery-server/internal/utils"
+)
+
+// checkParallelism is the number of issuers checked concurrently.
+const checkParallelism = 8
+
+// newCheckCommand returns the command checking the conformance of the published issuers with OpenID Connect Discovery 1.0.
+func newCheckCommand() *cobra.Command {
+	checkOpt := &options.CheckOptions{}
+	serverOpt := options.NewOptions()
+
+	cmd := &cobra.Command{
+		Use:   "check",
+		Short: "Check the conformance of the published issuers with OpenID Connect Discovery 1.0",
+		Long: `Check the conformance of the published issuers with OpenID Connect Discovery 1.0.
+
+The issuers are checked against a deployed discovery server at --base-url or, with --in-process,
+against a discovery server started in-process with the server flags. All published issuers are
+enumerated from the admin API or the in-process server unless --issuer is set.
+The command fails if any check failed.`,
+		SilenceUsage: true,
+		PreRunE: func(_ *cobra.Command, _ []string) error {
+			errs := checkOpt.Validate()
+			if checkOpt.InProcess {
+				errs = append(errs, serverOpt.Validate()...)
+			}
+			return utilerrors.NewAggregate(errs)
+		},
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			log, err := logger.NewZapLogger("info", "json")
+			if err != nil {
+				return fmt.Errorf("error instantiating zap logger: %w", err)
+			}
+			logf.SetLogger(log)
+
+			conf := &options.CheckConfig{}
+			if err := checkOpt.ApplyTo(conf); err != nil {
+				return fmt.Errorf("cannot apply options: %w", err)
+			}
+			var serverConf *options.Config
+			if conf.InProcess {
+				serverConf = &options.Config{}
+				if err := serverOpt.ApplyTo(serverConf); err != nil {
+					return fmt.Errorf("cannot apply options: %w", err)
+				}
+			}
+
+			return runCheck(cmd.Context(), log, conf, serverConf, cmd.OutOrStdout())
+		},
+	}
+
+	fs := cmd.Flags()
+	checkOpt.AddFlags(fs)
+	serverOpt.AddFlags(fs)
+	fs.AddGoFlagSet(flag.CommandLine)
+
+	return cmd
+}
+
+// runCheck checks the issuers and writes the results. It returns an error if any check failed.
+func runCheck(ctx context.Context, log logr.Logger, conf *options.CheckConfig, serverConf *options.Config, stdout io.Writer) error {
+	ctx, cancel := context.WithTimeout(ctx, conf.Timeout)
+	defer cancel()
+
+	client, err := newClient(conf.Client)
+	if err != nil {
+		return err
+	}
+
+	var results []conformance.Result
+	if conf.InProcess {
+		ds, err := newDiscoveryServer(serverConf, log)
+		if err != nil {
+			return err
+		}
+		client.Transport = handlerTransport{handler: ds.handler}
+
+		// The checks run as the only server, the managers of the gardens are stopped once they are done.
+		if err := runGardens(ctx, log, ds.gardens, func(ctx context.Context) error {
+			log.Info("Waiting for the in-process discovery server")
+			if err := waitForGardens(ctx, ds.gardens, ds.stores); err != nil {
+				return fmt.Errorf("in-process discovery server did not become ready: %w", err)
+			}
+			issuers := conf.Issuers
+			if len(issuers) == 0 {
+				issuers = storeIssuers(conf.BaseURL, ds.stores)
+			}
+			results = checkIssuers(ctx, log, client, conf.MaxAge, issuers)
+			return nil
+		}); err != nil {
+			return err
+		}
+	} else {
+		issuers := conf.Issuers
+		if len(issuers) == 0 {
+			if issuers, err = adminIssuers(ctx, client, conf); err != nil {
+				return err
+			}
+		}
+		results = checkIssuers(ctx, log, client, conf.MaxAge, issuers)
+	}
+
+	if err := writeResults(conf, stdout, results); err != nil {
+		return err
+	}
+
+	if len(results) == 0 {
+		return errors.New("no published issuers found")
+	}
+	if summary := conformance.Summarize(results); summary.Failed > 0 {
+		return fmt.Errorf("%d of %d checks failed", summary.Failed, summary.Passed+summary.Failed+summary.Skipped)
+	}
+	return nil
+}
+
+// checkIssuers checks the issuers concurrently, the results are sorted by issuer.
+func checkIssuers(ctx context.Context, log logr.Logger, client *http.Client, maxAge time.Duration, issuers []string) []conformance.Result {
+	issuers = slices.Compact(slices.Sorted(slices.Values(issuers)))
+	log.Info("Checking issuers", "count", len(issuers))
+
+	var (
+		checker = &conformance.Checker{Client: client, MaxAge: maxAge}
+		results = make([]conformance.Result, len(issuers))
+		sem     = make(chan struct{}, checkParallelism)
+		wg      sync.WaitGroup
+	)
+	for i, issuer := range issuers {
+		sem <- struct{}{}
+		wg.Go(func() {
+			defer func() { <-sem }()
+			results[i] = checker.Check(ctx, issuer)
+			if results[i].Failed() {
+				log.Info("Issuer is not conformant", "issuer", issuer)
+			}
+		})
+	}
+	wg.Wait()
+	return results
+}
+
+// writeResults writes the results in the configured format to the output file or stdout.
+func writeResults(conf *options.CheckConfig, stdout io.Writer, results []conformance.Result) (err error) {
+	w := stdout
+	if conf.OutputFile != "" {
+		f, err := os.Create(conf.OutputFile)
+		if err != nil {
+			return fmt.Errorf("failed to create output file: %w", err)
+		}
+		defer func() { err = errors.Join(err, f.Close()) }()
+		w = f
+	}
+
+	if conf.OutputFormat == options.OutputFormatJSON {
+		return conformance.WriteJSON(w, results)
+	}
+	return conformance.WriteJUnit(w, results)
+}
+
+// waitForGardens waits until the caches of all running gardens are synced and the number of entries of the stores
+// did not change for a few polls, i.e. the initial reconciliation of the published resources is done.
+func waitForGardens(ctx context.Context, gardens []*garden, stores map[string]store.Inspector) error {
+	const (
+		interval    = 500 * time.Millisecond
+		stablePolls = 3
+	)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "/readyz", nil)
+	if err != nil {
+		return err
+	}
+
+	var (
+		lastCount = -1
+		stable    int
+	)
+	return wait.PollUntilContextCancel(ctx, interval, true, func(context.Context) (bool, error) {
+		running := 0
+		for _, g := range gardens {
+			if state := g.getState(); state == gardenFailed || state == gardenStopped {
+				continue
+			}
+			running++
+			if g.ready(req) != nil {
+				return false, nil
+			}
+		}
+		if running == 0 {
+			return false, errors.New("managers of all gardens stopped")
+		}
+
+		count := 0
+		for _, s := range stores {
+			count += len(s.List("")) + len(s.Rejections(""))
+		}
+		if count != lastCount {
+			lastCount, stable = count, 0
+			return false, nil
+		}
+		stable++
+		return stable >= stablePolls, nil
+	})
+}
+
+// storeIssuers returns the issuers published by the stores of the in-process discovery server.
+func storeIssuers(baseURL string, stores map[string]store.Inspector) []string {
+	var issuers []string
+	for name, s := range stores {
+		if !isIssuerStore(name) {
+			continue
+		}
+		var keys []string
+		for _, m := range s.List("") {
+			keys = append(keys, m.Key)
+		}
+		issuers = append(issuers, issuersOfStore(baseURL, name, keys)...)
+	}
+	return issuers
+}
+
+// adminIssuers returns the issuers published by the stores listed by the admin API.
+func adminIssuers(ctx context.Context, client *http.Client, conf *options.CheckConfig) ([]string, error) {
+	var stores admin.ListResponse[string]
+	if err := adminRequest(ctx, client, conf.Client, http.MethodGet, "/admin/stores", nil, nil, &stores); err != nil {
+		return nil, err
+	}
+
+	var issuers []string
+	for _, name := range stores.Items {
+		if !isIssuerStore(name) {
+			continue
+		}
+		var (
+			keys  []string
+			query = url.Values{"limit": {"1000"}}
+		)
+		for {
+			var entries admin.ListResponse[store.Metadata]
+			if err := adminRequest(ctx, client, conf.Client, http.MethodGet, "/admin/stores/"+url.PathEscape(name)+"/entries", query, nil, &entries); err != nil {
+				return nil, err
+			}
+			for _, m := range entries.Items {
+				keys = append(keys, m.Key)
+			}
+			if entries.Continue == "" {
+				break
+			}
+			query.Set("continue", entries.Continue)
+		}
+		issuers = append(issuers, issuersOfStore(conf.BaseURL, name, keys)...)
+	}
+	return issuers, nil
+}
+
+// isIssuerStore reports whether the store with the given admin API name holds the documents of issuers.
+func isIssuerStore(name string) bool {
+	if name == workloadIdentityStoreName || name == openIDMetaStoreName {
+		return true
+	}
+	_, resource, qualified := strings.Cut(name, ".")
+	return qualified && resource == openIDMetaStoreName
+}
+
+// issuersOfStore returns the issuer URLs of the entries of the store with the given admin API name.
+// The shoot issuers of gardens other than the default one are served below their garden path prefix.
+func issuersOfStore(baseURL, name string, keys []string) []string {
+	baseURL = strings.TrimSuffix(baseURL, "/")
+	if name == workloadIdentityStoreName {
+		return []string{baseURL + workloadIdentityIssuerPath}
+	}
+
+	prefix := ""
+	if gardenName, _, qualified := strings.Cut(name, "."); qualified {
+		prefix = gardenPathPrefix(gardenName)
+	}
+	issuers := make([]string, 0, len(keys))
+	for _, key := range keys {
+		projectName, shootUID, err := utils.SplitProjectNameAndShootUID(key)
+		if err != nil {
+			continue
+		}
+		issuers = append(issuers, baseURL+prefix+"/projects/"+projectName+"/shoots/"+shootUID+"/issuer")
+	}
+	return issuers
+}
@@ -0,0 +1,208 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/go-jose/go-jose/v4"
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/gardener/gardener-discovery-server/cmd/discovery-server/app/options"
+	"github.com/gardener/gardener-discovery-server/internal/conformance"
+	"github.com/gardener/gardener-discovery-server/internal/handler/admin"
+	"github.com/gardener/gardener-discovery-server/internal/store"
+	"github.com/gardener/gardener-discovery-server/internal/store/certificate"
+	"github.com/gardener/gardener-discovery-server/internal/store/openidmeta"
+)
+
+var _ = Describe("Check", func() {
+	const shootUID = "a8c33bc6-36b1-4d4c-a8b1-5e5cb4e1d4a9"
+
+	Context("issuersOfStore", func() {
+		keys := []string{"foo--" + shootUID, "invalid"}
+
+		It("should return the shoot issuers of the default garden", func() {
+			Expect(isIssuerStore("openidmeta")).To(BeTrue())
+			Expect(issuersOfStore("https://example.com/", "openidmeta", keys)).To(ConsistOf(
+				"https://example.com/projects/foo/shoots/" + shootUID + "/issuer",
+			))
+		})
+
+		It("should return the shoot issuers of other gardens below their path prefix", func() {
+			Expect(isIssuerStore("bar.openidmeta")).To(BeTrue())
+			Expect(issuersOfStore("https://example.com", "bar.openidmeta", keys)).To(ConsistOf(
+				"https://example.com/gardens/bar/projects/foo/shoots/" + shootUID + "/issuer",
+			))
+		})
+
+		It("should return the workload identity issuer", func() {
+			Expect(isIssuerStore("workloadidentity")).To(BeTrue())
+			Expect(issuersOfStore("https://example.com", "workloadidentity", []string{"garden"})).To(ConsistOf(
+				"https://example.com/garden/workload-identity/issuer",
+			))
+		})
+
+		It("should not consider other stores", func() {
+			Expect(isIssuerStore("certificate")).To(BeFalse())
+			Expect(isIssuerStore("bar.certificate")).To(BeFalse())
+		})
+	})
+
+	Context("handlerTransport", func() {
+		It("should serve the requests with the handler", func() {
+			client := &http.Client{Transport: handlerTransport{handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				Expect(r.RequestURI).To(Equal("/foo?bar=baz"))
+				w.WriteHeader(http.StatusTeapot)
+			})}}
+			resp, err := client.Get("https://example.com/foo?bar=baz")
+			Expect(err).NotTo(HaveOccurred())
+			defer resp.Body.Close()
+			Expect(resp.StatusCode).To(Equal(http.StatusTeapot))
+		})
+	})
+
+	Context("waitForGardens", func() {
+		var (
+			ctx    context.Context
+			gdn    *garden
+			stores map[string]store.Inspector
+		)
+
+		BeforeEach(func() {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(context.Background(), 10*time.Second)
+			DeferCleanup(cancel)
+
+			gdn = &garden{name: "foo", synced: func(*http.Request) error { return nil }}
+			s := store.MustNewStore(openidmeta.Copy)
+			s.Write("foo--"+shootUID, openidmeta.Data{})
+			stores = map[string]store.Inspector{"openidmeta": s}
+		})
+
+		It("should return once the gardens are synced and the stores settled", func() {
+			Expect(waitForGardens(ctx, []*garden{gdn}, stores)).To(Succeed())
+		})
+
+		It("should fail if all gardens stopped", func() {
+			gdn.setState(gardenFailed)
+			Expect(waitForGardens(ctx, []*garden{gdn}, stores)).To(MatchError("managers of all gardens stopped"))
+		})
+
+		It("should fail if the gardens do not sync in time", func() {
+			gdn.synced = func(*http.Request) error { return errors.New("not synced") }
+			ctx, cancel := context.WithTimeout(ctx, time.Second)
+			defer cancel()
+			Expect(waitForGardens(ctx, []*garden{gdn}, stores)).To(MatchError(context.DeadlineExceeded))
+		})
+	})
+
+	Context("runCheck", func() {
+		var (
+			discoveryServer *httptest.Server
+			oidStore        *store.Store[openidmeta.Data]
+			conf            *options.CheckConfig
+			out             *bytes.Buffer
+		)
+
+		BeforeEach(func() {
+			key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+			Expect(err).NotTo(HaveOccurred())
+			jwks, err := json.Marshal(jose.JSONWebKeySet{Keys: []jose.JSONWebKey{{Key: &key.PublicKey, KeyID: "key", Algorithm: "ES256", Use: "sig"}}})
+			Expect(err).NotTo(HaveOccurred())
+
+			issuerPath := "/projects/foo/shoots/" + shootUID + "/issuer"
+			mux := http.NewServeMux()
+			discoveryServer = httptest.NewTLSServer(mux)
+			DeferCleanup(discoveryServer.Close)
+			mux.HandleFunc("GET "+issuerPath+"/.well-known/openid-configuration", func(w http.ResponseWriter, _ *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				w.Header().Set("Cache-Control", "public, max-age=3600")
+				Expect(json.NewEncoder(w).Encode(map[string]any{
+					"issuer":                                discoveryServer.URL + issuerPath,
+					"jwks_uri":                              discoveryServer.URL + issuerPath + "/jwks",
+					"response_types_supported":              []string{"id_token"},
+					"subject_types_supported":               []string{"public"},
+					"id_token_signing_alg_values_supported": []string{"ES256"},
+				})).To(Succeed())
+			})
+			mux.HandleFunc("GET "+issuerPath+"/jwks", func(w http.ResponseWriter, _ *http.Request) {
+				w.Header().Set("Content-Type", "application/jwk-set+json")
+				w.Header().Set("Cache-Control", "public, max-age=3600")
+				_, _ = w.Write(jwks)
+			})
+
+			oidStore = store.MustNewStore(openidmeta.Copy)
+			oidStore.Write("foo--"+shootUID, openidmeta.Data{})
+			certStore := store.MustNewStore(certificate.Copy)
+			certStore.Write("bar--"+shootUID, certificate.Data{})
+			adminHandler := admin.New(map[string]store.Inspector{"openidmeta": oidStore, "certificate": certStore}, nil, logr.Discard())
+			adminMux := http.NewServeMux()
+			adminMux.Handle("/admin/stores", adminHandler.HandleStores())
+			adminMux.Handle("/admin/stores/{store}/entries", adminHandler.HandleEntries())
+			adminServer := httptest.NewServer(adminMux)
+			DeferCleanup(adminServer.Close)
+
+			conf = &options.CheckConfig{
+				Client: options.ClientConfig{
+					AdminURL:       adminServer.URL,
+					CA:             pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: discoveryServer.Certificate().Raw}),
+					RequestTimeout: 5 * time.Second,
+				},
+				BaseURL:      discoveryServer.URL,
+				OutputFormat: options.OutputFormatJSON,
+				Timeout:      time.Minute,
+				MaxAge:       24 * time.Hour,
+			}
+			out = &bytes.Buffer{}
+		})
+
+		report := func() []conformance.Result {
+			var r struct {
+				Results []conformance.Result `json:"results"`
+			}
+			Expect(json.Unmarshal(out.Bytes(), &r)).To(Succeed())
+			return r.Results
+		}
+
+		It("should check the issuers enumerated by the admin API", func() {
+			Expect(runCheck(context.Background(), logr.Discard(), conf, nil, out)).To(Succeed())
+			results := report()
+			Expect(results).To(HaveLen(1))
+			Expect(results[0].Issuer).To(Equal(discoveryServer.URL + "/projects/foo/shoots/" + shootUID + "/issuer"))
+			Expect(results[0].Failed()).To(BeFalse())
+		})
+
+		It("should fail if an issuer is not conformant", func() {
+			oidStore.Write("bar--"+shootUID, openidmeta.Data{})
+			Expect(runCheck(context.Background(), logr.Discard(), conf, nil, out)).To(MatchError("1 of 12 checks failed"))
+			Expect(report()).To(HaveLen(2))
+		})
+
+		It("should only check the given issuers", func() {
+			conf.Client.AdminURL = ""
+			conf.Issuers = []string{discoveryServer.URL + "/projects/foo/shoots/" + shootUID + "/issuer"}
+			Expect(runCheck(context.Background(), logr.Discard(), conf, nil, out)).To(Succeed())
+			Expect(report()).To(HaveLen(1))
+		})
+
+		It("should fail if no issuer is published", func() {
+			oidStore.Delete("foo--" + shootUID)
+			Expect(runCheck(context.Background(), logr.Discard(), conf, nil, out)).To(MatchError("no published issuers found"))
+		})
+	})
+})
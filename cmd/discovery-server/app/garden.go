@@ -0,0 +1,490 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gardener/gardener/pkg/client/kubernetes"
+	gardenerhealthz "github.com/gardener/gardener/pkg/healthz"
+	"github.com/go-logr/logr"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	controllerconfig "sigs.k8s.io/controller-runtime/pkg/config"
+	"sigs.k8s.io/controller-runtime/pkg/healthz"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
+
+	"github.com/gardener/gardener-discovery-server/cmd/discovery-server/app/options"
+	discoverycache "github.com/gardener/gardener-discovery-server/internal/cache"
+	"github.com/gardener/gardener-discovery-server/internal/dynamiccert"
+	"github.com/gardener/gardener-discovery-server/internal/handler"
+	"github.com/gardener/gardener-discovery-server/internal/handler/admin"
+	"github.com/gardener/gardener-discovery-server/internal/handler/federation"
+	"github.com/gardener/gardener-discovery-server/internal/handler/spiffe"
+	"github.com/gardener/gardener-discovery-server/internal/metrics"
+	"github.com/gardener/gardener-discovery-server/internal/publisher"
+	certificatereconciler "github.com/gardener/gardener-discovery-server/internal/reconciler/certificate"
+	clusterinforeconciler "github.com/gardener/gardener-discovery-server/internal/reconciler/clusterinfo"
+	oidreconciler "github.com/gardener/gardener-discovery-server/internal/reconciler/openidmeta"
+	"github.com/gardener/gardener-discovery-server/internal/sharding"
+	"github.com/gardener/gardener-discovery-server/internal/signing"
+	"github.com/gardener/gardener-discovery-server/internal/store"
+	"github.com/gardener/gardener-discovery-server/internal/store/certificate"
+	"github.com/gardener/gardener-discovery-server/internal/store/clusterinfo"
+	"github.com/gardener/gardener-discovery-server/internal/store/openidmeta"
+	spiffestore "github.com/gardener/gardener-discovery-server/internal/store/spiffe"
+)
+
+// garden holds the manager and the stores of a garden cluster.
+type garden struct {
+	name      string
+	isDefault bool
+	stores    map[string]store.Inspector
+	breakers  map[string]admin.Breaker
+	// issuers holds the documents of the shoot issuers of the garden.
+	issuers *store.Store[openidmeta.Data]
+	// client reads and writes directly from and to the garden, it is not bound to the lifetime of a manager.
+	client client.Client
+
+	// newManager creates a manager running the reconcilers of the published resources on the stores of the garden.
+	// It is called again to replace a failed manager, the stores and the routes of the garden are kept.
+	newManager func(restart bool) (manager.Manager, healthz.Checker, error)
+	// setups set up the reconcilers of the published resources with a manager.
+	setups []func(manager.Manager) error
+	// restartDelay is the delay before the first restart of a failed manager, it doubles with every further restart.
+	restartDelay time.Duration
+
+	mutex  sync.RWMutex
+	mgr    manager.Manager
+	synced healthz.Checker
+	state  atomic.Int32
+}
+
+// gardenState is the state of the manager of a garden.
+type gardenState int32
+
+const (
+	// gardenRunning is the state of a garden whose manager is running.
+	gardenRunning gardenState = iota
+	// gardenRestarting is the state of a garden whose manager failed and is replaced, the caches of the new one are not yet synced.
+	gardenRestarting
+	// gardenFailed is the state of a garden whose manager could not be restarted.
+	gardenFailed
+	// gardenStopped is the state of a garden whose manager stopped because the discovery server shuts down.
+	gardenStopped
+)
+
+const (
+	// defaultRestartDelay is the delay before the first restart of a failed manager.
+	defaultRestartDelay = 5 * time.Second
+	// maxRestartDelay caps the delay between the restarts of a failed manager.
+	maxRestartDelay = 2 * time.Minute
+	// maxRestarts is the number of times a failed manager is restarted before the garden is marked as failed.
+	maxRestarts = 5
+	// restartResetAfter is the duration a manager has to run for until its restarts are no longer counted.
+	restartResetAfter = 10 * time.Minute
+)
+
+// gardenDeps are the dependencies shared by all gardens.
+type gardenDeps struct {
+	conf         *options.Config
+	signer       signing.Signer
+	certificates *dynamiccert.SNICertificate
+	// peerTransport forwards requests to other replicas, it is nil if sharding is disabled.
+	peerTransport http.RoundTripper
+	mux           *http.ServeMux
+	log           logr.Logger
+}
+
+// newGarden creates the manager of the garden, sets up the reconcilers of the published resources
+// and registers the routes of their documents below /gardens/{name}.
+// The routes of the default garden are registered without prefix as well.
+func newGarden(g options.Garden, deps gardenDeps) (*garden, error) {
+	var (
+		conf = deps.conf
+		log  = deps.log.WithValues("garden", g.Name)
+	)
+
+	cfg, err := restConfig(g)
+	if err != nil {
+		return nil, fmt.Errorf("unable to load configuration of garden %s: %w", g.Name, err)
+	}
+
+	c, err := client.New(cfg, client.Options{Scheme: kubernetes.GardenScheme})
+	if err != nil {
+		return nil, fmt.Errorf("unable to create client of garden %s: %w", g.Name, err)
+	}
+
+	out := &garden{
+		name:         g.Name,
+		isDefault:    g.Name == conf.Garden.Default,
+		stores:       map[string]store.Inspector{},
+		breakers:     map[string]admin.Breaker{},
+		client:       c,
+		restartDelay: defaultRestartDelay,
+	}
+
+	// shard stays nil if sharding is disabled, so that all projects are reconciled and served locally.
+	var (
+		shard      sharding.Shard
+		membership *sharding.Membership
+	)
+	forward := func(h http.Handler) http.Handler { return h }
+	if conf.Sharding.Enabled {
+		membership = sharding.NewMembership(c, c, sharding.Config{
+			Namespace:     conf.Sharding.LeaseNamespace,
+			Identity:      conf.Sharding.Identity,
+			Address:       conf.Sharding.AdvertiseAddress,
+			LeaseDuration: conf.Sharding.LeaseDuration,
+			Garden:        g.Name,
+		}, log.WithName("sharding"))
+		shard = membership
+
+		forward = func(h http.Handler) http.Handler {
+			return sharding.Forward(shard, conf.Sharding.Identity, deps.peerTransport, h, log.WithName("sharding"))
+		}
+	}
+
+	unavailableLog := log.WithName("unavailable")
+	registryOpts := []publisher.RegistryOption{
+		publisher.WithWrapper(func(path string, h http.Handler) http.Handler {
+			return instrument(path, out.available(forward(h), unavailableLog))
+		}),
+		publisher.WithCORS(corsPolicies(conf.CORS).Shoot),
+	}
+	if conf.ShootNameAlias.Enabled {
+		registryOpts = append(registryOpts, publisher.WithShootNameAliases(conf.ShootNameAlias.Redirect))
+	}
+	routes := []*publisher.Registry{
+		publisher.NewRegistry(deps.mux, cachePolicies(conf.Cache), log.WithName("document-handler"), append(registryOpts, publisher.WithPathPrefix(gardenPathPrefix(g.Name)))...),
+	}
+	if out.isDefault {
+		routes = append(routes, publisher.NewRegistry(deps.mux, cachePolicies(conf.Cache), log.WithName("document-handler"), registryOpts...))
+	}
+
+	pub := publishing{
+		garden: out,
+		conf:   conf,
+		shard:  shard,
+		signer: deps.signer,
+		routes: routes,
+		log:    log,
+	}
+	oidStore := publish(pub, openIDMetaStoreName, oidreconciler.Resource{}, openidmeta.Copy)
+	out.issuers = oidStore
+	certStore := publish(pub, "certificate", certificatereconciler.Resource{}, certificate.Copy)
+
+	federationHandler := federation.New(oidStore, deps.certificates, deps.signer, cachePolicies(conf.Cache), log.WithName("federation-handler"))
+	bundleStore := store.MustNewStore(spiffestore.Copy)
+	spiffe.NewComposer(oidStore, certStore, bundleStore, cachePolicies(conf.Cache), deps.signer, log.WithName("spiffe-bundle-composer"))
+	out.stores[out.storeName("spiffe")] = bundleStore
+	metrics.RegisterStore(out.name, "spiffe", bundleStore)
+	spiffeHandler := spiffe.New(bundleStore, cachePolicies(conf.Cache), log.WithName("spiffe-bundle-handler"))
+	for _, r := range routes {
+		r.Handle(publisher.ShootPath+"/issuer/federation", federationHandler.HandleShoot())
+		r.Handle(publisher.ShootPath+"/spiffe-bundle", spiffeHandler.HandleBundle())
+	}
+
+	if conf.ClusterInfo.Enabled {
+		infoStore := publish(pub, "clusterinfo", clusterinforeconciler.Resource{}, clusterinfo.Copy)
+		kubeconfigStore := store.MustNewStore(clusterinfo.CopyKubeconfig)
+		clusterinforeconciler.NewComposer(infoStore, certStore, kubeconfigStore, deps.signer, log.WithName("cluster-info-composer"))
+		out.stores[out.storeName("clusterinfo-kubeconfig")] = kubeconfigStore
+		metrics.RegisterStore(out.name, "clusterinfo-kubeconfig", kubeconfigStore)
+		for _, r := range routes {
+			publisher.Register(r, kubeconfigStore, clusterinforeconciler.KubeconfigDocuments()...)
+		}
+	}
+
+	out.newManager = func(restart bool) (manager.Manager, healthz.Checker, error) {
+		mgr, err := ctrl.NewManager(cfg, ctrl.Options{
+			Logger: log.WithName("manager"),
+			Scheme: kubernetes.GardenScheme,
+			// The metrics and the health probes of all gardens are served together.
+			Metrics: metricsserver.Options{
+				BindAddress: "0",
+			},
+			GracefulShutdownTimeout: ptr.To(10 * time.Second),
+			LeaderElection:          false,
+			PprofBindAddress:        "",
+			HealthProbeBindAddress:  "",
+			// The cache is not restricted to the projects of the shard, the ownership changes with the members
+			// and the reconcilers only skip the projects of other replicas.
+			Cache: discoverycache.Options(),
+			Controller: controllerconfig.Controller{
+				RecoverPanic: ptr.To(true),
+				// The controllers of a restarted manager reuse the names of the ones of the failed manager.
+				SkipNameValidation: ptr.To(restart),
+			},
+		})
+		if err != nil {
+			return nil, nil, fmt.Errorf("unable to create manager of garden %s: %w", g.Name, err)
+		}
+
+		synced := gardenerhealthz.NewCacheSyncHealthz(mgr.GetCache())
+		if membership != nil {
+			if err := mgr.Add(membership); err != nil {
+				return nil, nil, fmt.Errorf("unable to add shard membership: %w", err)
+			}
+			cacheSynced := synced
+			synced = func(req *http.Request) error {
+				if err := cacheSynced(req); err != nil {
+					return err
+				}
+				return membership.Ready(req)
+			}
+		}
+
+		for _, setup := range out.setups {
+			if err := setup(mgr); err != nil {
+				return nil, nil, err
+			}
+		}
+
+		// Runnables without leader election are started once the caches are synced, a restarted garden is served again from then on.
+		if err := mgr.Add(manager.RunnableFunc(func(context.Context) error {
+			if out.state.CompareAndSwap(int32(gardenRestarting), int32(gardenRunning)) {
+				metrics.SetGardenUp(out.name, true)
+			}
+			return nil
+		})); err != nil {
+			return nil, nil, fmt.Errorf("unable to add garden state runnable: %w", err)
+		}
+		return mgr, synced, nil
+	}
+
+	out.mgr, out.synced, err = out.newManager(false)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// restConfig returns the configuration of the garden, the in-cluster or --kubeconfig configuration is used if the garden has no kubeconfig.
+func restConfig(g options.Garden) (*rest.Config, error) {
+	if g.Kubeconfig == "" {
+		return ctrl.GetConfig()
+	}
+	return clientcmd.BuildConfigFromFlags("", g.Kubeconfig)
+}
+
+// openIDMetaStoreName is the name of the store of the shoot issuers, see [garden.storeName] for its name in the admin API.
+const openIDMetaStoreName = "openidmeta"
+
+// gardenPathPrefix returns the path prefix of the documents of the garden.
+func gardenPathPrefix(name string) string {
+	return "/gardens/" + name
+}
+
+// storeName returns the name of the store in the admin API.
+// The stores of the default garden keep their name, the ones of other gardens are qualified with the garden name.
+func (g *garden) storeName(name string) string {
+	if g.isDefault {
+		return name
+	}
+	return g.name + "." + name
+}
+
+// controllerName returns the name of the controller, it has to be unique across the gardens.
+func (g *garden) controllerName(name string) string {
+	if g.isDefault {
+		return name
+	}
+	return name + "-" + g.name
+}
+
+// run runs the manager of the garden until the context is canceled.
+// A failed manager is replaced with a new one after a backoff, the last published documents are served in the meantime.
+// The reconcilers of the new manager reconcile the sources of the stored entries, so that the entries of objects removed
+// in the meantime are deleted through the deletion breakers. The garden is marked as failed if the manager could not be restarted.
+func (g *garden) run(ctx context.Context, log logr.Logger) error {
+	log = log.WithValues("garden", g.name)
+	g.setState(gardenRunning)
+
+	var (
+		delay    = g.restartDelay
+		restarts int
+	)
+	for {
+		started := time.Now()
+		err := g.manager().Start(ctx)
+		if err == nil {
+			g.setState(gardenStopped)
+			return nil
+		}
+		if ctx.Err() != nil {
+			g.setState(gardenStopped)
+			return fmt.Errorf("manager of garden %s failed: %w", g.name, err)
+		}
+
+		g.setState(gardenRestarting)
+		if time.Since(started) > restartResetAfter {
+			delay, restarts = g.restartDelay, 0
+		}
+
+		// A manager cannot be started twice, it is replaced with a new one after the delay.
+		for ; err != nil; restarts++ {
+			if restarts == maxRestarts {
+				g.setState(gardenFailed)
+				log.Error(err, "Manager of garden could not be restarted, the other gardens are still served", "restarts", restarts)
+				return fmt.Errorf("manager of garden %s failed: %w", g.name, err)
+			}
+
+			log.Error(err, "Manager of garden failed, restarting it", "restart", restarts+1, "delay", delay)
+			select {
+			case <-ctx.Done():
+				g.setState(gardenStopped)
+				return nil
+			case <-time.After(delay):
+			}
+			delay = min(2*delay, maxRestartDelay)
+			err = g.restart()
+		}
+	}
+}
+
+// restart replaces the manager of the garden with a new one.
+func (g *garden) restart() error {
+	mgr, synced, err := g.newManager(true)
+	if err != nil {
+		return err
+	}
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	g.mgr, g.synced = mgr, synced
+	return nil
+}
+
+// manager returns the current manager of the garden.
+func (g *garden) manager() manager.Manager {
+	g.mutex.RLock()
+	defer g.mutex.RUnlock()
+	return g.mgr
+}
+
+// getState returns the state of the manager of the garden.
+func (g *garden) getState() gardenState {
+	return gardenState(g.state.Load())
+}
+
+// setState sets the state of the manager of the garden, the garden is reported as up if it is running.
+func (g *garden) setState(state gardenState) {
+	g.state.Store(int32(state))
+	metrics.SetGardenUp(g.name, state == gardenRunning)
+}
+
+// ready reports whether the manager of the garden is running and its caches are synced.
+func (g *garden) ready(req *http.Request) error {
+	switch g.getState() {
+	case gardenRestarting:
+		return errors.New("manager of the garden is restarting")
+	case gardenFailed:
+		return errors.New("manager of the garden failed")
+	case gardenStopped:
+		return errors.New("manager of the garden stopped")
+	}
+	g.mutex.RLock()
+	synced := g.synced
+	g.mutex.RUnlock()
+	return synced(req)
+}
+
+// available serves the requests with the handler unless the manager of the garden failed,
+// the documents are answered with 503 Service Unavailable then as they are no longer reconciled.
+// The last published documents are still served while the manager is restarting.
+func (g *garden) available(h http.Handler, log logr.Logger) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if g.getState() == gardenFailed {
+			handler.WriteProblem(w, r, log, http.StatusServiceUnavailable, "garden "+g.name+" is unavailable")
+			return
+		}
+		h.ServeHTTP(w, r)
+	})
+}
+
+// publishing holds what is shared by the published resources of a garden.
+type publishing struct {
+	garden *garden
+	conf   *options.Config
+	shard  sharding.Shard
+	signer signing.Signer
+	routes []*publisher.Registry
+	log    logr.Logger
+}
+
+// publish registers the routes of the documents of the resource and the setup of its reconciler.
+// The store of the resource is registered under the given name.
+func publish[O client.Object, T any](p publishing, name string, resource publisher.PublishedResource[O, T], copyFunc func(T) T) *store.Store[T] {
+	s := store.MustNewStore(copyFunc)
+	writer := guard(p.conf.DeletionBreaker, p.garden.storeName(name), s, p.garden.breakers, p.log)
+	p.garden.setups = append(p.garden.setups, func(mgr manager.Manager) error {
+		if err := (&publisher.Reconciler[O, T]{
+			ResyncPeriod: p.conf.Resync.Duration,
+			Store:        writer,
+			Resource:     resource,
+			Name:         p.garden.controllerName(resource.Name()),
+			Shard:        p.shard,
+			Signer:       p.signer,
+			Published:    s,
+		}).SetupWithManager(mgr); err != nil {
+			return fmt.Errorf("unable to create %s controller: %w", resource.Name(), err)
+		}
+		return nil
+	})
+	p.garden.stores[p.garden.storeName(name)] = s
+	metrics.RegisterStore(p.garden.name, name, s)
+	if b, ok := p.garden.breakers[p.garden.storeName(name)]; ok {
+		metrics.RegisterDeletionBreaker(p.garden.name, name, b)
+	}
+	for _, r := range p.routes {
+		publisher.Register(r, s, resource.Documents()...)
+	}
+	return s
+}
+
+// newHealthHandler serves the liveness and the readiness of the discovery server.
+// Every garden has a readiness check under /readyz/{garden}. The discovery server is ready if the caches
+// of all running gardens are synced, gardens whose manager is restarting or failed do not take down the others.
+// They are still reported by /gardenz, which fails unless the managers of all gardens are running and synced.
+func newHealthHandler(gardens []*garden) http.Handler {
+	checks := map[string]healthz.Checker{}
+	for _, g := range gardens {
+		checks[g.name] = g.ready
+	}
+
+	readyz := map[string]healthz.Checker{
+		"gardens": func(req *http.Request) error {
+			var errs []error
+			for _, g := range gardens {
+				if g.getState() != gardenRunning {
+					continue
+				}
+				if err := g.ready(req); err != nil {
+					errs = append(errs, fmt.Errorf("garden %s: %w", g.name, err))
+				}
+			}
+			return errors.Join(errs...)
+		},
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/healthz", http.StripPrefix("/healthz", &healthz.Handler{Checks: map[string]healthz.Checker{"ping": healthz.Ping}}))
+	mux.Handle("/readyz", http.StripPrefix("/readyz", &healthz.Handler{Checks: readyz}))
+	mux.Handle("/readyz/", http.StripPrefix("/readyz", &healthz.Handler{Checks: checks}))
+	mux.Handle("/gardenz", http.StripPrefix("/gardenz", &healthz.Handler{Checks: checks}))
+	mux.Handle("/gardenz/", http.StripPrefix("/gardenz", &healthz.Handler{Checks: checks}))
+	mux.Handle("/", handler.NotFound(logr.Discard()))
+	return mux
+}
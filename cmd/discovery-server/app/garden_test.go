@@ -0,0 +1,110 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"sigs.k8s.io/controller-runtime/pkg/healthz"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+var _ = Describe("Garden", func() {
+	var (
+		ctx    context.Context
+		cancel context.CancelFunc
+		gdn    *garden
+		h      http.Handler
+	)
+
+	BeforeEach(func() {
+		ctx, cancel = context.WithTimeout(context.Background(), 10*time.Second)
+		DeferCleanup(cancel)
+
+		gdn = &garden{
+			name:         "foo",
+			mgr:          &fakeManager{err: errors.New("lost connection")},
+			synced:       func(*http.Request) error { return nil },
+			restartDelay: time.Millisecond,
+		}
+		h = gdn.available(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) { w.WriteHeader(http.StatusOK) }), logr.Discard())
+	})
+
+	get := func() int {
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/gardens/foo/projects/foo/shoots/uid/issuer/jwks", nil))
+		return rec.Code
+	}
+
+	It("should serve the documents while the manager is running", func() {
+		Expect(get()).To(Equal(http.StatusOK))
+	})
+
+	It("should stop once the manager stops", func() {
+		gdn.mgr = &fakeManager{}
+		cancel()
+		Expect(gdn.run(ctx, logr.Discard())).To(Succeed())
+		Expect(gdn.getState()).To(Equal(gardenStopped))
+	})
+
+	It("should restart a failed manager and serve the stored documents until then", func() {
+		restarted := &fakeManager{started: make(chan struct{})}
+		gdn.newManager = func(restart bool) (manager.Manager, healthz.Checker, error) {
+			Expect(restart).To(BeTrue())
+			return restarted, func(*http.Request) error { return nil }, nil
+		}
+
+		done := make(chan error)
+		go func() { done <- gdn.run(ctx, logr.Discard()) }()
+
+		Eventually(restarted.started).Should(BeClosed())
+		Expect(gdn.getState()).To(Equal(gardenRestarting))
+		Expect(get()).To(Equal(http.StatusOK))
+		Expect(gdn.ready(nil)).To(MatchError("manager of the garden is restarting"))
+
+		cancel()
+		Eventually(done).Should(Receive(BeNil()))
+		Expect(gdn.getState()).To(Equal(gardenStopped))
+	})
+
+	It("should mark the garden as failed if the manager cannot be restarted", func() {
+		var created int
+		gdn.newManager = func(bool) (manager.Manager, healthz.Checker, error) {
+			created++
+			return nil, nil, errors.New("unreachable")
+		}
+
+		Expect(gdn.run(ctx, logr.Discard())).To(MatchError("manager of garden foo failed: unreachable"))
+		Expect(created).To(Equal(maxRestarts))
+		Expect(gdn.getState()).To(Equal(gardenFailed))
+		Expect(get()).To(Equal(http.StatusServiceUnavailable))
+		Expect(gdn.ready(nil)).To(MatchError("manager of the garden failed"))
+	})
+})
+
+// fakeManager fails with the error once started, without error it runs until the context is canceled.
+type fakeManager struct {
+	manager.Manager
+	err     error
+	started chan struct{}
+}
+
+func (m *fakeManager) Start(ctx context.Context) error {
+	if m.started != nil {
+		close(m.started)
+	}
+	if m.err != nil {
+		return m.err
+	}
+	<-ctx.Done()
+	return nil
+}
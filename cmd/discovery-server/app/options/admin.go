@@ -0,0 +1,104 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package options
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/netip"
+	"os"
+	"strconv"
+
+	"github.com/spf13/pflag"
+)
+
+// AdminOptions holds options regarding the admin API inspecting the published entries.
+type AdminOptions struct {
+	// Address is the IP address the admin API listens on.
+	Address string
+	// Port is the port the admin API listens on, zero disables the admin API.
+	Port uint
+	// ClientCAFile is the path to the CA bundle used to verify client certificates.
+	ClientCAFile string
+	// TokenReview indicates whether bearer tokens are authenticated with the TokenReview API.
+	TokenReview bool
+	// AllowedUsers are the user names allowed to access the admin API.
+	AllowedUsers []string
+	// AllowedGroups are the groups allowed to access the admin API.
+	AllowedGroups []string
+	// VerifyToken indicates whether the admin API serves the token verification endpoint.
+	VerifyToken bool
+}
+
+// AddFlags adds the [AdminOptions] flags to the flagset.
+func (o *AdminOptions) AddFlags(fs *pflag.FlagSet) {
+	fs.StringVar(&o.Address, "admin-address", "127.0.0.1", "The IP address that the admin API will listen on. "+
+		"Must be a loopback address unless --admin-client-ca-file or --admin-token-review is set.")
+	fs.UintVar(&o.Port, "admin-port", 0, "The port that the admin API will listen on. Zero disables the admin API.")
+	fs.StringVar(&o.ClientCAFile, "admin-client-ca-file", "", "File containing the CA bundle used to verify client certificates of admin API requests. "+
+		"The common name of the certificate is used as user name and the organizations as groups.")
+	fs.BoolVar(&o.TokenReview, "admin-token-review", false, "Authenticate bearer tokens of admin API requests with the TokenReview API. "+
+		"Requires --admin-allowed-users or --admin-allowed-groups and permissions to create tokenreviews.")
+	fs.StringSliceVar(&o.AllowedUsers, "admin-allowed-users", nil, "Comma-separated list of user names allowed to access the admin API.")
+	fs.StringSliceVar(&o.AllowedGroups, "admin-allowed-groups", nil, "Comma-separated list of groups allowed to access the admin API.")
+	fs.BoolVar(&o.VerifyToken, "admin-verify-token", false, "Serve /admin/verify-token diagnosing tokens against the published JWKS of their issuer.")
+}
+
+// Validate checks if options are valid.
+func (o *AdminOptions) Validate() []error {
+	if o.Port == 0 {
+		return nil
+	}
+
+	var errs []error
+	if o.Port > 65535 {
+		errs = append(errs, errors.New("--admin-port must be a valid port"))
+	}
+	ip, err := netip.ParseAddr(o.Address)
+	if err != nil {
+		errs = append(errs, fmt.Errorf("--admin-address is invalid: %w", err))
+	} else if !ip.IsLoopback() && o.ClientCAFile == "" && !o.TokenReview {
+		errs = append(errs, errors.New("--admin-address must be a loopback address unless --admin-client-ca-file or --admin-token-review is set"))
+	}
+	if o.TokenReview && len(o.AllowedUsers) == 0 && len(o.AllowedGroups) == 0 {
+		errs = append(errs, errors.New("--admin-allowed-users or --admin-allowed-groups is required if --admin-token-review is set"))
+	}
+	return errs
+}
+
+// ApplyTo applies the options to the configuration.
+func (o *AdminOptions) ApplyTo(c *AdminConfig) error {
+	if o.Port == 0 {
+		return nil
+	}
+
+	c.Address = net.JoinHostPort(o.Address, strconv.FormatUint(uint64(o.Port), 10))
+	c.TokenReview = o.TokenReview
+	c.AllowedUsers = o.AllowedUsers
+	c.AllowedGroups = o.AllowedGroups
+	c.VerifyToken = o.VerifyToken
+	if o.ClientCAFile != "" {
+		ca, err := os.ReadFile(o.ClientCAFile)
+		if err != nil {
+			return fmt.Errorf("failed to read admin client CA file: %w", err)
+		}
+		c.ClientCA = ca
+	}
+	return nil
+}
+
+// AdminConfig holds configurations regarding the admin API.
+type AdminConfig struct {
+	// Address is the address the admin API listens on, the admin API is disabled if it is empty.
+	Address string
+	// ClientCA is the CA bundle used to verify client certificates, client certificates are not requested if it is empty.
+	ClientCA      []byte
+	TokenReview   bool
+	AllowedUsers  []string
+	AllowedGroups []string
+	// VerifyToken indicates whether /admin/verify-token is served.
+	VerifyToken bool
+}
@@ -0,0 +1,55 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package options_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/gardener/gardener-discovery-server/cmd/discovery-server/app/options"
+)
+
+var _ = Describe("AdminOptions", func() {
+	It("should not validate the disabled admin API", func() {
+		o := &options.AdminOptions{}
+		Expect(parse(o, "--admin-address=0.0.0.0").Validate()).To(BeEmpty())
+
+		c := &options.AdminConfig{}
+		Expect(o.ApplyTo(c)).To(Succeed())
+		Expect(c.Address).To(BeEmpty())
+	})
+
+	It("should allow loopback addresses", func() {
+		o := &options.AdminOptions{}
+		Expect(parse(o, "--admin-port=10445", "--admin-address=::1").Validate()).To(BeEmpty())
+
+		c := &options.AdminConfig{}
+		Expect(o.ApplyTo(c)).To(Succeed())
+		Expect(c.Address).To(Equal("[::1]:10445"))
+	})
+
+	It("should allow other addresses if the clients are authenticated", func() {
+		Expect(parse(&options.AdminOptions{}, "--admin-port=10445", "--admin-address=0.0.0.0", "--admin-client-ca-file=ca.crt").Validate()).To(BeEmpty())
+
+		o := &options.AdminOptions{}
+		Expect(parse(o, "--admin-port=10445", "--admin-address=0.0.0.0", "--admin-token-review", "--admin-allowed-users=alice", "--admin-allowed-groups=admins").Validate()).To(BeEmpty())
+
+		c := &options.AdminConfig{}
+		Expect(o.ApplyTo(c)).To(Succeed())
+		Expect(c.TokenReview).To(BeTrue())
+		Expect(c.AllowedUsers).To(ConsistOf("alice"))
+		Expect(c.AllowedGroups).To(ConsistOf("admins"))
+	})
+
+	DescribeTable("should reject invalid admin options",
+		func(match string, args ...string) {
+			Expect(parse(&options.AdminOptions{}, append([]string{"--admin-port=10445"}, args...)...).Validate()).To(ConsistOf(MatchError(ContainSubstring(match))))
+		},
+		Entry("unauthenticated non-loopback address", "--admin-address must be a loopback address unless --admin-client-ca-file or --admin-token-review is set", "--admin-address=0.0.0.0"),
+		Entry("host name", "--admin-address is invalid", "--admin-address=localhost"),
+		Entry("token review without allowlist", "--admin-allowed-users or --admin-allowed-groups is required if --admin-token-review is set", "--admin-token-review"),
+		Entry("invalid port", "--admin-port must be a valid port", "--admin-port=65536"),
+	)
+})
@@ -0,0 +1,131 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package options
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/spf13/pflag"
+)
+
+// Endpoint types with a separate cache policy.
+const (
+	EndpointOpenIDConfiguration = "openid-configuration"
+	EndpointJWKS                = "jwks"
+	EndpointCABundle            = "ca-bundle"
+	EndpointSPIFFEBundle        = "spiffe-bundle"
+)
+
+var endpointTypes = []string{EndpointOpenIDConfiguration, EndpointJWKS, EndpointCABundle, EndpointSPIFFEBundle}
+
+// CacheOptions holds options regarding the caching headers of the published documents.
+// The durations are given per endpoint type.
+type CacheOptions struct {
+	MaxAge               map[string]string
+	StaleWhileRevalidate map[string]string
+	StaleIfError         map[string]string
+	// ExpiresFromData indicates whether the max-age is capped to the expiration of the published certificates.
+	ExpiresFromData bool
+}
+
+// AddFlags adds the [CacheOptions] flags to the flagset.
+func (o *CacheOptions) AddFlags(fs *pflag.FlagSet) {
+	types := strings.Join(endpointTypes, ", ")
+	fs.StringToStringVar(&o.MaxAge, "cache-max-age", nil, "Comma-separated list of endpoint types and the max-age of their responses, e.g. jwks=5m,openid-configuration=1h. "+
+		"Endpoint types are "+types+". The max-age defaults to 1h.")
+	fs.StringToStringVar(&o.StaleWhileRevalidate, "cache-stale-while-revalidate", nil, "Comma-separated list of endpoint types and the duration stale responses may be served while they are revalidated, e.g. jwks=1m. "+
+		"Endpoint types are "+types+".")
+	fs.StringToStringVar(&o.StaleIfError, "cache-stale-if-error", nil, "Comma-separated list of endpoint types and the duration stale responses may be served if revalidation fails, e.g. ca-bundle=24h. "+
+		"Endpoint types are "+types+".")
+	fs.BoolVar(&o.ExpiresFromData, "cache-expires-from-data", false, "Cap the max-age to the earliest expiration of the published certificates and set the Expires header if it is within the max-age. "+
+		"The documents carry no rotation schedule, so service account JWKS without certificates never get an Expires header.")
+}
+
+// Validate checks if options are valid.
+func (o *CacheOptions) Validate() []error {
+	var errs []error
+	for flag, durations := range map[string]map[string]string{
+		"--cache-max-age":                o.MaxAge,
+		"--cache-stale-while-revalidate": o.StaleWhileRevalidate,
+		"--cache-stale-if-error":         o.StaleIfError,
+	} {
+		if _, err := parseEndpointDurations(durations); err != nil {
+			errs = append(errs, fmt.Errorf("%s is invalid: %w", flag, err))
+		}
+	}
+	return errs
+}
+
+// ApplyTo applies the options to the configuration.
+func (o *CacheOptions) ApplyTo(c *CacheConfig) error {
+	maxAge, err := parseEndpointDurations(o.MaxAge)
+	if err != nil {
+		return err
+	}
+	staleWhileRevalidate, err := parseEndpointDurations(o.StaleWhileRevalidate)
+	if err != nil {
+		return err
+	}
+	staleIfError, err := parseEndpointDurations(o.StaleIfError)
+	if err != nil {
+		return err
+	}
+
+	policy := func(endpoint string) CachePolicy {
+		p := CachePolicy{
+			MaxAge:               time.Hour,
+			StaleWhileRevalidate: staleWhileRevalidate[endpoint],
+			StaleIfError:         staleIfError[endpoint],
+			Expires:              o.ExpiresFromData,
+		}
+		if d, ok := maxAge[endpoint]; ok {
+			p.MaxAge = d
+		}
+		return p
+	}
+	c.OpenIDConfiguration = policy(EndpointOpenIDConfiguration)
+	c.JWKS = policy(EndpointJWKS)
+	c.CABundle = policy(EndpointCABundle)
+	c.SPIFFEBundle = policy(EndpointSPIFFEBundle)
+	return nil
+}
+
+func parseEndpointDurations(in map[string]string) (map[string]time.Duration, error) {
+	out := make(map[string]time.Duration, len(in))
+	for endpoint, v := range in {
+		if !slices.Contains(endpointTypes, endpoint) {
+			return nil, fmt.Errorf("unknown endpoint type %q", endpoint)
+		}
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid duration for %s: %w", endpoint, err)
+		}
+		if d < 0 {
+			return nil, fmt.Errorf("duration for %s must not be negative", endpoint)
+		}
+		out[endpoint] = d
+	}
+	return out, nil
+}
+
+// CacheConfig holds the cache policies per endpoint type.
+type CacheConfig struct {
+	OpenIDConfiguration CachePolicy
+	JWKS                CachePolicy
+	CABundle            CachePolicy
+	SPIFFEBundle        CachePolicy
+}
+
+// CachePolicy configures the caching headers of the responses of an endpoint type.
+type CachePolicy struct {
+	MaxAge               time.Duration
+	StaleWhileRevalidate time.Duration
+	StaleIfError         time.Duration
+	// Expires indicates whether the max-age is capped to the expiration of the published data.
+	Expires bool
+}
@@ -0,0 +1,53 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package options_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/gardener/gardener-discovery-server/cmd/discovery-server/app/options"
+)
+
+var _ = Describe("CacheOptions", func() {
+	It("should default the max-age of all endpoint types to one hour", func() {
+		o := &options.CacheOptions{}
+		Expect(parse(o).Validate()).To(BeEmpty())
+
+		c := &options.CacheConfig{}
+		Expect(o.ApplyTo(c)).To(Succeed())
+		for _, p := range []options.CachePolicy{c.OpenIDConfiguration, c.JWKS, c.CABundle, c.SPIFFEBundle} {
+			Expect(p).To(Equal(options.CachePolicy{MaxAge: time.Hour}))
+		}
+	})
+
+	It("should apply the durations per endpoint type", func() {
+		o := &options.CacheOptions{}
+		Expect(parse(o,
+			"--cache-max-age=jwks=5m,openid-configuration=2h",
+			"--cache-stale-while-revalidate=jwks=1m",
+			"--cache-stale-if-error=ca-bundle=24h",
+			"--cache-expires-from-data",
+		).Validate()).To(BeEmpty())
+
+		c := &options.CacheConfig{}
+		Expect(o.ApplyTo(c)).To(Succeed())
+		Expect(c.JWKS).To(Equal(options.CachePolicy{MaxAge: 5 * time.Minute, StaleWhileRevalidate: time.Minute, Expires: true}))
+		Expect(c.OpenIDConfiguration).To(Equal(options.CachePolicy{MaxAge: 2 * time.Hour, Expires: true}))
+		Expect(c.CABundle).To(Equal(options.CachePolicy{MaxAge: time.Hour, StaleIfError: 24 * time.Hour, Expires: true}))
+		Expect(c.SPIFFEBundle).To(Equal(options.CachePolicy{MaxAge: time.Hour, Expires: true}))
+	})
+
+	DescribeTable("should reject invalid durations",
+		func(match string, args ...string) {
+			Expect(parse(&options.CacheOptions{}, args...).Validate()).To(ConsistOf(MatchError(match)))
+		},
+		Entry("unknown endpoint type", `--cache-max-age is invalid: unknown endpoint type "foo"`, "--cache-max-age=foo=5m"),
+		Entry("invalid duration", `--cache-stale-while-revalidate is invalid: invalid duration for jwks: time: invalid duration "five"`, "--cache-stale-while-revalidate=jwks=five"),
+		Entry("negative duration", "--cache-stale-if-error is invalid: duration for ca-bundle must not be negative", "--cache-stale-if-error=ca-bundle=-1h"),
+	)
+})
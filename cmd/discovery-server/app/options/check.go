@@ -0,0 +1,125 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package options
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"time"
+
+	"github.com/spf13/pflag"
+)
+
+const (
+	// OutputFormatJSON emits the results of the check command as JSON.
+	OutputFormatJSON = "json"
+	// OutputFormatJUnit emits the results of the check command as JUnit XML.
+	OutputFormatJUnit = "junit"
+)
+
+// CheckOptions holds the options of the check command verifying the conformance of the published issuers.
+type CheckOptions struct {
+	ClientOptions ClientOptions
+	// BaseURL is the URL the discovery server is reachable at, the issuers are derived from it.
+	BaseURL string
+	// Issuers are the issuers to check, all published issuers are checked if it is empty.
+	Issuers []string
+	// InProcess indicates whether the checks run against a discovery server started in-process.
+	InProcess bool
+	// OutputFormat is the format of the results, either json or junit.
+	OutputFormat string
+	// OutputFile is the path the results are written to, they are written to stdout if it is empty.
+	OutputFile string
+	// Timeout is the maximum duration of the whole check.
+	Timeout time.Duration
+	// MaxAge is the maximum max-age of the cached documents that is considered sane.
+	MaxAge time.Duration
+}
+
+// AddFlags adds the [CheckOptions] flags to the flagset.
+func (o *CheckOptions) AddFlags(fs *pflag.FlagSet) {
+	o.ClientOptions.AddFlags(fs)
+	fs.StringVar(&o.BaseURL, "base-url", "", "The https URL the discovery server is reachable at, e.g. https://discovery.example.com. "+
+		"The published issuers are expected below it.")
+	fs.StringArrayVar(&o.Issuers, "issuer", nil, "Issuer URL to check, can be repeated. All published issuers are checked if it is not set.")
+	fs.BoolVar(&o.InProcess, "in-process", false, "Start the discovery server in-process with the server flags and check it instead of a deployed one. "+
+		"Requests to any host are served by the in-process server.")
+	fs.StringVar(&o.OutputFormat, "output-format", OutputFormatJUnit, "Format of the results, one of json, junit.")
+	fs.StringVar(&o.OutputFile, "output-file", "", "File the results are written to. They are written to stdout if it is not set.")
+	fs.DurationVar(&o.Timeout, "timeout", 5*time.Minute, "Maximum duration of the check, including the start of the in-process discovery server.")
+	fs.DurationVar(&o.MaxAge, "max-age", 24*time.Hour, "Maximum max-age of the Cache-Control header that is considered sane.")
+}
+
+// Validate checks if options are valid.
+func (o *CheckOptions) Validate() []error {
+	errs := o.ClientOptions.Validate()
+	if !slices.Contains([]string{OutputFormatJSON, OutputFormatJUnit}, o.OutputFormat) {
+		errs = append(errs, fmt.Errorf("--output-format must be one of %s, %s", OutputFormatJSON, OutputFormatJUnit))
+	}
+	if o.Timeout <= 0 {
+		errs = append(errs, errors.New("--timeout must be positive"))
+	}
+	if o.MaxAge <= 0 {
+		errs = append(errs, errors.New("--max-age must be positive"))
+	}
+	for _, issuer := range o.Issuers {
+		if err := validateHTTPSURL(issuer); err != nil {
+			errs = append(errs, fmt.Errorf("--issuer %w", err))
+		}
+	}
+	if o.InProcess && o.ClientOptions.AdminURL != "" {
+		errs = append(errs, errors.New("--in-process and --admin-url are mutually exclusive"))
+	}
+
+	if len(o.Issuers) > 0 {
+		return errs
+	}
+	if o.BaseURL == "" {
+		errs = append(errs, errors.New("--base-url is required unless --issuer is set"))
+	} else if err := validateHTTPSURL(o.BaseURL); err != nil {
+		errs = append(errs, fmt.Errorf("--base-url %w", err))
+	}
+	if !o.InProcess && o.ClientOptions.AdminURL == "" {
+		errs = append(errs, errors.New("--in-process or --admin-url is required to enumerate the published issuers unless --issuer is set"))
+	}
+	return errs
+}
+
+func validateHTTPSURL(raw string) error {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return fmt.Errorf("is invalid: %w", err)
+	}
+	if u.Scheme != "https" || u.Host == "" || u.RawQuery != "" || u.Fragment != "" {
+		return fmt.Errorf("%q must be an https URL without query and fragment", raw)
+	}
+	return nil
+}
+
+// ApplyTo applies the options to the configuration.
+func (o *CheckOptions) ApplyTo(c *CheckConfig) error {
+	c.BaseURL = o.BaseURL
+	c.Issuers = o.Issuers
+	c.InProcess = o.InProcess
+	c.OutputFormat = o.OutputFormat
+	c.OutputFile = o.OutputFile
+	c.Timeout = o.Timeout
+	c.MaxAge = o.MaxAge
+	return o.ClientOptions.ApplyTo(&c.Client)
+}
+
+// CheckConfig holds the configuration of the check command.
+type CheckConfig struct {
+	Client       ClientConfig
+	BaseURL      string
+	Issuers      []string
+	InProcess    bool
+	OutputFormat string
+	OutputFile   string
+	Timeout      time.Duration
+	MaxAge       time.Duration
+}
@@ -0,0 +1,85 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package options
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/pflag"
+)
+
+// ClientOptions holds the options of the commands connecting to a deployed discovery server.
+type ClientOptions struct {
+	// AdminURL is the URL of the admin API of the discovery server.
+	AdminURL string
+	// AdminTokenFile is the path to the bearer token sent to the admin API.
+	AdminTokenFile string
+	// CAFile is the path to the CA bundle used to verify the discovery server and the admin API.
+	CAFile string
+	// RequestTimeout is the maximum duration of a single request.
+	RequestTimeout time.Duration
+}
+
+// AddFlags adds the [ClientOptions] flags to the flagset.
+func (o *ClientOptions) AddFlags(fs *pflag.FlagSet) {
+	fs.StringVar(&o.AdminURL, "admin-url", "", "URL of the admin API of a deployed discovery server.")
+	fs.StringVar(&o.AdminTokenFile, "admin-token-file", "", "File containing the bearer token sent to the admin API.")
+	fs.StringVar(&o.CAFile, "ca-file", "", "File containing the CA bundle used to verify the discovery server and the admin API. "+
+		"The system roots are used if it is not set.")
+	fs.DurationVar(&o.RequestTimeout, "request-timeout", 10*time.Second, "Maximum duration of a single request.")
+}
+
+// Validate checks if options are valid.
+func (o *ClientOptions) Validate() []error {
+	var errs []error
+	if o.RequestTimeout <= 0 {
+		errs = append(errs, errors.New("--request-timeout must be positive"))
+	}
+	if o.AdminTokenFile != "" && o.AdminURL == "" {
+		errs = append(errs, errors.New("--admin-token-file requires --admin-url"))
+	}
+	if o.AdminURL != "" {
+		if u, err := url.Parse(o.AdminURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			errs = append(errs, errors.New("--admin-url must be an http or https URL"))
+		}
+	}
+	return errs
+}
+
+// ApplyTo applies the options to the configuration.
+func (o *ClientOptions) ApplyTo(c *ClientConfig) error {
+	c.AdminURL = o.AdminURL
+	c.RequestTimeout = o.RequestTimeout
+	if o.AdminTokenFile != "" {
+		token, err := os.ReadFile(o.AdminTokenFile)
+		if err != nil {
+			return fmt.Errorf("failed to read admin token file: %w", err)
+		}
+		c.AdminToken = strings.TrimSpace(string(token))
+	}
+	if o.CAFile != "" {
+		ca, err := os.ReadFile(o.CAFile)
+		if err != nil {
+			return fmt.Errorf("failed to read CA file: %w", err)
+		}
+		c.CA = ca
+	}
+	return nil
+}
+
+// ClientConfig holds the configuration of the clients connecting to a deployed discovery server.
+type ClientConfig struct {
+	AdminURL string
+	// AdminToken is the bearer token sent to the admin API, no token is sent if it is empty.
+	AdminToken string
+	// CA is the CA bundle used to verify the servers, the system roots are used if it is empty.
+	CA             []byte
+	RequestTimeout time.Duration
+}
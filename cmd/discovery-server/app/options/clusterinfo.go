@@ -0,0 +1,36 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package options
+
+import (
+	"github.com/spf13/pflag"
+)
+
+// ClusterInfoOptions holds options regarding the cluster info of the shoots.
+type ClusterInfoOptions struct {
+	Enabled bool
+}
+
+// AddFlags adds the [ClusterInfoOptions] flags to the flagset.
+func (o *ClusterInfoOptions) AddFlags(fs *pflag.FlagSet) {
+	fs.BoolVar(&o.Enabled, "cluster-info-enabled", false, "Serve the API server addresses and the CA bundle of shoots as kubeconfig under /projects/{projectName}/shoots/{shootUID}/cluster-info. "+
+		"Shoots opt in with the annotation discovery.gardener.cloud/cluster-info=enabled.")
+}
+
+// Validate checks if options are valid.
+func (o *ClusterInfoOptions) Validate() []error {
+	return nil
+}
+
+// ApplyTo applies the options to the configuration.
+func (o *ClusterInfoOptions) ApplyTo(c *ClusterInfoConfig) error {
+	c.Enabled = o.Enabled
+	return nil
+}
+
+// ClusterInfoConfig holds configurations regarding the cluster info of the shoots.
+type ClusterInfoConfig struct {
+	Enabled bool
+}
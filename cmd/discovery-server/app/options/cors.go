@@ -0,0 +1,93 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package options
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/spf13/pflag"
+)
+
+// CORSOptions holds options regarding the CORS headers of the published documents.
+type CORSOptions struct {
+	ShootAllowedOrigins  []string
+	GardenAllowedOrigins []string
+	ExposedHeaders       []string
+	MaxAge               time.Duration
+}
+
+// AddFlags adds the [CORSOptions] flags to the flagset.
+func (o *CORSOptions) AddFlags(fs *pflag.FlagSet) {
+	fs.StringSliceVar(&o.ShootAllowedOrigins, "shoot-cors-allowed-origins", nil, "Comma-separated list of origins allowed to read the documents of the shoots from browsers, "+
+		"e.g. https://portal.example.com,https://*.example.com. Use * to allow any origin. Cross-origin requests are not allowed if it is not set.")
+	fs.StringSliceVar(&o.GardenAllowedOrigins, "garden-cors-allowed-origins", nil, "Comma-separated list of origins allowed to read the documents of the garden from browsers, "+
+		"e.g. the workload identity issuer. Same format as --shoot-cors-allowed-origins.")
+	fs.StringSliceVar(&o.ExposedHeaders, "cors-exposed-headers", []string{"ETag", "JWS-Signature"}, "Comma-separated list of response headers readable by browsers in cross-origin requests.")
+	fs.DurationVar(&o.MaxAge, "cors-max-age", time.Hour, "Duration browsers may cache the result of a preflight request.")
+}
+
+// Validate checks if options are valid.
+func (o *CORSOptions) Validate() []error {
+	var errs []error
+	for flag, origins := range map[string][]string{
+		"--shoot-cors-allowed-origins":  o.ShootAllowedOrigins,
+		"--garden-cors-allowed-origins": o.GardenAllowedOrigins,
+	} {
+		for _, origin := range origins {
+			if err := validateOrigin(origin); err != nil {
+				errs = append(errs, fmt.Errorf("%s is invalid: %w", flag, err))
+			}
+		}
+	}
+	for _, h := range o.ExposedHeaders {
+		if h == "" || strings.ContainsAny(h, " \t,:") {
+			errs = append(errs, fmt.Errorf("--cors-exposed-headers contains invalid header name %q", h))
+		}
+	}
+	if o.MaxAge < 0 {
+		errs = append(errs, errors.New("--cors-max-age must not be negative"))
+	}
+	return errs
+}
+
+// validateOrigin checks that the origin is *, scheme://host[:port] or scheme://*.domain[:port].
+func validateOrigin(origin string) error {
+	if origin == "*" {
+		return nil
+	}
+	u, err := url.Parse(strings.Replace(origin, "://*.", "://wildcard.", 1))
+	if err != nil {
+		return fmt.Errorf("origin %q is invalid: %w", origin, err)
+	}
+	if (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" || strings.Contains(u.Host, "*") ||
+		u.User != nil || u.Path != "" || u.RawQuery != "" || u.Fragment != "" {
+		return fmt.Errorf("origin %q must be *, scheme://host[:port] or scheme://*.domain[:port]", origin)
+	}
+	return nil
+}
+
+// ApplyTo applies the options to the configuration.
+func (o *CORSOptions) ApplyTo(c *CORSConfig) error {
+	c.Shoot = CORSPolicy{AllowedOrigins: o.ShootAllowedOrigins, ExposedHeaders: o.ExposedHeaders, MaxAge: o.MaxAge}
+	c.Garden = CORSPolicy{AllowedOrigins: o.GardenAllowedOrigins, ExposedHeaders: o.ExposedHeaders, MaxAge: o.MaxAge}
+	return nil
+}
+
+// CORSConfig holds the CORS policies per route group.
+type CORSConfig struct {
+	Shoot  CORSPolicy
+	Garden CORSPolicy
+}
+
+// CORSPolicy configures the CORS headers of the responses of a route group, CORS is disabled if no origin is allowed.
+type CORSPolicy struct {
+	AllowedOrigins []string
+	ExposedHeaders []string
+	MaxAge         time.Duration
+}
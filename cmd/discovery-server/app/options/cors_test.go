@@ -0,0 +1,50 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package options_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/gardener/gardener-discovery-server/cmd/discovery-server/app/options"
+)
+
+var _ = Describe("CORSOptions", func() {
+	It("should allow exact, wildcard and any origins", func() {
+		o := &options.CORSOptions{}
+		Expect(parse(o,
+			"--shoot-cors-allowed-origins=https://portal.example.com,https://*.example.com:8443,http://localhost:3000",
+			"--garden-cors-allowed-origins=*",
+		).Validate()).To(BeEmpty())
+
+		c := &options.CORSConfig{}
+		Expect(o.ApplyTo(c)).To(Succeed())
+		Expect(c.Shoot.AllowedOrigins).To(ConsistOf("https://portal.example.com", "https://*.example.com:8443", "http://localhost:3000"))
+		Expect(c.Garden.AllowedOrigins).To(ConsistOf("*"))
+		Expect(c.Shoot.ExposedHeaders).To(ConsistOf("ETag", "JWS-Signature"))
+	})
+
+	DescribeTable("should reject invalid origins",
+		func(origin string) {
+			Expect(parse(&options.CORSOptions{}, "--shoot-cors-allowed-origins="+origin).Validate()).To(ConsistOf(
+				MatchError(ContainSubstring("--shoot-cors-allowed-origins is invalid")),
+			))
+		},
+		Entry("without scheme", "portal.example.com"),
+		Entry("with unsupported scheme", "ftp://portal.example.com"),
+		Entry("with path", "https://portal.example.com/app"),
+		Entry("with query", "https://portal.example.com?foo=bar"),
+		Entry("with user info", "https://user@portal.example.com"),
+		Entry("with wildcard inside the host", "https://portal.*.example.com"),
+		Entry("with trailing slash", "https://portal.example.com/"),
+	)
+
+	It("should reject invalid exposed headers and a negative max age", func() {
+		Expect(parse(&options.CORSOptions{}, "--cors-exposed-headers=ETag,X Foo", "--cors-max-age=-1s").Validate()).To(ConsistOf(
+			MatchError(`--cors-exposed-headers contains invalid header name "X Foo"`),
+			MatchError("--cors-max-age must not be negative"),
+		))
+	})
+})
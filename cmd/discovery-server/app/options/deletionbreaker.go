@@ -0,0 +1,61 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package options
+
+import (
+	"errors"
+	"time"
+
+	"github.com/spf13/pflag"
+)
+
+// DeletionBreakerOptions holds options regarding the safeguard against mass deletions from the stores.
+type DeletionBreakerOptions struct {
+	// Threshold is the fraction of entries that may be deleted within Window, zero disables the safeguard.
+	Threshold float64
+	// Window is the period in which deletions are counted.
+	Window time.Duration
+	// MinDeletions is the number of deletions within Window that are always allowed.
+	MinDeletions int
+}
+
+// AddFlags adds the [DeletionBreakerOptions] flags to the flagset.
+func (o *DeletionBreakerOptions) AddFlags(fs *pflag.FlagSet) {
+	fs.Float64Var(&o.Threshold, "deletion-breaker-threshold", 0.1, "Fraction of the entries of a store that may be deleted within --deletion-breaker-window. "+
+		"Further deletions are paused until the entries are written again or the breaker is released via the admin API. Zero disables the safeguard.")
+	fs.DurationVar(&o.Window, "deletion-breaker-window", 5*time.Minute, "The period in which deletions are counted.")
+	fs.IntVar(&o.MinDeletions, "deletion-breaker-min-deletions", 10, "The number of deletions within --deletion-breaker-window that never pause deletions.")
+}
+
+// Validate checks if options are valid.
+func (o *DeletionBreakerOptions) Validate() []error {
+	var errs []error
+	if o.Threshold < 0 || o.Threshold > 1 {
+		errs = append(errs, errors.New("--deletion-breaker-threshold must be between 0 and 1"))
+	}
+	if o.Window <= 0 {
+		errs = append(errs, errors.New("--deletion-breaker-window must be positive"))
+	}
+	if o.MinDeletions < 0 {
+		errs = append(errs, errors.New("--deletion-breaker-min-deletions must not be negative"))
+	}
+	return errs
+}
+
+// ApplyTo applies the options to the configuration.
+func (o *DeletionBreakerOptions) ApplyTo(c *DeletionBreakerConfig) error {
+	c.Threshold = o.Threshold
+	c.Window = o.Window
+	c.MinDeletions = o.MinDeletions
+	return nil
+}
+
+// DeletionBreakerConfig holds configurations regarding the safeguard against mass deletions from the stores.
+type DeletionBreakerConfig struct {
+	// Threshold is the fraction of entries that may be deleted within Window, the safeguard is disabled if it is zero.
+	Threshold    float64
+	Window       time.Duration
+	MinDeletions int
+}
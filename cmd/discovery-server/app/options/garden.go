@@ -0,0 +1,90 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package options
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/pflag"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// GardenOptions holds options regarding the garden clusters the discovery data is served for.
+type GardenOptions struct {
+	// Kubeconfigs are the kubeconfig files of the gardens in the form name=path.
+	Kubeconfigs []string
+	// Default is the name of the garden which is served under the paths without garden prefix.
+	Default string
+}
+
+// AddFlags adds the [GardenOptions] flags to the flagset.
+func (o *GardenOptions) AddFlags(fs *pflag.FlagSet) {
+	fs.StringArrayVar(&o.Kubeconfigs, "garden", nil, "Garden to serve the discovery data of in the form name=kubeconfig-path, can be repeated. "+
+		"The documents of a garden are served under /gardens/{name}/projects/. "+
+		"If unspecified, a single garden named after --default-garden is served from the in-cluster or --kubeconfig configuration.")
+	fs.StringVar(&o.Default, "default-garden", "default", "Name of the garden which is also served under the paths without garden prefix, e.g. /projects/. "+
+		"If --garden is specified and the name is empty, only the prefixed paths are served.")
+}
+
+// Validate checks if options are valid.
+func (o *GardenOptions) Validate() []error {
+	var (
+		errs  []error
+		names = map[string]struct{}{}
+	)
+	for _, kubeconfig := range o.Kubeconfigs {
+		name, path, ok := strings.Cut(kubeconfig, "=")
+		if !ok || strings.TrimSpace(path) == "" {
+			errs = append(errs, fmt.Errorf("--garden must be in the form name=kubeconfig-path, got %q", kubeconfig))
+			continue
+		}
+		if msgs := validation.IsDNS1123Label(name); len(msgs) > 0 {
+			errs = append(errs, fmt.Errorf("--garden name %q is invalid: %s", name, strings.Join(msgs, ", ")))
+		}
+		if _, ok := names[name]; ok {
+			errs = append(errs, fmt.Errorf("--garden name %q is specified more than once", name))
+		}
+		names[name] = struct{}{}
+	}
+
+	if len(o.Kubeconfigs) == 0 {
+		if msgs := validation.IsDNS1123Label(o.Default); len(msgs) > 0 {
+			errs = append(errs, fmt.Errorf("--default-garden %q is invalid: %s", o.Default, strings.Join(msgs, ", ")))
+		}
+	} else if _, ok := names[o.Default]; o.Default != "" && !ok {
+		errs = append(errs, fmt.Errorf("--default-garden %q is not one of the gardens specified with --garden", o.Default))
+	}
+	return errs
+}
+
+// ApplyTo applies the options to the configuration.
+func (o *GardenOptions) ApplyTo(c *GardenConfig) error {
+	c.Default = o.Default
+	c.Gardens = nil
+	for _, kubeconfig := range o.Kubeconfigs {
+		name, path, _ := strings.Cut(kubeconfig, "=")
+		c.Gardens = append(c.Gardens, Garden{Name: name, Kubeconfig: path})
+	}
+	if len(c.Gardens) == 0 {
+		c.Gardens = []Garden{{Name: o.Default}}
+	}
+	return nil
+}
+
+// GardenConfig holds configurations regarding the garden clusters the discovery data is served for.
+type GardenConfig struct {
+	Gardens []Garden
+	// Default is the name of the garden which is also served under the paths without garden prefix.
+	// These paths are not served if it is empty.
+	Default string
+}
+
+// Garden is a garden cluster the discovery data is served for.
+type Garden struct {
+	Name string
+	// Kubeconfig is the path of the kubeconfig file, the in-cluster or --kubeconfig configuration is used if it is empty.
+	Kubeconfig string
+}
@@ -0,0 +1,53 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package options_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/gardener/gardener-discovery-server/cmd/discovery-server/app/options"
+)
+
+var _ = Describe("GardenOptions", func() {
+	It("should serve the default garden if no garden is specified", func() {
+		o := &options.GardenOptions{}
+		Expect(parse(o).Validate()).To(BeEmpty())
+
+		c := &options.GardenConfig{}
+		Expect(o.ApplyTo(c)).To(Succeed())
+		Expect(c.Default).To(Equal("default"))
+		Expect(c.Gardens).To(ConsistOf(options.Garden{Name: "default"}))
+	})
+
+	It("should parse the gardens in the form name=path", func() {
+		o := &options.GardenOptions{}
+		Expect(parse(o, "--garden=foo=/foo/kubeconfig", "--garden=bar=/bar/kubeconfig", "--default-garden=foo").Validate()).To(BeEmpty())
+
+		c := &options.GardenConfig{}
+		Expect(o.ApplyTo(c)).To(Succeed())
+		Expect(c.Default).To(Equal("foo"))
+		Expect(c.Gardens).To(Equal([]options.Garden{
+			{Name: "foo", Kubeconfig: "/foo/kubeconfig"},
+			{Name: "bar", Kubeconfig: "/bar/kubeconfig"},
+		}))
+	})
+
+	It("should allow to serve the gardens only with prefix", func() {
+		Expect(parse(&options.GardenOptions{}, "--garden=foo=/foo/kubeconfig", "--default-garden=").Validate()).To(BeEmpty())
+	})
+
+	DescribeTable("should reject invalid gardens",
+		func(match string, args ...string) {
+			Expect(parse(&options.GardenOptions{}, args...).Validate()).To(ContainElement(MatchError(ContainSubstring(match))))
+		},
+		Entry("without path", "must be in the form name=kubeconfig-path", "--garden=foo", "--default-garden=foo"),
+		Entry("with empty path", "must be in the form name=kubeconfig-path", "--garden=foo= ", "--default-garden="),
+		Entry("with invalid name", `--garden name "Foo" is invalid`, "--garden=Foo=/foo/kubeconfig", "--default-garden="),
+		Entry("with duplicate name", `--garden name "foo" is specified more than once`, "--garden=foo=/foo/kubeconfig", "--garden=foo=/bar/kubeconfig", "--default-garden=foo"),
+		Entry("with unknown default", `--default-garden "bar" is not one of the gardens`, "--garden=foo=/foo/kubeconfig", "--default-garden=bar"),
+		Entry("with invalid default", `--default-garden "Foo" is invalid`, "--default-garden=Foo"),
+	)
+})
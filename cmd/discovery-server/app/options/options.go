@@ -5,13 +5,7 @@
 package options
 
 import (
-	"errors"
-	"net"
-	"os"
 	"slices"
-	"strconv"
-	"strings"
-	"time"
 
 	"github.com/spf13/pflag"
 )
@@ -21,167 +15,90 @@ type Options struct {
 	ResyncOptions           ResyncOptions
 	ServingOptions          ServingOptions
 	WorkloadIdentityOptions WorkloadIdentityOptions
+	TracingOptions          TracingOptions
+	ShardingOptions         ShardingOptions
+	AdminOptions            AdminOptions
+	DeletionBreakerOptions  DeletionBreakerOptions
+	CacheOptions            CacheOptions
+	CORSOptions             CORSOptions
+	ShootNameAliasOptions   ShootNameAliasOptions
+	ClusterInfoOptions      ClusterInfoOptions
+	SigningOptions          SigningOptions
+	GardenOptions           GardenOptions
 }
 
-// ServingOptions are options applied to the discovery server.
-type ServingOptions struct {
-	TLSCertFile string
-	TLSKeyFile  string
-
-	Address string
-	Port    uint
+// NewOptions return options with default values.
+func NewOptions() *Options {
+	opts := &Options{
+		ResyncOptions: ResyncOptions{},
+	}
+	return opts
 }
 
 // AddFlags adds server options to flagset
-func (o *ServingOptions) AddFlags(fs *pflag.FlagSet) {
-	fs.StringVar(&o.TLSCertFile, "tls-cert-file", o.TLSCertFile, "File containing the x509 Certificate for HTTPS.")
-	fs.StringVar(&o.TLSKeyFile, "tls-private-key-file", o.TLSKeyFile, "File containing the x509 private key matching --tls-cert-file.")
-
-	fs.StringVar(&o.Address, "address", "", "The IP address that the server will listen on. If unspecified all interfaces will be used.")
-	fs.UintVar(&o.Port, "port", 10443, "The port that the server will listen on.")
+func (o *Options) AddFlags(fs *pflag.FlagSet) {
+	o.ServingOptions.AddFlags(fs)
+	o.ResyncOptions.AddFlags(fs)
+	o.WorkloadIdentityOptions.AddFlags(fs)
+	o.TracingOptions.AddFlags(fs)
+	o.ShardingOptions.AddFlags(fs)
+	o.AdminOptions.AddFlags(fs)
+	o.DeletionBreakerOptions.AddFlags(fs)
+	o.CacheOptions.AddFlags(fs)
+	o.CORSOptions.AddFlags(fs)
+	o.ShootNameAliasOptions.AddFlags(fs)
+	o.ClusterInfoOptions.AddFlags(fs)
+	o.SigningOptions.AddFlags(fs)
+	o.GardenOptions.AddFlags(fs)
 }
 
-// Validate checks if options are valid.
-func (o *ServingOptions) Validate() []error {
-	errs := []error{}
-	if strings.TrimSpace(o.TLSCertFile) == "" {
-		errs = append(errs, errors.New("--tls-cert-file is required"))
+// ApplyTo applies the options to the configuration.
+func (o *Options) ApplyTo(server *Config) error {
+	if err := o.ResyncOptions.ApplyTo(&server.Resync); err != nil {
+		return err
 	}
 
-	if strings.TrimSpace(o.TLSKeyFile) == "" {
-		errs = append(errs, errors.New("--tls-private-key-file is required"))
+	if err := o.WorkloadIdentityOptions.ApplyTo(&server.WorkloadIdentity); err != nil {
+		return err
 	}
 
-	return errs
-}
-
-// ApplyTo applies the options to the configuration.
-func (o *ServingOptions) ApplyTo(c *ServingConfig) error {
-	c.Address = net.JoinHostPort(o.Address, strconv.FormatUint(uint64(o.Port), 10))
-
-	c.TLSCertFile = o.TLSCertFile
-	c.TLSKeyFile = o.TLSKeyFile
-	return nil
-}
-
-// WorkloadIdentityOptions holds the options for the workload identity OIDC discovery documents.
-type WorkloadIdentityOptions struct {
-	// OpenIDConfigFile is the path to the file containing the openid configuration.
-	OpenIDConfigFile string
-	// JWKSFile is the path to the file containing the JWKS.
-	JWKSFile string
-}
-
-// WorkloadIdentityConfig holds the configuration regarding the workload identity OIDC discovery documents.
-type WorkloadIdentityConfig struct {
-	// OpenIDConfig is the workload identity openid configuration.
-	OpenIDConfig []byte
-	// JWKS is the workload identity JWKS.
-	JWKS []byte
-	// Enabled indicate whether the discovery server should serve workload identity discovery documents or not.
-	Enabled bool
-}
-
-// AddFlags adds workload identity options to  flagset
-func (o *WorkloadIdentityOptions) AddFlags(fs *pflag.FlagSet) {
-	fs.StringVar(&o.OpenIDConfigFile, "workload-identity-openid-configuration-file", o.OpenIDConfigFile, "Path to garden workload identity openid configuration file.")
-	fs.StringVar(&o.JWKSFile, "workload-identity-jwks-file", o.JWKSFile, "Path to garden workload identity JWKS file.")
-}
-
-// Validate checks if workload identity options are valid.
-func (o *WorkloadIdentityOptions) Validate() []error {
-	errs := []error{}
-
-	if strings.TrimSpace(o.OpenIDConfigFile) == "" && strings.TrimSpace(o.JWKSFile) == "" {
-		return nil
+	if err := o.TracingOptions.ApplyTo(&server.Tracing); err != nil {
+		return err
 	}
 
-	if strings.TrimSpace(o.OpenIDConfigFile) == "" {
-		errs = append(errs, errors.New(`flag "workload-identity-openid-configuration-file" must be set when "workload-identity-jwks-file" is set`))
-	}
-	if strings.TrimSpace(o.JWKSFile) == "" {
-		errs = append(errs, errors.New(`flag "workload-identity-jwks-file" must be set when "workload-identity-openid-configuration-file" is set`))
+	if err := o.ShardingOptions.ApplyTo(&server.Sharding); err != nil {
+		return err
 	}
 
-	return errs
-}
-
-// ApplyTo applies the options to the configuration.
-func (o *WorkloadIdentityOptions) ApplyTo(c *WorkloadIdentityConfig) error {
-	if strings.TrimSpace(o.OpenIDConfigFile) == "" {
-		// Serving workload identity discovery documents is optional,
-		// if the flags are not set, this feature is considered disabled
-		c.Enabled = false
-		return nil
+	if err := o.AdminOptions.ApplyTo(&server.Admin); err != nil {
+		return err
 	}
-	c.Enabled = true
 
-	var err error
-	c.OpenIDConfig, err = os.ReadFile(o.OpenIDConfigFile)
-	if err != nil {
+	if err := o.DeletionBreakerOptions.ApplyTo(&server.DeletionBreaker); err != nil {
 		return err
 	}
 
-	c.JWKS, err = os.ReadFile(o.JWKSFile)
-	if err != nil {
+	if err := o.CacheOptions.ApplyTo(&server.Cache); err != nil {
 		return err
 	}
 
-	return nil
-}
-
-// ResyncOptions holds options regarding the resync interval between reconciliations.
-type ResyncOptions struct {
-	Duration time.Duration
-}
-
-// AddFlags adds the [ResyncOptions] flags to the flagset.
-func (o *ResyncOptions) AddFlags(fs *pflag.FlagSet) {
-	fs.DurationVar(&o.Duration, "resync-period", time.Minute*30, "The period between reconciliations of cluster discovery information.")
-}
-
-// Validate checks if options are valid.
-func (o *ResyncOptions) Validate() []error {
-	var errs []error
-	if o.Duration <= 0 {
-		errs = append(errs, errors.New("--resync-period must be positive"))
+	if err := o.CORSOptions.ApplyTo(&server.CORS); err != nil {
+		return err
 	}
-	return errs
-}
 
-// ApplyTo applies the options to the configuration.
-func (o *ResyncOptions) ApplyTo(c *ResyncConfig) error {
-	c.Duration = o.Duration
-	return nil
-}
-
-// ResyncConfig holds configurations regarding the resync interval between reconciliations.
-type ResyncConfig struct {
-	Duration time.Duration
-}
-
-// NewOptions return options with default values.
-func NewOptions() *Options {
-	opts := &Options{
-		ResyncOptions: ResyncOptions{},
+	if err := o.ShootNameAliasOptions.ApplyTo(&server.ShootNameAlias); err != nil {
+		return err
 	}
-	return opts
-}
 
-// AddFlags adds server options to flagset
-func (o *Options) AddFlags(fs *pflag.FlagSet) {
-	o.ServingOptions.AddFlags(fs)
-	o.ResyncOptions.AddFlags(fs)
-	o.WorkloadIdentityOptions.AddFlags(fs)
-}
+	if err := o.ClusterInfoOptions.ApplyTo(&server.ClusterInfo); err != nil {
+		return err
+	}
 
-// ApplyTo applies the options to the configuration.
-func (o *Options) ApplyTo(server *Config) error {
-	if err := o.ResyncOptions.ApplyTo(&server.Resync); err != nil {
+	if err := o.SigningOptions.ApplyTo(&server.Signing); err != nil {
 		return err
 	}
 
-	if err := o.WorkloadIdentityOptions.ApplyTo(&server.WorkloadIdentity); err != nil {
+	if err := o.GardenOptions.ApplyTo(&server.Garden); err != nil {
 		return err
 	}
 
@@ -194,6 +111,16 @@ func (o *Options) Validate() []error {
 		o.ResyncOptions.Validate(),
 		o.ServingOptions.Validate(),
 		o.WorkloadIdentityOptions.Validate(),
+		o.TracingOptions.Validate(),
+		o.ShardingOptions.Validate(),
+		o.AdminOptions.Validate(),
+		o.DeletionBreakerOptions.Validate(),
+		o.CacheOptions.Validate(),
+		o.CORSOptions.Validate(),
+		o.ShootNameAliasOptions.Validate(),
+		o.ClusterInfoOptions.Validate(),
+		o.SigningOptions.Validate(),
+		o.GardenOptions.Validate(),
 	)
 }
 
@@ -202,12 +129,14 @@ type Config struct {
 	Resync           ResyncConfig
 	Serving          ServingConfig
 	WorkloadIdentity WorkloadIdentityConfig
-}
-
-// ServingConfig has the context to run an http server.
-type ServingConfig struct {
-	Address string
-
-	TLSCertFile string
-	TLSKeyFile  string
+	Tracing          TracingConfig
+	Sharding         ShardingConfig
+	Admin            AdminConfig
+	DeletionBreaker  DeletionBreakerConfig
+	Cache            CacheConfig
+	CORS             CORSConfig
+	ShootNameAlias   ShootNameAliasConfig
+	ClusterInfo      ClusterInfoConfig
+	Signing          SigningConfig
+	Garden           GardenConfig
 }
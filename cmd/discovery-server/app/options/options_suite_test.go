@@ -0,0 +1,32 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package options_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/pflag"
+)
+
+func TestOptions(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Options Test Suite")
+}
+
+// flagOptions are options whose defaults are set by their flags.
+type flagOptions interface {
+	AddFlags(fs *pflag.FlagSet)
+	Validate() []error
+}
+
+// parse applies the defaults and the arguments to the options.
+func parse(o flagOptions, args ...string) flagOptions {
+	fs := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	o.AddFlags(fs)
+	ExpectWithOffset(1, fs.Parse(args)).To(Succeed())
+	return o
+}
@@ -0,0 +1,42 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package options
+
+import (
+	"errors"
+	"time"
+
+	"github.com/spf13/pflag"
+)
+
+// ResyncOptions holds options regarding the resync interval between reconciliations.
+type ResyncOptions struct {
+	Duration time.Duration
+}
+
+// AddFlags adds the [ResyncOptions] flags to the flagset.
+func (o *ResyncOptions) AddFlags(fs *pflag.FlagSet) {
+	fs.DurationVar(&o.Duration, "resync-period", time.Minute*30, "The period between reconciliations of cluster discovery information.")
+}
+
+// Validate checks if options are valid.
+func (o *ResyncOptions) Validate() []error {
+	var errs []error
+	if o.Duration <= 0 {
+		errs = append(errs, errors.New("--resync-period must be positive"))
+	}
+	return errs
+}
+
+// ApplyTo applies the options to the configuration.
+func (o *ResyncOptions) ApplyTo(c *ResyncConfig) error {
+	c.Duration = o.Duration
+	return nil
+}
+
+// ResyncConfig holds configurations regarding the resync interval between reconciliations.
+type ResyncConfig struct {
+	Duration time.Duration
+}
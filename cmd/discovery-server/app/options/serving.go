@@ -0,0 +1,360 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package options
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/netip"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/pflag"
+	cliflag "k8s.io/component-base/cli/flag"
+)
+
+// ServingOptions are options applied to the discovery server.
+type ServingOptions struct {
+	TLSCertFile    string
+	TLSKeyFile     string
+	TLSSNICertKeys []cliflag.NamedCertKey
+
+	TLSMinVersion       string
+	TLSCipherSuites     []string
+	TLSCurvePreferences []string
+
+	Address string
+	Port    uint
+
+	ReadTimeout       time.Duration
+	ReadHeaderTimeout time.Duration
+	WriteTimeout      time.Duration
+	IdleTimeout       time.Duration
+	MaxHeaderBytes    int
+
+	MaxConnections      int
+	MaxConnectionsPerIP int
+
+	HTTPPort         uint
+	HTTPAllowedCIDRs []string
+
+	ProxyProtocol             bool
+	ProxyProtocolAllowedCIDRs []string
+
+	MetricsBindAddress     string
+	HealthProbeBindAddress string
+}
+
+// AddFlags adds server options to flagset
+func (o *ServingOptions) AddFlags(fs *pflag.FlagSet) {
+	fs.StringVar(&o.TLSCertFile, "tls-cert-file", o.TLSCertFile, "File containing the x509 Certificate for HTTPS.")
+	fs.StringVar(&o.TLSKeyFile, "tls-private-key-file", o.TLSKeyFile, "File containing the x509 private key matching --tls-cert-file.")
+	fs.Var(cliflag.NewNamedCertKeyArray(&o.TLSSNICertKeys), "tls-sni-cert-key", "A pair of x509 certificate and private key file paths, optionally suffixed with a list of domain patterns, "+
+		"e.g. \"example.crt,example.key\" or \"foo.crt,foo.key:*.foo.com,foo.com\". The certificate is served to clients requesting one of the domains via SNI. "+
+		"If no domain patterns are given, the DNS names of the certificate are used. Wildcard patterns match exactly one label. "+
+		"The certificate from --tls-cert-file is served if no pattern matches. May be given multiple times.")
+	fs.StringVar(&o.TLSMinVersion, "tls-min-version", "VersionTLS12", "Minimum TLS version supported. Possible values: VersionTLS12, VersionTLS13.")
+	fs.StringSliceVar(&o.TLSCipherSuites, "tls-cipher-suites", nil, "Comma-separated list of cipher suites for the server. "+
+		"If omitted, the default Go cipher suites without CBC mode will be used. Must not be set if --tls-min-version is VersionTLS13.\n"+
+		"Preferred values: "+strings.Join(cliflag.PreferredTLSCipherNames(), ", ")+".")
+	fs.StringSliceVar(&o.TLSCurvePreferences, "tls-curve-preferences", nil, "Comma-separated list of elliptic curves used in ECDHE handshakes. "+
+		"If omitted, the default Go curves will be used. Possible values: "+strings.Join(tlsCurveNames(), ", ")+".")
+
+	fs.StringVar(&o.Address, "address", "", "The IP address that the server will listen on. If unspecified all interfaces will be used.")
+	fs.UintVar(&o.Port, "port", 10443, "The port that the server will listen on.")
+
+	fs.DurationVar(&o.ReadTimeout, "read-timeout", 10*time.Second, "The maximum duration for reading an entire request, including the body.")
+	fs.DurationVar(&o.ReadHeaderTimeout, "read-header-timeout", 5*time.Second, "The maximum duration for reading the request headers.")
+	fs.DurationVar(&o.WriteTimeout, "write-timeout", 10*time.Second, "The maximum duration before timing out writes of the response.")
+	fs.DurationVar(&o.IdleTimeout, "idle-timeout", 2*time.Minute, "The maximum duration to wait for the next request when keep-alives are enabled.")
+	fs.IntVar(&o.MaxHeaderBytes, "max-header-bytes", 32<<10, "The maximum number of bytes the server will read parsing the request headers.")
+
+	fs.IntVar(&o.MaxConnections, "max-connections", 0, "The maximum number of simultaneous connections per listener. Zero means no limit.")
+	fs.IntVar(&o.MaxConnectionsPerIP, "max-connections-per-ip", 0, "The maximum number of simultaneous connections from a single IP address per listener. Zero means no limit.")
+
+	fs.UintVar(&o.HTTPPort, "http-port", 0, "The port of an additional plain HTTP listener, e.g. for load balancers terminating TLS. Zero disables the listener. Requires --http-allowed-cidrs.")
+	fs.StringSliceVar(&o.HTTPAllowedCIDRs, "http-allowed-cidrs", nil, "Comma-separated list of CIDRs that are allowed to connect to the plain HTTP listener.")
+	fs.BoolVar(&o.ProxyProtocol, "proxy-protocol", false, "Require a PROXY protocol v1 or v2 header on all connections and use the conveyed client address. Requires --proxy-protocol-allowed-cidrs and --max-connections.")
+	fs.StringSliceVar(&o.ProxyProtocolAllowedCIDRs, "proxy-protocol-allowed-cidrs", nil, "Comma-separated list of CIDRs of proxies that are allowed to connect. Required if --proxy-protocol is set.")
+
+	fs.StringVar(&o.MetricsBindAddress, "metrics-bind-address", ":8080", "The address the metrics endpoint binds to.")
+	fs.StringVar(&o.HealthProbeBindAddress, "health-probe-bind-address", ":8081", "The address the health and readiness probes bind to.")
+}
+
+// Validate checks if options are valid.
+func (o *ServingOptions) Validate() []error {
+	errs := []error{}
+	if strings.TrimSpace(o.TLSCertFile) == "" {
+		errs = append(errs, errors.New("--tls-cert-file is required"))
+	}
+
+	if strings.TrimSpace(o.TLSKeyFile) == "" {
+		errs = append(errs, errors.New("--tls-private-key-file is required"))
+	}
+
+	for i, sni := range o.TLSSNICertKeys {
+		if strings.TrimSpace(sni.CertFile) == "" || strings.TrimSpace(sni.KeyFile) == "" {
+			errs = append(errs, fmt.Errorf("--tls-sni-cert-key[%d] requires both certificate and key file", i))
+		}
+		for _, name := range sni.Names {
+			if name == "" || strings.Contains(strings.TrimPrefix(name, "*."), "*") {
+				errs = append(errs, fmt.Errorf("--tls-sni-cert-key[%d] has invalid domain pattern %q", i, name))
+			}
+		}
+	}
+
+	minVersion, err := cliflag.TLSVersion(o.TLSMinVersion)
+	if err != nil {
+		errs = append(errs, fmt.Errorf("--tls-min-version is invalid: %w", err))
+	} else if minVersion < tls.VersionTLS12 {
+		errs = append(errs, errors.New("--tls-min-version must be VersionTLS12 or VersionTLS13"))
+	}
+
+	cipherSuites, err := cliflag.TLSCipherSuites(o.TLSCipherSuites)
+	if err != nil {
+		errs = append(errs, fmt.Errorf("--tls-cipher-suites is invalid: %w", err))
+	}
+	if minVersion == tls.VersionTLS13 && len(o.TLSCipherSuites) > 0 {
+		errs = append(errs, errors.New("--tls-cipher-suites must not be set if --tls-min-version is VersionTLS13, TLS 1.3 cipher suites are not configurable"))
+	}
+	for _, id := range cipherSuites {
+		if slices.ContainsFunc(tls.InsecureCipherSuites(), func(cs *tls.CipherSuite) bool { return cs.ID == id }) {
+			errs = append(errs, fmt.Errorf("--tls-cipher-suites contains insecure cipher suite %s", tls.CipherSuiteName(id)))
+		}
+	}
+
+	if _, err := tlsCurves(o.TLSCurvePreferences); err != nil {
+		errs = append(errs, fmt.Errorf("--tls-curve-preferences is invalid: %w", err))
+	}
+
+	if o.ReadTimeout <= 0 {
+		errs = append(errs, errors.New("--read-timeout must be positive"))
+	}
+	if o.ReadHeaderTimeout <= 0 {
+		errs = append(errs, errors.New("--read-header-timeout must be positive"))
+	}
+	if o.ReadHeaderTimeout > o.ReadTimeout {
+		errs = append(errs, errors.New("--read-header-timeout must not be greater than --read-timeout"))
+	}
+	if o.WriteTimeout <= 0 {
+		errs = append(errs, errors.New("--write-timeout must be positive"))
+	}
+	if o.IdleTimeout <= 0 {
+		errs = append(errs, errors.New("--idle-timeout must be positive"))
+	}
+	if o.MaxHeaderBytes <= 0 {
+		errs = append(errs, errors.New("--max-header-bytes must be positive"))
+	}
+	if o.MaxConnections < 0 {
+		errs = append(errs, errors.New("--max-connections must not be negative"))
+	}
+	if o.MaxConnectionsPerIP < 0 {
+		errs = append(errs, errors.New("--max-connections-per-ip must not be negative"))
+	}
+
+	if o.HTTPPort > 0 {
+		if o.HTTPPort > 65535 {
+			errs = append(errs, errors.New("--http-port must not be greater than 65535"))
+		}
+		if o.HTTPPort == o.Port {
+			errs = append(errs, errors.New("--http-port must differ from --port"))
+		}
+		if len(o.HTTPAllowedCIDRs) == 0 {
+			errs = append(errs, errors.New("--http-allowed-cidrs is required if --http-port is set"))
+		}
+	}
+	if _, err := parseCIDRs(o.HTTPAllowedCIDRs); err != nil {
+		errs = append(errs, fmt.Errorf("--http-allowed-cidrs is invalid: %w", err))
+	}
+	if _, err := parseCIDRs(o.ProxyProtocolAllowedCIDRs); err != nil {
+		errs = append(errs, fmt.Errorf("--proxy-protocol-allowed-cidrs is invalid: %w", err))
+	}
+	if !o.ProxyProtocol && len(o.ProxyProtocolAllowedCIDRs) > 0 {
+		errs = append(errs, errors.New("--proxy-protocol-allowed-cidrs requires --proxy-protocol"))
+	}
+	if o.ProxyProtocol {
+		// Every accepted connection waits for its PROXY protocol header in its own goroutine,
+		// thus the sources and the number of pending connections must be bounded.
+		if len(o.ProxyProtocolAllowedCIDRs) == 0 {
+			errs = append(errs, errors.New("--proxy-protocol-allowed-cidrs is required if --proxy-protocol is set"))
+		}
+		if o.MaxConnections == 0 {
+			errs = append(errs, errors.New("--max-connections is required if --proxy-protocol is set"))
+		}
+	}
+
+	if err := validateBindAddress(o.MetricsBindAddress); err != nil {
+		errs = append(errs, fmt.Errorf("--metrics-bind-address is invalid: %w", err))
+	}
+	if err := validateBindAddress(o.HealthProbeBindAddress); err != nil {
+		errs = append(errs, fmt.Errorf("--health-probe-bind-address is invalid: %w", err))
+	}
+
+	return errs
+}
+
+// ApplyTo applies the options to the configuration.
+func (o *ServingOptions) ApplyTo(c *ServingConfig) error {
+	c.Address = net.JoinHostPort(o.Address, strconv.FormatUint(uint64(o.Port), 10))
+
+	c.TLSCertFile = o.TLSCertFile
+	c.TLSKeyFile = o.TLSKeyFile
+	c.TLSSNICertKeys = nil
+	for _, sni := range o.TLSSNICertKeys {
+		c.TLSSNICertKeys = append(c.TLSSNICertKeys, SNICertKey{
+			CertFile: sni.CertFile,
+			KeyFile:  sni.KeyFile,
+			Names:    sni.Names,
+		})
+	}
+
+	var err error
+	if c.TLSMinVersion, err = cliflag.TLSVersion(o.TLSMinVersion); err != nil {
+		return err
+	}
+	if c.TLSCipherSuites, err = cliflag.TLSCipherSuites(o.TLSCipherSuites); err != nil {
+		return err
+	}
+	if c.TLSCurvePreferences, err = tlsCurves(o.TLSCurvePreferences); err != nil {
+		return err
+	}
+
+	c.ReadTimeout = o.ReadTimeout
+	c.ReadHeaderTimeout = o.ReadHeaderTimeout
+	c.WriteTimeout = o.WriteTimeout
+	c.IdleTimeout = o.IdleTimeout
+	c.MaxHeaderBytes = o.MaxHeaderBytes
+
+	c.MaxConnections = o.MaxConnections
+	c.MaxConnectionsPerIP = o.MaxConnectionsPerIP
+
+	c.HTTPAddress = ""
+	if o.HTTPPort > 0 {
+		c.HTTPAddress = net.JoinHostPort(o.Address, strconv.FormatUint(uint64(o.HTTPPort), 10))
+	}
+	if c.HTTPAllowedCIDRs, err = parseCIDRs(o.HTTPAllowedCIDRs); err != nil {
+		return err
+	}
+
+	c.ProxyProtocol = o.ProxyProtocol
+	if c.ProxyProtocolAllowedCIDRs, err = parseCIDRs(o.ProxyProtocolAllowedCIDRs); err != nil {
+		return err
+	}
+
+	c.MetricsAddress = o.MetricsBindAddress
+	c.HealthAddress = o.HealthProbeBindAddress
+	return nil
+}
+
+// validateBindAddress checks that the address is in the form [host]:port.
+func validateBindAddress(address string) error {
+	_, port, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	if _, err := strconv.ParseUint(port, 10, 16); err != nil {
+		return fmt.Errorf("port %q is invalid", port)
+	}
+	return nil
+}
+
+func parseCIDRs(cidrs []string) ([]netip.Prefix, error) {
+	prefixes := make([]netip.Prefix, 0, len(cidrs))
+	for _, cidr := range cidrs {
+		prefix, err := netip.ParsePrefix(strings.TrimSpace(cidr))
+		if err != nil {
+			return nil, err
+		}
+		prefixes = append(prefixes, prefix.Masked())
+	}
+	return prefixes, nil
+}
+
+// supportedCurves are the elliptic curves that can be configured with --tls-curve-preferences.
+var supportedCurves = []tls.CurveID{
+	tls.X25519MLKEM768,
+	tls.X25519,
+	tls.CurveP256,
+	tls.CurveP384,
+	tls.CurveP521,
+}
+
+func tlsCurveNames() []string {
+	names := make([]string, 0, len(supportedCurves))
+	for _, c := range supportedCurves {
+		names = append(names, c.String())
+	}
+	return names
+}
+
+func tlsCurves(names []string) ([]tls.CurveID, error) {
+	if len(names) == 0 {
+		return nil, nil
+	}
+	curves := make([]tls.CurveID, 0, len(names))
+	for _, name := range names {
+		idx := slices.IndexFunc(supportedCurves, func(c tls.CurveID) bool { return c.String() == name })
+		if idx < 0 {
+			return nil, fmt.Errorf("curve %q is not supported", name)
+		}
+		curves = append(curves, supportedCurves[idx])
+	}
+	return curves, nil
+}
+
+// ServingConfig has the context to run an http server.
+type ServingConfig struct {
+	Address string
+
+	TLSCertFile string
+	TLSKeyFile  string
+	// TLSSNICertKeys are additional certificates selected by the server name indication of the client.
+	TLSSNICertKeys []SNICertKey
+
+	TLSMinVersion uint16
+	// TLSCipherSuites are the configured cipher suites, the server defaults are used if it is empty.
+	TLSCipherSuites []uint16
+	// TLSCurvePreferences are the configured curves, the Go defaults are used if it is empty.
+	TLSCurvePreferences []tls.CurveID
+
+	ReadTimeout       time.Duration
+	ReadHeaderTimeout time.Duration
+	WriteTimeout      time.Duration
+	IdleTimeout       time.Duration
+	MaxHeaderBytes    int
+
+	// MaxConnections is the maximum number of simultaneous connections, zero means no limit.
+	MaxConnections int
+	// MaxConnectionsPerIP is the maximum number of simultaneous connections from a single IP, zero means no limit.
+	MaxConnectionsPerIP int
+
+	// HTTPAddress is the address of the plain HTTP listener, the listener is disabled if it is empty.
+	HTTPAddress string
+	// HTTPAllowedCIDRs are the source networks allowed to connect to the plain HTTP listener.
+	HTTPAllowedCIDRs []netip.Prefix
+
+	// ProxyProtocol indicates whether connections must start with a PROXY protocol header.
+	ProxyProtocol bool
+	// ProxyProtocolAllowedCIDRs are the source networks of proxies allowed to connect.
+	ProxyProtocolAllowedCIDRs []netip.Prefix
+
+	// MetricsAddress is the address of the metrics endpoint.
+	MetricsAddress string
+	// HealthAddress is the address of the health and readiness probes.
+	HealthAddress string
+}
+
+// SNICertKey is a certificate served for a set of domains.
+type SNICertKey struct {
+	CertFile string
+	KeyFile  string
+	// Names are the domain patterns the certificate is served for.
+	// The DNS names of the certificate are used if it is empty.
+	Names []string
+}
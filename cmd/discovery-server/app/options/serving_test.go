@@ -0,0 +1,179 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package options_test
+
+import (
+	"crypto/tls"
+	"net/netip"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/gardener/gardener-discovery-server/cmd/discovery-server/app/options"
+)
+
+var _ = Describe("ServingOptions", func() {
+	var args []string
+
+	BeforeEach(func() {
+		args = []string{"--tls-cert-file=tls.crt", "--tls-private-key-file=tls.key"}
+	})
+
+	// expectInvalid expects the options parsed from the arguments to be rejected with the message.
+	expectInvalid := func(match string, extraArgs ...string) {
+		Expect(parse(&options.ServingOptions{}, append(args, extraArgs...)...).Validate()).To(ContainElement(MatchError(ContainSubstring(match))))
+	}
+
+	It("should require the serving certificate", func() {
+		Expect(parse(&options.ServingOptions{}).Validate()).To(ConsistOf(
+			MatchError("--tls-cert-file is required"),
+			MatchError("--tls-private-key-file is required"),
+		))
+	})
+
+	Context("bind addresses", func() {
+		It("should apply the bind addresses of the metrics and the health probes", func() {
+			o := &options.ServingOptions{}
+			Expect(parse(o, args...).Validate()).To(BeEmpty())
+
+			c := &options.ServingConfig{}
+			Expect(o.ApplyTo(c)).To(Succeed())
+			Expect(c.MetricsAddress).To(Equal(":8080"))
+			Expect(c.HealthAddress).To(Equal(":8081"))
+
+			Expect(parse(o, append(args, "--metrics-bind-address=127.0.0.1:9090", "--health-probe-bind-address=[::1]:9091")...).Validate()).To(BeEmpty())
+			Expect(o.ApplyTo(c)).To(Succeed())
+			Expect(c.MetricsAddress).To(Equal("127.0.0.1:9090"))
+			Expect(c.HealthAddress).To(Equal("[::1]:9091"))
+		})
+
+		DescribeTable("should reject invalid bind addresses", expectInvalid,
+			Entry("metrics address without port", "--metrics-bind-address is invalid", "--metrics-bind-address=127.0.0.1"),
+			Entry("health address with invalid port", `--health-probe-bind-address is invalid: port "http" is invalid`, "--health-probe-bind-address=:http"),
+		)
+	})
+
+	Context("hardening", func() {
+		It("should apply the timeouts and limits", func() {
+			o := &options.ServingOptions{}
+			Expect(parse(o, append(args,
+				"--read-timeout=20s",
+				"--read-header-timeout=2s",
+				"--write-timeout=30s",
+				"--idle-timeout=1m",
+				"--max-header-bytes=8192",
+				"--max-connections=1000",
+				"--max-connections-per-ip=10",
+			)...).Validate()).To(BeEmpty())
+
+			c := &options.ServingConfig{}
+			Expect(o.ApplyTo(c)).To(Succeed())
+			Expect(c.ReadTimeout).To(Equal(20 * time.Second))
+			Expect(c.ReadHeaderTimeout).To(Equal(2 * time.Second))
+			Expect(c.WriteTimeout).To(Equal(30 * time.Second))
+			Expect(c.IdleTimeout).To(Equal(time.Minute))
+			Expect(c.MaxHeaderBytes).To(Equal(8192))
+			Expect(c.MaxConnections).To(Equal(1000))
+			Expect(c.MaxConnectionsPerIP).To(Equal(10))
+		})
+
+		DescribeTable("should reject invalid timeouts and limits", expectInvalid,
+			Entry("zero read timeout", "--read-timeout must be positive", "--read-timeout=0s"),
+			Entry("zero read header timeout", "--read-header-timeout must be positive", "--read-header-timeout=0s"),
+			Entry("read header timeout above the read timeout", "--read-header-timeout must not be greater than --read-timeout", "--read-timeout=5s", "--read-header-timeout=10s"),
+			Entry("zero write timeout", "--write-timeout must be positive", "--write-timeout=0s"),
+			Entry("negative idle timeout", "--idle-timeout must be positive", "--idle-timeout=-1s"),
+			Entry("zero max header bytes", "--max-header-bytes must be positive", "--max-header-bytes=0"),
+			Entry("negative max connections", "--max-connections must not be negative", "--max-connections=-1"),
+			Entry("negative max connections per IP", "--max-connections-per-ip must not be negative", "--max-connections-per-ip=-1"),
+		)
+	})
+
+	Context("TLS policy", func() {
+		It("should apply the TLS version, cipher suites and curves", func() {
+			o := &options.ServingOptions{}
+			Expect(parse(o, append(args,
+				"--tls-cipher-suites=TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256",
+				"--tls-curve-preferences=X25519MLKEM768,CurveP256",
+			)...).Validate()).To(BeEmpty())
+
+			c := &options.ServingConfig{}
+			Expect(o.ApplyTo(c)).To(Succeed())
+			Expect(c.TLSMinVersion).To(Equal(uint16(tls.VersionTLS12)))
+			Expect(c.TLSCipherSuites).To(Equal([]uint16{tls.TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256}))
+			Expect(c.TLSCurvePreferences).To(Equal([]tls.CurveID{tls.X25519MLKEM768, tls.CurveP256}))
+		})
+
+		It("should allow TLS 1.3 only", func() {
+			o := &options.ServingOptions{}
+			Expect(parse(o, append(args, "--tls-min-version=VersionTLS13")...).Validate()).To(BeEmpty())
+
+			c := &options.ServingConfig{}
+			Expect(o.ApplyTo(c)).To(Succeed())
+			Expect(c.TLSMinVersion).To(Equal(uint16(tls.VersionTLS13)))
+		})
+
+		DescribeTable("should reject invalid TLS policies", expectInvalid,
+			Entry("unknown TLS version", "--tls-min-version is invalid", "--tls-min-version=VersionTLS99"),
+			Entry("TLS version below 1.2", "--tls-min-version must be VersionTLS12 or VersionTLS13", "--tls-min-version=VersionTLS11"),
+			Entry("unknown cipher suite", "--tls-cipher-suites is invalid", "--tls-cipher-suites=foo"),
+			Entry("cipher suites with TLS 1.3", "--tls-cipher-suites must not be set", "--tls-min-version=VersionTLS13", "--tls-cipher-suites=TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256"),
+			Entry("insecure cipher suite", "--tls-cipher-suites contains insecure cipher suite", "--tls-cipher-suites=TLS_RSA_WITH_RC4_128_SHA"),
+			Entry("unsupported curve", `--tls-curve-preferences is invalid: curve "P-224" is not supported`, "--tls-curve-preferences=P-224"),
+		)
+	})
+
+	Context("SNI certificates", func() {
+		It("should apply the certificates and their domain patterns", func() {
+			o := &options.ServingOptions{}
+			Expect(parse(o, append(args, "--tls-sni-cert-key=foo.crt,foo.key:*.foo.com,foo.com", "--tls-sni-cert-key=bar.crt,bar.key")...).Validate()).To(BeEmpty())
+
+			c := &options.ServingConfig{}
+			Expect(o.ApplyTo(c)).To(Succeed())
+			Expect(c.TLSSNICertKeys).To(Equal([]options.SNICertKey{
+				{CertFile: "foo.crt", KeyFile: "foo.key", Names: []string{"*.foo.com", "foo.com"}},
+				{CertFile: "bar.crt", KeyFile: "bar.key"},
+			}))
+		})
+
+		DescribeTable("should reject invalid domain patterns", expectInvalid,
+			Entry("wildcard inside the domain", `--tls-sni-cert-key[0] has invalid domain pattern "foo.*.com"`, "--tls-sni-cert-key=foo.crt,foo.key:foo.*.com"),
+			Entry("double wildcard", `--tls-sni-cert-key[0] has invalid domain pattern "*.*.com"`, "--tls-sni-cert-key=foo.crt,foo.key:*.*.com"),
+		)
+	})
+
+	Context("plain HTTP and PROXY protocol listeners", func() {
+		It("should apply the listeners and their allowed CIDRs", func() {
+			o := &options.ServingOptions{}
+			Expect(parse(o, append(args,
+				"--address=127.0.0.1",
+				"--http-port=8080",
+				"--http-allowed-cidrs=10.0.0.1/8, 2001:db8::/32",
+				"--proxy-protocol",
+				"--proxy-protocol-allowed-cidrs=192.168.0.0/16",
+				"--max-connections=1000",
+			)...).Validate()).To(BeEmpty())
+
+			c := &options.ServingConfig{}
+			Expect(o.ApplyTo(c)).To(Succeed())
+			Expect(c.HTTPAddress).To(Equal("127.0.0.1:8080"))
+			Expect(c.HTTPAllowedCIDRs).To(Equal([]netip.Prefix{netip.MustParsePrefix("10.0.0.0/8"), netip.MustParsePrefix("2001:db8::/32")}))
+			Expect(c.ProxyProtocol).To(BeTrue())
+			Expect(c.ProxyProtocolAllowedCIDRs).To(Equal([]netip.Prefix{netip.MustParsePrefix("192.168.0.0/16")}))
+		})
+
+		DescribeTable("should reject invalid listeners", expectInvalid,
+			Entry("invalid HTTP CIDR", "--http-allowed-cidrs is invalid", "--http-port=8080", "--http-allowed-cidrs=10.0.0.0"),
+			Entry("HTTP port without CIDRs", "--http-allowed-cidrs is required if --http-port is set", "--http-port=8080"),
+			Entry("HTTP port equal to the port", "--http-port must differ from --port", "--http-port=10443", "--http-allowed-cidrs=10.0.0.0/8"),
+			Entry("HTTP port out of range", "--http-port must not be greater than 65535", "--http-port=65536", "--http-allowed-cidrs=10.0.0.0/8"),
+			Entry("invalid proxy CIDR", "--proxy-protocol-allowed-cidrs is invalid", "--proxy-protocol", "--proxy-protocol-allowed-cidrs=foo", "--max-connections=1000"),
+			Entry("PROXY protocol without CIDRs", "--proxy-protocol-allowed-cidrs is required if --proxy-protocol is set", "--proxy-protocol", "--max-connections=1000"),
+			Entry("PROXY protocol without connection limit", "--max-connections is required if --proxy-protocol is set", "--proxy-protocol", "--proxy-protocol-allowed-cidrs=10.0.0.0/8"),
+			Entry("proxy CIDRs without PROXY protocol", "--proxy-protocol-allowed-cidrs requires --proxy-protocol", "--proxy-protocol-allowed-cidrs=10.0.0.0/8"),
+		)
+	})
+})
@@ -0,0 +1,133 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package options
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/pflag"
+)
+
+// ShardingOptions holds options regarding the partitioning of projects between replicas.
+type ShardingOptions struct {
+	// Enabled indicates whether each replica reconciles and stores only its share of the projects.
+	// The informers are not sharded, every replica still caches the objects of all projects.
+	Enabled bool
+	// LeaseNamespace is the namespace of the leases announcing the replicas.
+	LeaseNamespace string
+	// Identity is the unique name of the replica.
+	Identity string
+	// AdvertiseAddress is the URL other replicas forward requests to.
+	AdvertiseAddress string
+	// LeaseDuration is the duration after which a replica that did not renew its lease is considered gone.
+	LeaseDuration time.Duration
+	// PeerPort is the port of the listener receiving the requests forwarded by other replicas.
+	PeerPort uint
+	// PeerCertFile is the path to the client certificate presented to other replicas.
+	PeerCertFile string
+	// PeerKeyFile is the path to the key of the client certificate presented to other replicas.
+	PeerKeyFile string
+	// PeerCAFile is the path to the CA bundle used to verify the server and client certificates of other replicas.
+	PeerCAFile string
+	// PeerServerName is the server name used to verify the certificates of other replicas.
+	PeerServerName string
+}
+
+// AddFlags adds the [ShardingOptions] flags to the flagset.
+func (o *ShardingOptions) AddFlags(fs *pflag.FlagSet) {
+	hostname, _ := os.Hostname()
+	fs.BoolVar(&o.Enabled, "sharding-enabled", false, "Partition the projects between the replicas, so that each replica reconciles and stores only its share. "+
+		"Requests for projects of other replicas are forwarded to their owner. The informer caches are not partitioned, every replica still watches and caches the objects of all projects. "+
+		"Requires permissions to manage leases in --sharding-lease-namespace.")
+	fs.StringVar(&o.LeaseNamespace, "sharding-lease-namespace", "", "Namespace of the leases announcing the replicas.")
+	fs.StringVar(&o.Identity, "sharding-identity", hostname, "Unique name of the replica. Defaults to the hostname.")
+	fs.StringVar(&o.AdvertiseAddress, "sharding-advertise-address", "", "URL of the peer listener other replicas forward requests to, e.g. https://10.0.0.1:10444.")
+	fs.DurationVar(&o.LeaseDuration, "sharding-lease-duration", 15*time.Second, "Duration after which a replica that did not renew its lease is considered gone.")
+	fs.UintVar(&o.PeerPort, "sharding-peer-port", 10444, "The port of the peer listener receiving the requests forwarded by other replicas. "+
+		"It listens on --address and requires client certificates signed by --sharding-peer-ca-file.")
+	fs.StringVar(&o.PeerCertFile, "sharding-peer-cert-file", "", "File containing the client certificate presented to other replicas when forwarding requests.")
+	fs.StringVar(&o.PeerKeyFile, "sharding-peer-key-file", "", "File containing the key of the client certificate presented to other replicas.")
+	fs.StringVar(&o.PeerCAFile, "sharding-peer-ca-file", "", "File containing the CA bundle used to verify the server and client certificates of other replicas.")
+	fs.StringVar(&o.PeerServerName, "sharding-peer-server-name", "", "Server name used to verify the certificates of other replicas, e.g. the DNS name of the discovery server.")
+}
+
+// Validate checks if options are valid.
+func (o *ShardingOptions) Validate() []error {
+	if !o.Enabled {
+		return nil
+	}
+
+	var errs []error
+	if strings.TrimSpace(o.LeaseNamespace) == "" {
+		errs = append(errs, errors.New("--sharding-lease-namespace is required if --sharding-enabled is set"))
+	}
+	if strings.TrimSpace(o.Identity) == "" {
+		errs = append(errs, errors.New("--sharding-identity is required if --sharding-enabled is set"))
+	}
+	if u, err := url.Parse(o.AdvertiseAddress); err != nil || u.Scheme != "https" || u.Host == "" {
+		errs = append(errs, errors.New("--sharding-advertise-address must be an https URL if --sharding-enabled is set"))
+	}
+	if o.LeaseDuration < 3*time.Second {
+		errs = append(errs, errors.New("--sharding-lease-duration must be at least 3s"))
+	}
+	if o.PeerPort == 0 || o.PeerPort > 65535 {
+		errs = append(errs, errors.New("--sharding-peer-port must be a valid port if --sharding-enabled is set"))
+	}
+	// The peers authenticate each other, so that clients cannot forge forwarded requests.
+	for _, f := range []struct{ flag, value string }{
+		{"--sharding-peer-cert-file", o.PeerCertFile},
+		{"--sharding-peer-key-file", o.PeerKeyFile},
+		{"--sharding-peer-ca-file", o.PeerCAFile},
+	} {
+		if strings.TrimSpace(f.value) == "" {
+			errs = append(errs, fmt.Errorf("%s is required if --sharding-enabled is set", f.flag))
+		}
+	}
+	return errs
+}
+
+// ApplyTo applies the options to the configuration.
+func (o *ShardingOptions) ApplyTo(c *ShardingConfig) error {
+	c.Enabled = o.Enabled
+	c.LeaseNamespace = o.LeaseNamespace
+	c.Identity = o.Identity
+	c.AdvertiseAddress = o.AdvertiseAddress
+	c.LeaseDuration = o.LeaseDuration
+	c.PeerPort = o.PeerPort
+	c.PeerCertFile = o.PeerCertFile
+	c.PeerKeyFile = o.PeerKeyFile
+	c.PeerServerName = o.PeerServerName
+
+	if o.PeerCAFile != "" {
+		ca, err := os.ReadFile(o.PeerCAFile)
+		if err != nil {
+			return fmt.Errorf("failed to read sharding peer CA file: %w", err)
+		}
+		c.PeerCA = ca
+	}
+	return nil
+}
+
+// ShardingConfig holds configurations regarding the partitioning of projects between replicas.
+type ShardingConfig struct {
+	// Enabled indicates whether each replica reconciles and stores only its share of the projects.
+	// The informers are not sharded, every replica still caches the objects of all projects.
+	Enabled          bool
+	LeaseNamespace   string
+	Identity         string
+	AdvertiseAddress string
+	LeaseDuration    time.Duration
+	PeerPort         uint
+	PeerCertFile     string
+	PeerKeyFile      string
+	// PeerCA is the CA bundle used to verify the server and client certificates of other replicas.
+	PeerCA         []byte
+	PeerServerName string
+}
@@ -0,0 +1,65 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package options_test
+
+import (
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/gardener/gardener-discovery-server/cmd/discovery-server/app/options"
+)
+
+var _ = Describe("ShardingOptions", func() {
+	var args []string
+
+	BeforeEach(func() {
+		caFile := filepath.Join(GinkgoT().TempDir(), "ca.crt")
+		Expect(os.WriteFile(caFile, []byte("ca"), 0o600)).To(Succeed())
+
+		args = []string{
+			"--sharding-enabled",
+			"--sharding-lease-namespace=gardener-system-shoot-issuer",
+			"--sharding-identity=replica-0",
+			"--sharding-advertise-address=https://10.0.0.1:10444",
+			"--sharding-peer-cert-file=peer.crt",
+			"--sharding-peer-key-file=peer.key",
+			"--sharding-peer-ca-file=" + caFile,
+		}
+	})
+
+	It("should not validate the disabled sharding", func() {
+		Expect(parse(&options.ShardingOptions{}, "--sharding-lease-duration=1s").Validate()).To(BeEmpty())
+	})
+
+	It("should apply the sharding options", func() {
+		o := &options.ShardingOptions{}
+		Expect(parse(o, args...).Validate()).To(BeEmpty())
+
+		c := &options.ShardingConfig{}
+		Expect(o.ApplyTo(c)).To(Succeed())
+		Expect(c.Enabled).To(BeTrue())
+		Expect(c.Identity).To(Equal("replica-0"))
+		Expect(c.AdvertiseAddress).To(Equal("https://10.0.0.1:10444"))
+		Expect(c.LeaseDuration).To(Equal(15 * time.Second))
+		Expect(c.PeerCA).To(Equal([]byte("ca")))
+	})
+
+	DescribeTable("should reject invalid sharding options",
+		func(match string, extraArgs ...string) {
+			Expect(parse(&options.ShardingOptions{}, append(args, extraArgs...)...).Validate()).To(ConsistOf(MatchError(match)))
+		},
+		Entry("missing lease namespace", "--sharding-lease-namespace is required if --sharding-enabled is set", "--sharding-lease-namespace="),
+		Entry("missing identity", "--sharding-identity is required if --sharding-enabled is set", "--sharding-identity= "),
+		Entry("plain HTTP advertise address", "--sharding-advertise-address must be an https URL if --sharding-enabled is set", "--sharding-advertise-address=http://10.0.0.1:10444"),
+		Entry("short lease duration", "--sharding-lease-duration must be at least 3s", "--sharding-lease-duration=1s"),
+		Entry("invalid peer port", "--sharding-peer-port must be a valid port if --sharding-enabled is set", "--sharding-peer-port=0"),
+		Entry("missing peer certificate", "--sharding-peer-cert-file is required if --sharding-enabled is set", "--sharding-peer-cert-file="),
+		Entry("missing peer CA", "--sharding-peer-ca-file is required if --sharding-enabled is set", "--sharding-peer-ca-file="),
+	)
+})
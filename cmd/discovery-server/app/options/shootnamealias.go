@@ -0,0 +1,53 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package options
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/spf13/pflag"
+)
+
+// Modes of resolving shoots by name.
+const (
+	ShootNameAliasDisabled = "disabled"
+	ShootNameAliasRedirect = "redirect"
+	ShootNameAliasServe    = "serve"
+)
+
+// ShootNameAliasOptions holds options regarding the routes addressing shoots by name instead of UID.
+type ShootNameAliasOptions struct {
+	Mode string
+}
+
+// AddFlags adds the [ShootNameAliasOptions] flags to the flagset.
+func (o *ShootNameAliasOptions) AddFlags(fs *pflag.FlagSet) {
+	fs.StringVar(&o.Mode, "shoot-name-aliases", ShootNameAliasDisabled, "Resolve shoots by name under /projects/{projectName}/shoots/by-name/{shootName}/. "+
+		"One of disabled, redirect (redirect to the path with the shoot UID) or serve (serve the documents directly). "+
+		"Projects opt out with the annotation discovery.gardener.cloud/shoot-name-alias=disabled, changes of the annotation apply on the next resync.")
+}
+
+// Validate checks if options are valid.
+func (o *ShootNameAliasOptions) Validate() []error {
+	if !slices.Contains([]string{ShootNameAliasDisabled, ShootNameAliasRedirect, ShootNameAliasServe}, o.Mode) {
+		return []error{fmt.Errorf("--shoot-name-aliases must be one of disabled, redirect or serve, got %q", o.Mode)}
+	}
+	return nil
+}
+
+// ApplyTo applies the options to the configuration.
+func (o *ShootNameAliasOptions) ApplyTo(c *ShootNameAliasConfig) error {
+	c.Enabled = o.Mode != ShootNameAliasDisabled
+	c.Redirect = o.Mode == ShootNameAliasRedirect
+	return nil
+}
+
+// ShootNameAliasConfig holds configurations regarding the routes addressing shoots by name.
+type ShootNameAliasConfig struct {
+	Enabled bool
+	// Redirect indicates whether clients are redirected to the path with the shoot UID instead of being served directly.
+	Redirect bool
+}
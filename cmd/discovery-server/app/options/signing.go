@@ -0,0 +1,39 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package options
+
+import (
+	"github.com/spf13/pflag"
+)
+
+// SigningOptions holds options regarding the signatures of the served documents.
+type SigningOptions struct {
+	KeyFile string
+}
+
+// AddFlags adds the [SigningOptions] flags to the flagset.
+func (o *SigningOptions) AddFlags(fs *pflag.FlagSet) {
+	fs.StringVar(&o.KeyFile, "signing-key-file", "", "File containing the PEM encoded private key the served documents are signed with. "+
+		"The detached JWS is sent in the JWS-Signature header and the public keys are served under /garden/discovery-server/signing-keys. "+
+		"The key is reloaded if the file changes, a reloaded key is published for the max-age of the jwks endpoint type before documents are signed with it. "+
+		"Signing is disabled if empty.")
+}
+
+// Validate checks if options are valid.
+func (o *SigningOptions) Validate() []error {
+	return nil
+}
+
+// ApplyTo applies the options to the configuration.
+func (o *SigningOptions) ApplyTo(c *SigningConfig) error {
+	c.KeyFile = o.KeyFile
+	return nil
+}
+
+// SigningConfig holds configurations regarding the signatures of the served documents.
+type SigningConfig struct {
+	// KeyFile is the file containing the signing key, signing is disabled if it is empty.
+	KeyFile string
+}
@@ -0,0 +1,59 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package options
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+
+	"github.com/spf13/pflag"
+)
+
+// TracingOptions holds options regarding the export of traces.
+type TracingOptions struct {
+	// Endpoint is the URL of the OTLP/HTTP traces endpoint.
+	Endpoint string
+	// SamplingRatio is the fraction of root traces that are sampled.
+	SamplingRatio float64
+}
+
+// AddFlags adds the [TracingOptions] flags to the flagset.
+func (o *TracingOptions) AddFlags(fs *pflag.FlagSet) {
+	fs.StringVar(&o.Endpoint, "tracing-endpoint", "", "URL of the OTLP/HTTP traces endpoint, e.g. https://collector:4318/v1/traces. If unspecified traces are not exported.")
+	fs.Float64Var(&o.SamplingRatio, "tracing-sampling-ratio", 1, "Fraction of traces that are sampled, between 0 and 1. Requests carrying a trace context follow the sampling decision of the caller.")
+}
+
+// Validate checks if options are valid.
+func (o *TracingOptions) Validate() []error {
+	var errs []error
+	if o.Endpoint != "" {
+		u, err := url.Parse(o.Endpoint)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("--tracing-endpoint is not a valid URL: %w", err))
+		} else if u.Scheme != "http" && u.Scheme != "https" {
+			errs = append(errs, errors.New("--tracing-endpoint must use http or https scheme"))
+		}
+	}
+	if o.SamplingRatio < 0 || o.SamplingRatio > 1 {
+		errs = append(errs, errors.New("--tracing-sampling-ratio must be between 0 and 1"))
+	}
+	return errs
+}
+
+// ApplyTo applies the options to the configuration.
+func (o *TracingOptions) ApplyTo(c *TracingConfig) error {
+	c.Endpoint = o.Endpoint
+	c.SamplingRatio = o.SamplingRatio
+	return nil
+}
+
+// TracingConfig holds configurations regarding the export of traces.
+type TracingConfig struct {
+	// Endpoint is the URL of the OTLP/HTTP traces endpoint.
+	// Tracing is disabled if it is empty.
+	Endpoint      string
+	SamplingRatio float64
+}
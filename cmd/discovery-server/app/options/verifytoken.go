@@ -0,0 +1,100 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package options
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"time"
+
+	"github.com/spf13/pflag"
+)
+
+// OutputFormatText emits the diagnosis of the verify-token command in a human-readable form.
+const OutputFormatText = "text"
+
+// maxTokenFileSize is the maximum size of the token file, it is larger than the accepted tokens to report their size.
+const maxTokenFileSize = 1 << 20
+
+// VerifyTokenOptions holds the options of the verify-token command diagnosing a token.
+type VerifyTokenOptions struct {
+	ClientOptions ClientOptions
+	// TokenFile is the path to the token, the token is read from stdin if it is "-".
+	TokenFile string
+	// Audiences are the audiences the token is expected to be issued for.
+	Audiences []string
+	// InProcess indicates whether the token is verified against a discovery server started in-process.
+	InProcess bool
+	// OutputFormat is the format of the diagnosis, either text or json.
+	OutputFormat string
+	// Timeout is the maximum duration of the verification.
+	Timeout time.Duration
+}
+
+// AddFlags adds the [VerifyTokenOptions] flags to the flagset.
+func (o *VerifyTokenOptions) AddFlags(fs *pflag.FlagSet) {
+	o.ClientOptions.AddFlags(fs)
+	fs.StringVar(&o.TokenFile, "token-file", "-", "File containing the token to verify, the token is read from stdin if it is -.")
+	fs.StringArrayVar(&o.Audiences, "audience", nil, "Audience the token is expected to be issued for, can be repeated. "+
+		"The token is valid for any audience if it is not set.")
+	fs.BoolVar(&o.InProcess, "in-process", false, "Start the discovery server in-process with the server flags and verify the token against it "+
+		"instead of a deployed one.")
+	fs.StringVar(&o.OutputFormat, "output-format", OutputFormatText, "Format of the diagnosis, one of text, json.")
+	fs.DurationVar(&o.Timeout, "timeout", 5*time.Minute, "Maximum duration of the verification, including the start of the in-process discovery server.")
+}
+
+// Validate checks if options are valid.
+func (o *VerifyTokenOptions) Validate() []error {
+	errs := o.ClientOptions.Validate()
+	if o.TokenFile == "" {
+		errs = append(errs, errors.New("--token-file is required"))
+	}
+	if !slices.Contains([]string{OutputFormatText, OutputFormatJSON}, o.OutputFormat) {
+		errs = append(errs, fmt.Errorf("--output-format must be one of %s, %s", OutputFormatText, OutputFormatJSON))
+	}
+	if o.Timeout <= 0 {
+		errs = append(errs, errors.New("--timeout must be positive"))
+	}
+	if o.InProcess == (o.ClientOptions.AdminURL != "") {
+		errs = append(errs, errors.New("exactly one of --in-process and --admin-url is required"))
+	}
+	return errs
+}
+
+// ApplyTo applies the options to the configuration. The token is read from stdin if the token file is "-".
+func (o *VerifyTokenOptions) ApplyTo(c *VerifyTokenConfig, stdin io.Reader) error {
+	if o.TokenFile != "-" {
+		f, err := os.Open(o.TokenFile)
+		if err != nil {
+			return fmt.Errorf("failed to open token file: %w", err)
+		}
+		defer f.Close()
+		stdin = f
+	}
+	token, err := io.ReadAll(io.LimitReader(stdin, maxTokenFileSize))
+	if err != nil {
+		return fmt.Errorf("failed to read token: %w", err)
+	}
+
+	c.Token = string(token)
+	c.Audiences = o.Audiences
+	c.InProcess = o.InProcess
+	c.OutputFormat = o.OutputFormat
+	c.Timeout = o.Timeout
+	return o.ClientOptions.ApplyTo(&c.Client)
+}
+
+// VerifyTokenConfig holds the configuration of the verify-token command.
+type VerifyTokenConfig struct {
+	Client       ClientConfig
+	Token        string
+	Audiences    []string
+	InProcess    bool
+	OutputFormat string
+	Timeout      time.Duration
+}
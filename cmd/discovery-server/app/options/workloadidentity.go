@@ -0,0 +1,79 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package options
+
+import (
+	"errors"
+	"os"
+	"strings"
+
+	"github.com/spf13/pflag"
+)
+
+// WorkloadIdentityOptions holds the options for the workload identity OIDC discovery documents.
+type WorkloadIdentityOptions struct {
+	// OpenIDConfigFile is the path to the file containing the openid configuration.
+	OpenIDConfigFile string
+	// JWKSFile is the path to the file containing the JWKS.
+	JWKSFile string
+}
+
+// WorkloadIdentityConfig holds the configuration regarding the workload identity OIDC discovery documents.
+type WorkloadIdentityConfig struct {
+	// OpenIDConfig is the workload identity openid configuration.
+	OpenIDConfig []byte
+	// JWKS is the workload identity JWKS.
+	JWKS []byte
+	// Enabled indicate whether the discovery server should serve workload identity discovery documents or not.
+	Enabled bool
+}
+
+// AddFlags adds workload identity options to  flagset
+func (o *WorkloadIdentityOptions) AddFlags(fs *pflag.FlagSet) {
+	fs.StringVar(&o.OpenIDConfigFile, "workload-identity-openid-configuration-file", o.OpenIDConfigFile, "Path to garden workload identity openid configuration file.")
+	fs.StringVar(&o.JWKSFile, "workload-identity-jwks-file", o.JWKSFile, "Path to garden workload identity JWKS file.")
+}
+
+// Validate checks if workload identity options are valid.
+func (o *WorkloadIdentityOptions) Validate() []error {
+	errs := []error{}
+
+	if strings.TrimSpace(o.OpenIDConfigFile) == "" && strings.TrimSpace(o.JWKSFile) == "" {
+		return nil
+	}
+
+	if strings.TrimSpace(o.OpenIDConfigFile) == "" {
+		errs = append(errs, errors.New(`flag "workload-identity-openid-configuration-file" must be set when "workload-identity-jwks-file" is set`))
+	}
+	if strings.TrimSpace(o.JWKSFile) == "" {
+		errs = append(errs, errors.New(`flag "workload-identity-jwks-file" must be set when "workload-identity-openid-configuration-file" is set`))
+	}
+
+	return errs
+}
+
+// ApplyTo applies the options to the configuration.
+func (o *WorkloadIdentityOptions) ApplyTo(c *WorkloadIdentityConfig) error {
+	if strings.TrimSpace(o.OpenIDConfigFile) == "" {
+		// Serving workload identity discovery documents is optional,
+		// if the flags are not set, this feature is considered disabled
+		c.Enabled = false
+		return nil
+	}
+	c.Enabled = true
+
+	var err error
+	c.OpenIDConfig, err = os.ReadFile(o.OpenIDConfigFile)
+	if err != nil {
+		return err
+	}
+
+	c.JWKS, err = os.ReadFile(o.JWKSFile)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,197 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/gardener/gardener/pkg/logger"
+	"github.com/go-logr/logr"
+	"github.com/spf13/cobra"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/gardener/gardener-discovery-server/cmd/discovery-server/app/options"
+	"github.com/gardener/gardener-discovery-server/internal/handler/admin"
+	"github.com/gardener/gardener-discovery-server/internal/store"
+	"github.com/gardener/gardener-discovery-server/internal/store/openidmeta"
+	"github.com/gardener/gardener-discovery-server/internal/tokenverify"
+)
+
+// newVerifyTokenCommand returns the command diagnosing a token against the published JWKS of its issuer.
+func newVerifyTokenCommand() *cobra.Command {
+	verifyOpt := &options.VerifyTokenOptions{}
+	serverOpt := options.NewOptions()
+
+	cmd := &cobra.Command{
+		Use:   "verify-token",
+		Short: "Diagnose why a token is accepted or rejected by relying parties",
+		Long: `Diagnose why a token is accepted or rejected by relying parties.
+
+The issuer of the token is resolved to the documents published by the discovery server, either a deployed
+one via its admin API (--admin-url, requires --admin-verify-token on the server) or one started in-process
+with the server flags (--in-process). The signature is verified against the published JWKS and the iss, aud
+and exp claims are checked. The command fails if the token is not valid.`,
+		SilenceUsage: true,
+		PreRunE: func(_ *cobra.Command, _ []string) error {
+			errs := verifyOpt.Validate()
+			if verifyOpt.InProcess {
+				errs = append(errs, serverOpt.Validate()...)
+			}
+			return utilerrors.NewAggregate(errs)
+		},
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			log, err := logger.NewZapLogger("info", "json")
+			if err != nil {
+				return fmt.Errorf("error instantiating zap logger: %w", err)
+			}
+			logf.SetLogger(log)
+
+			conf := &options.VerifyTokenConfig{}
+			if err := verifyOpt.ApplyTo(conf, cmd.InOrStdin()); err != nil {
+				return fmt.Errorf("cannot apply options: %w", err)
+			}
+			var serverConf *options.Config
+			if conf.InProcess {
+				serverConf = &options.Config{}
+				if err := serverOpt.ApplyTo(serverConf); err != nil {
+					return fmt.Errorf("cannot apply options: %w", err)
+				}
+			}
+
+			return runVerifyToken(cmd.Context(), log, conf, serverConf, cmd.OutOrStdout())
+		},
+	}
+
+	fs := cmd.Flags()
+	verifyOpt.AddFlags(fs)
+	serverOpt.AddFlags(fs)
+	fs.AddGoFlagSet(flag.CommandLine)
+
+	return cmd
+}
+
+// runVerifyToken diagnoses the token and writes the diagnosis. It returns an error if the token is not valid.
+func runVerifyToken(ctx context.Context, log logr.Logger, conf *options.VerifyTokenConfig, serverConf *options.Config, stdout io.Writer) error {
+	ctx, cancel := context.WithTimeout(ctx, conf.Timeout)
+	defer cancel()
+
+	var diagnosis tokenverify.Diagnosis
+	if conf.InProcess {
+		ds, err := newDiscoveryServer(serverConf, log)
+		if err != nil {
+			return err
+		}
+		if err := runGardens(ctx, log, ds.gardens, func(ctx context.Context) error {
+			if err := waitForGardens(ctx, ds.gardens, ds.stores); err != nil {
+				return fmt.Errorf("in-process discovery server did not become ready: %w", err)
+			}
+			diagnosis = ds.verifier.Verify(conf.Token, conf.Audiences)
+			return nil
+		}); err != nil {
+			return err
+		}
+	} else {
+		client, err := newClient(conf.Client)
+		if err != nil {
+			return err
+		}
+		req := admin.VerifyTokenRequest{Token: conf.Token, Audiences: conf.Audiences}
+		if err := adminRequest(ctx, client, conf.Client, http.MethodPost, "/admin/verify-token", nil, req, &diagnosis); err != nil {
+			return err
+		}
+	}
+
+	if conf.OutputFormat == options.OutputFormatJSON {
+		enc := json.NewEncoder(stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(diagnosis); err != nil {
+			return err
+		}
+	} else if err := tokenverify.WriteText(stdout, diagnosis); err != nil {
+		return err
+	}
+
+	if !diagnosis.Valid {
+		return errors.New("token is not valid")
+	}
+	return nil
+}
+
+// issuerResolver resolves the issuer URLs to the documents in the stores of the gardens.
+// Only the path of the issuer URL is considered, the host is checked against the issuer of the discovery document.
+type issuerResolver struct {
+	gardens []*garden
+	// workloadIdentity holds the documents of the workload identity issuer, it is nil if the issuer is not served.
+	workloadIdentity *store.Store[openidmeta.Data]
+}
+
+// Resolve implements [tokenverify.Resolver].
+func (r *issuerResolver) Resolve(issuer string) (tokenverify.Documents, error) {
+	u, err := url.Parse(issuer)
+	if err != nil {
+		return tokenverify.Documents{}, fmt.Errorf("issuer is not a valid URL: %w", err)
+	}
+
+	if u.Path == workloadIdentityIssuerPath {
+		if r.workloadIdentity == nil {
+			return tokenverify.Documents{}, errors.New("the workload identity issuer of the garden is not served")
+		}
+		data, ok := r.workloadIdentity.Read("garden")
+		if !ok {
+			return tokenverify.Documents{}, errors.New("the workload identity issuer of the garden is not published")
+		}
+		return tokenverify.Documents{Config: data.Config, JWKS: data.JWKS}, nil
+	}
+
+	var (
+		g    *garden
+		path = u.Path
+	)
+	if rest, ok := strings.CutPrefix(path, "/gardens/"); ok {
+		name, shootPath, _ := strings.Cut(rest, "/")
+		for _, gdn := range r.gardens {
+			if gdn.name == name {
+				g = gdn
+			}
+		}
+		if g == nil {
+			return tokenverify.Documents{}, fmt.Errorf("garden %q is not served", name)
+		}
+		path = "/" + shootPath
+	} else {
+		for _, gdn := range r.gardens {
+			if gdn.isDefault {
+				g = gdn
+			}
+		}
+		if g == nil {
+			return tokenverify.Documents{}, errors.New("no default garden is served below the paths without garden prefix")
+		}
+	}
+
+	parts := strings.Split(strings.TrimPrefix(path, "/"), "/")
+	if len(parts) != 5 || parts[0] != "projects" || parts[2] != "shoots" || parts[4] != "issuer" {
+		return tokenverify.Documents{}, fmt.Errorf("path %q is not the path of a shoot issuer, expected /projects/{projectName}/shoots/{shootUID}/issuer", path)
+	}
+	key := parts[1] + "--" + parts[3]
+	data, ok := g.issuers.Read(key)
+	if !ok {
+		if rejection, ok := g.issuers.Rejection(key); ok {
+			return tokenverify.Documents{}, fmt.Errorf("the issuer of shoot %s in project %s of garden %s is not published: %s", parts[3], parts[1], g.name, rejection.Reason)
+		}
+		return tokenverify.Documents{}, fmt.Errorf("garden %s does not publish an issuer for shoot %s in project %s, "+
+			"the shoot may not exist or may not use a managed service account issuer", g.name, parts[3], parts[1])
+	}
+	return tokenverify.Documents{Config: data.Config, JWKS: data.JWKS}, nil
+}
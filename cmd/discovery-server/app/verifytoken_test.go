@@ -0,0 +1,160 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/go-jose/go-jose/v4"
+	"github.com/go-jose/go-jose/v4/jwt"
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/gardener/gardener-discovery-server/cmd/discovery-server/app/options"
+	"github.com/gardener/gardener-discovery-server/internal/handler/admin"
+	"github.com/gardener/gardener-discovery-server/internal/store"
+	"github.com/gardener/gardener-discovery-server/internal/store/openidmeta"
+	"github.com/gardener/gardener-discovery-server/internal/tokenverify"
+)
+
+var _ = Describe("VerifyToken", func() {
+	const (
+		shootUID = "a8c33bc6-36b1-4d4c-a8b1-5e5cb4e1d4a9"
+		host     = "https://discovery.example.com"
+	)
+
+	var (
+		foo, bar         *garden
+		workloadIdentity *store.Store[openidmeta.Data]
+		resolver         *issuerResolver
+	)
+
+	BeforeEach(func() {
+		foo = &garden{name: "foo", isDefault: true, issuers: store.MustNewStore(openidmeta.Copy)}
+		bar = &garden{name: "bar", issuers: store.MustNewStore(openidmeta.Copy)}
+		workloadIdentity = store.MustNewStore(openidmeta.Copy)
+		resolver = &issuerResolver{gardens: []*garden{foo, bar}, workloadIdentity: workloadIdentity}
+
+		foo.issuers.Write("proj--"+shootUID, openidmeta.Data{Config: []byte("foo")})
+		bar.issuers.Write("proj--"+shootUID, openidmeta.Data{Config: []byte("bar")})
+		workloadIdentity.Write("garden", openidmeta.Data{Config: []byte("garden")})
+	})
+
+	Context("issuerResolver", func() {
+		resolve := func(issuer string) string {
+			docs, err := resolver.Resolve(issuer)
+			Expect(err).NotTo(HaveOccurred())
+			return string(docs.Config)
+		}
+
+		It("should resolve the issuers of the shoots", func() {
+			Expect(resolve(host + "/projects/proj/shoots/" + shootUID + "/issuer")).To(Equal("foo"))
+			Expect(resolve(host + "/gardens/foo/projects/proj/shoots/" + shootUID + "/issuer")).To(Equal("foo"))
+			Expect(resolve(host + "/gardens/bar/projects/proj/shoots/" + shootUID + "/issuer")).To(Equal("bar"))
+		})
+
+		It("should resolve the workload identity issuer", func() {
+			Expect(resolve(host + "/garden/workload-identity/issuer")).To(Equal("garden"))
+		})
+
+		DescribeTable("should explain why an issuer cannot be resolved",
+			func(issuer, message string) {
+				_, err := resolver.Resolve(issuer)
+				Expect(err).To(MatchError(ContainSubstring(message)))
+			},
+			Entry("unknown garden", host+"/gardens/baz/projects/proj/shoots/"+shootUID+"/issuer", `garden "baz" is not served`),
+			Entry("unknown path", host+"/projects/proj/issuer", "is not the path of a shoot issuer"),
+			Entry("unknown shoot", host+"/projects/proj/shoots/other/issuer", "garden foo does not publish an issuer for shoot other in project proj"),
+		)
+
+		It("should report the rejection reason of an unpublished issuer", func() {
+			foo.issuers.Delete("proj--"+shootUID, store.WithReason("shoot not found"))
+			_, err := resolver.Resolve(host + "/projects/proj/shoots/" + shootUID + "/issuer")
+			Expect(err).To(MatchError(HaveSuffix("is not published: shoot not found")))
+		})
+
+		It("should fail if no default garden is served", func() {
+			foo.isDefault = false
+			_, err := resolver.Resolve(host + "/projects/proj/shoots/" + shootUID + "/issuer")
+			Expect(err).To(MatchError(ContainSubstring("no default garden")))
+		})
+
+		It("should fail if the workload identity issuer is not served", func() {
+			resolver.workloadIdentity = nil
+			_, err := resolver.Resolve(host + "/garden/workload-identity/issuer")
+			Expect(err).To(MatchError(ContainSubstring("is not served")))
+		})
+	})
+
+	Context("runVerifyToken", func() {
+		var (
+			conf  *options.VerifyTokenConfig
+			out   *bytes.Buffer
+			token string
+		)
+
+		BeforeEach(func() {
+			key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+			Expect(err).NotTo(HaveOccurred())
+			issuer := host + "/projects/proj/shoots/" + shootUID + "/issuer"
+			jwks, err := json.Marshal(jose.JSONWebKeySet{Keys: []jose.JSONWebKey{{Key: &key.PublicKey, KeyID: "key", Algorithm: "ES256", Use: "sig"}}})
+			Expect(err).NotTo(HaveOccurred())
+			foo.issuers.Write("proj--"+shootUID, openidmeta.Data{Config: []byte(`{"issuer":"` + issuer + `"}`), JWKS: jwks})
+
+			signer, err := jose.NewSigner(jose.SigningKey{Algorithm: jose.ES256, Key: key}, (&jose.SignerOptions{}).WithHeader(jose.HeaderKey("kid"), "key"))
+			Expect(err).NotTo(HaveOccurred())
+			token, err = jwt.Signed(signer).Claims(jwt.Claims{
+				Issuer:   issuer,
+				Audience: jwt.Audience{"sts.amazonaws.com"},
+				Expiry:   jwt.NewNumericDate(time.Now().Add(time.Hour)),
+			}).Serialize()
+			Expect(err).NotTo(HaveOccurred())
+
+			h := admin.New(nil, nil, logr.Discard())
+			mux := http.NewServeMux()
+			mux.Handle("/admin/verify-token", h.HandleVerifyToken(&tokenverify.Verifier{Resolver: resolver}))
+			adminServer := httptest.NewServer(mux)
+			DeferCleanup(adminServer.Close)
+
+			conf = &options.VerifyTokenConfig{
+				Client:       options.ClientConfig{AdminURL: adminServer.URL, RequestTimeout: 5 * time.Second},
+				Token:        token,
+				OutputFormat: options.OutputFormatText,
+				Timeout:      time.Minute,
+			}
+			out = &bytes.Buffer{}
+		})
+
+		It("should diagnose a valid token with the admin API", func() {
+			Expect(runVerifyToken(context.Background(), logr.Discard(), conf, nil, out)).To(Succeed())
+			Expect(out.String()).To(HavePrefix("Token is valid.\n"))
+		})
+
+		It("should fail for an invalid token", func() {
+			conf.Audiences = []string{"other"}
+			conf.OutputFormat = options.OutputFormatJSON
+			Expect(runVerifyToken(context.Background(), logr.Discard(), conf, nil, out)).To(MatchError("token is not valid"))
+
+			diagnosis := tokenverify.Diagnosis{}
+			Expect(json.Unmarshal(out.Bytes(), &diagnosis)).To(Succeed())
+			Expect(diagnosis.Valid).To(BeFalse())
+			Expect(diagnosis.Checks).To(ContainElement(HaveField("Name", tokenverify.CheckAudience)))
+		})
+
+		It("should fail if the admin API does not serve the token verification", func() {
+			conf.Client.AdminURL += "/unknown"
+			Expect(runVerifyToken(context.Background(), logr.Discard(), conf, nil, out)).To(MatchError(ContainSubstring("status code 404")))
+		})
+	})
+})
@@ -0,0 +1,106 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cache
+
+import (
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	v1beta1constants "github.com/gardener/gardener/pkg/apis/core/v1beta1/constants"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ShootIssuerNamespace is the namespace containing the shoot issuer metadata secrets.
+const ShootIssuerNamespace = "gardener-system-shoot-issuer"
+
+// Options returns the cache options of the discovery server.
+// The cached objects are restricted to the ones read by the reconcilers and
+// stripped of all fields that the reconcilers do not read.
+func Options() cache.Options {
+	return cache.Options{
+		DefaultTransform: cache.TransformStripManagedFields(),
+		ByObject: map[client.Object]cache.ByObject{
+			&corev1.Secret{}: {
+				Namespaces: map[string]cache.Config{
+					ShootIssuerNamespace: {},
+				},
+			},
+			&corev1.ConfigMap{}: {
+				Label: labels.SelectorFromSet(labels.Set{
+					v1beta1constants.LabelDiscoveryPublic: v1beta1constants.DiscoveryShootCA,
+				}),
+			},
+			&gardencorev1beta1.Shoot{}: {
+				Transform: TransformShoot,
+			},
+			&gardencorev1beta1.Project{}: {
+				Transform: TransformProject,
+			},
+			&corev1.Namespace{}: {
+				Transform: TransformNamespace,
+			},
+		},
+	}
+}
+
+// TransformShoot strips all fields of a shoot except its metadata and advertised addresses.
+func TransformShoot(in any) (any, error) {
+	shoot, ok := in.(*gardencorev1beta1.Shoot)
+	if !ok {
+		return in, nil
+	}
+	return &gardencorev1beta1.Shoot{
+		TypeMeta:   shoot.TypeMeta,
+		ObjectMeta: stripObjectMeta(shoot.ObjectMeta),
+		Status: gardencorev1beta1.ShootStatus{
+			AdvertisedAddresses: shoot.Status.AdvertisedAddresses,
+		},
+	}, nil
+}
+
+// TransformProject strips all fields of a project except its metadata and namespace.
+func TransformProject(in any) (any, error) {
+	project, ok := in.(*gardencorev1beta1.Project)
+	if !ok {
+		return in, nil
+	}
+	return &gardencorev1beta1.Project{
+		TypeMeta:   project.TypeMeta,
+		ObjectMeta: stripObjectMeta(project.ObjectMeta),
+		Spec: gardencorev1beta1.ProjectSpec{
+			Namespace: project.Spec.Namespace,
+		},
+	}, nil
+}
+
+// TransformNamespace strips all fields of a namespace except its metadata.
+func TransformNamespace(in any) (any, error) {
+	namespace, ok := in.(*corev1.Namespace)
+	if !ok {
+		return in, nil
+	}
+	return &corev1.Namespace{
+		TypeMeta:   namespace.TypeMeta,
+		ObjectMeta: stripObjectMeta(namespace.ObjectMeta),
+	}, nil
+}
+
+// stripObjectMeta keeps the identity, labels and annotations of an object
+// which are required by the reconcilers and the informers.
+func stripObjectMeta(in metav1.ObjectMeta) metav1.ObjectMeta {
+	return metav1.ObjectMeta{
+		Name:              in.Name,
+		Namespace:         in.Namespace,
+		UID:               in.UID,
+		ResourceVersion:   in.ResourceVersion,
+		Generation:        in.Generation,
+		CreationTimestamp: in.CreationTimestamp,
+		DeletionTimestamp: in.DeletionTimestamp,
+		Labels:            in.Labels,
+		Annotations:       in.Annotations,
+	}
+}
@@ -1,8 +1,8 @@
-// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and Gardener contributors
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
 //
 // SPDX-License-Identifier: Apache-2.0
 
-package certificate_test
+package cache_test
 
 import (
 	"testing"
@@ -11,7 +11,7 @@ import (
 	. "github.com/onsi/gomega"
 )
 
-func TestCertificate(t *testing.T) {
+func TestCache(t *testing.T) {
 	RegisterFailHandler(Fail)
-	RunSpecs(t, "Certificate Handler Test Suite")
+	RunSpecs(t, "Cache Test Suite")
 }
@@ -0,0 +1,205 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cache_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"runtime"
+	"testing"
+
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	v1beta1constants "github.com/gardener/gardener/pkg/apis/core/v1beta1/constants"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/gardener/gardener-discovery-server/internal/cache"
+)
+
+var _ = Describe("Cache", func() {
+	Describe("#Options", func() {
+		It("should only cache public shoot CA configmaps", func() {
+			opts := cache.Options()
+			var selector labels.Selector
+			for obj, byObject := range opts.ByObject {
+				if _, ok := obj.(*corev1.ConfigMap); ok {
+					selector = byObject.Label
+				}
+			}
+			Expect(selector).ToNot(BeNil())
+			Expect(selector.Matches(labels.Set{v1beta1constants.LabelDiscoveryPublic: v1beta1constants.DiscoveryShootCA})).To(BeTrue())
+			Expect(selector.Matches(labels.Set{"foo": "bar"})).To(BeFalse())
+		})
+	})
+
+	Describe("#TransformShoot", func() {
+		It("should only keep metadata and advertised addresses", func() {
+			shoot := newShoot(0)
+			out, err := cache.TransformShoot(shoot)
+			Expect(err).ToNot(HaveOccurred())
+
+			transformed := out.(*gardencorev1beta1.Shoot)
+			Expect(transformed.Name).To(Equal(shoot.Name))
+			Expect(transformed.Namespace).To(Equal(shoot.Namespace))
+			Expect(transformed.UID).To(Equal(shoot.UID))
+			Expect(transformed.ResourceVersion).To(Equal(shoot.ResourceVersion))
+			Expect(transformed.Labels).To(Equal(shoot.Labels))
+			Expect(transformed.Annotations).To(Equal(shoot.Annotations))
+			Expect(transformed.ManagedFields).To(BeEmpty())
+			Expect(transformed.Spec).To(Equal(gardencorev1beta1.ShootSpec{}))
+			Expect(transformed.Status).To(Equal(gardencorev1beta1.ShootStatus{AdvertisedAddresses: shoot.Status.AdvertisedAddresses}))
+
+			Expect(jsonSize(transformed)).To(BeNumerically("<", jsonSize(shoot)/4))
+		})
+
+		It("should return unknown objects unchanged", func() {
+			project := &gardencorev1beta1.Project{}
+			Expect(cache.TransformShoot(project)).To(BeIdenticalTo(project))
+		})
+	})
+
+	Describe("#TransformProject", func() {
+		It("should only keep metadata and namespace", func() {
+			project := &gardencorev1beta1.Project{
+				ObjectMeta: metav1.ObjectMeta{Name: "foo", Labels: map[string]string{"foo": "bar"}},
+				Spec: gardencorev1beta1.ProjectSpec{
+					Namespace:   ptr.To("garden-foo"),
+					Description: ptr.To("description"),
+					Members:     []gardencorev1beta1.ProjectMember{{Subject: rbacv1.Subject{Kind: rbacv1.UserKind, Name: "alice"}}},
+				},
+				Status: gardencorev1beta1.ProjectStatus{Phase: gardencorev1beta1.ProjectReady},
+			}
+			out, err := cache.TransformProject(project)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(out).To(Equal(&gardencorev1beta1.Project{
+				ObjectMeta: metav1.ObjectMeta{Name: "foo", Labels: map[string]string{"foo": "bar"}},
+				Spec:       gardencorev1beta1.ProjectSpec{Namespace: ptr.To("garden-foo")},
+			}))
+		})
+	})
+
+	Describe("#TransformNamespace", func() {
+		It("should only keep metadata", func() {
+			namespace := &corev1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{Name: "garden-foo", Labels: map[string]string{v1beta1constants.ProjectName: "foo"}},
+				Spec:       corev1.NamespaceSpec{Finalizers: []corev1.FinalizerName{"kubernetes"}},
+				Status:     corev1.NamespaceStatus{Phase: corev1.NamespaceActive},
+			}
+			out, err := cache.TransformNamespace(namespace)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(out).To(Equal(&corev1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{Name: "garden-foo", Labels: map[string]string{v1beta1constants.ProjectName: "foo"}},
+			}))
+		})
+	})
+})
+
+func jsonSize(obj client.Object) int {
+	data, err := json.Marshal(obj)
+	ExpectWithOffset(1, err).ToNot(HaveOccurred())
+	return len(data)
+}
+
+var managedFields = func() []byte {
+	fields := map[string]any{}
+	for i := range 100 {
+		fields[fmt.Sprintf("f:field%d", i)] = map[string]any{}
+	}
+	data, _ := json.Marshal(map[string]any{"f:spec": fields})
+	return data
+}()
+
+// newShoot returns a shoot whose size is comparable to the ones found in productive gardens.
+func newShoot(i int) *gardencorev1beta1.Shoot {
+	shoot := &gardencorev1beta1.Shoot{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            fmt.Sprintf("shoot-%d", i),
+			Namespace:       "garden-foo",
+			UID:             types.UID(fmt.Sprintf("7a25a9b8-f7fc-4e1e-a421-%012d", i)),
+			ResourceVersion: "12345",
+			Labels:          map[string]string{"shoot.gardener.cloud/status": "healthy"},
+			Annotations:     map[string]string{v1beta1constants.AnnotationAuthenticationIssuer: v1beta1constants.AnnotationAuthenticationIssuerManaged},
+			ManagedFields: []metav1.ManagedFieldsEntry{{
+				Manager:    "gardener-apiserver",
+				Operation:  metav1.ManagedFieldsOperationUpdate,
+				FieldsType: "FieldsV1",
+				FieldsV1:   &metav1.FieldsV1{Raw: managedFields},
+			}},
+		},
+		Spec: gardencorev1beta1.ShootSpec{
+			Region:       "eu-west-1",
+			CloudProfile: &gardencorev1beta1.CloudProfileReference{Name: "aws"},
+			Kubernetes:   gardencorev1beta1.Kubernetes{Version: "1.33.0"},
+		},
+	}
+	for w := range 5 {
+		shoot.Spec.Provider.Workers = append(shoot.Spec.Provider.Workers, gardencorev1beta1.Worker{
+			Name:    fmt.Sprintf("worker-%d", w),
+			Machine: gardencorev1beta1.Machine{Type: "m5.large", Image: &gardencorev1beta1.ShootMachineImage{Name: "gardenlinux", Version: ptr.To("1877.0")}},
+			Minimum: 3,
+			Maximum: 10,
+			Zones:   []string{"eu-west-1a", "eu-west-1b", "eu-west-1c"},
+			Labels:  map[string]string{"worker": fmt.Sprintf("worker-%d", w)},
+		})
+	}
+	shoot.Status.AdvertisedAddresses = []gardencorev1beta1.ShootAdvertisedAddress{
+		{Name: "external", URL: fmt.Sprintf("https://api.shoot-%d.foo.example.com", i)},
+		{Name: "internal", URL: fmt.Sprintf("https://api.shoot-%d.foo.internal.example.com", i)},
+	}
+	for c := range 10 {
+		shoot.Status.Conditions = append(shoot.Status.Conditions, gardencorev1beta1.Condition{
+			Type:    gardencorev1beta1.ConditionType(fmt.Sprintf("Condition%d", c)),
+			Status:  gardencorev1beta1.ConditionTrue,
+			Reason:  "ConditionReason",
+			Message: "All components are healthy and have been successfully reconciled by gardenlet.",
+		})
+	}
+	return shoot
+}
+
+// BenchmarkShootCache reports the heap retained by cached shoots with and without the transformation.
+// Run with: go test -run=^$ -bench=BenchmarkShootCache ./internal/cache/
+func BenchmarkShootCache(b *testing.B) {
+	const objects = 1000
+
+	for _, tc := range []struct {
+		name      string
+		transform func(any) (any, error)
+	}{
+		{name: "full", transform: func(in any) (any, error) { return in, nil }},
+		{name: "transformed", transform: cache.TransformShoot},
+	} {
+		b.Run(tc.name, func(b *testing.B) {
+			var retained uint64
+			for range b.N {
+				var before, after runtime.MemStats
+				runtime.GC()
+				runtime.ReadMemStats(&before)
+
+				cached := make([]any, 0, objects)
+				for i := range objects {
+					obj, err := tc.transform(newShoot(i))
+					if err != nil {
+						b.Fatal(err)
+					}
+					cached = append(cached, obj)
+				}
+
+				runtime.GC()
+				runtime.ReadMemStats(&after)
+				runtime.KeepAlive(cached)
+				retained += after.HeapAlloc - before.HeapAlloc
+			}
+			b.ReportMetric(float64(retained)/float64(b.N*objects), "retained-B/object")
+		})
+	}
+}
@@ -0,0 +1,343 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package conformance
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-jose/go-jose/v4"
+
+	"github.com/gardener/gardener-discovery-server/internal/utils"
+)
+
+// Names of the checks performed for every issuer.
+const (
+	// CheckDiscoveryDocument fetches the discovery document from {issuer}/.well-known/openid-configuration.
+	CheckDiscoveryDocument = "discovery-document"
+	// CheckIssuer compares the issuer of the discovery document with the URL it was fetched from.
+	CheckIssuer = "issuer"
+	// CheckRequiredFields checks that the fields required by the discovery document are present.
+	CheckRequiredFields = "required-fields"
+	// CheckJWKS fetches the JWKS from jwks_uri and checks its keys.
+	CheckJWKS = "jwks"
+	// CheckAlgorithms checks that the keys are consistent with the advertised signing algorithms.
+	CheckAlgorithms = "algorithms"
+	// CheckCaching checks the caching headers of the discovery document and the JWKS.
+	CheckCaching = "caching"
+)
+
+// Status is the outcome of a check.
+type Status string
+
+const (
+	// StatusPassed is the status of a passed check.
+	StatusPassed Status = "passed"
+	// StatusFailed is the status of a failed check.
+	StatusFailed Status = "failed"
+	// StatusSkipped is the status of a check that could not be performed because a check it depends on failed.
+	StatusSkipped Status = "skipped"
+)
+
+// Result holds the checks of an issuer.
+type Result struct {
+	Issuer string  `json:"issuer"`
+	Checks []Check `json:"checks"`
+}
+
+// Check is the outcome of a single check.
+type Check struct {
+	Name    string `json:"name"`
+	Status  Status `json:"status"`
+	Message string `json:"message,omitempty"`
+	// Time is the duration of the check, it is only reported in JUnit XML.
+	Time time.Duration `json:"-"`
+}
+
+// Failed reports whether any check of the result failed.
+func (r Result) Failed() bool {
+	return slices.ContainsFunc(r.Checks, func(c Check) bool { return c.Status == StatusFailed })
+}
+
+// Checker checks the conformance of issuers with OpenID Connect Discovery 1.0 as far as it applies
+// to issuers of service account tokens, i.e. the authorization_endpoint is not required.
+type Checker struct {
+	// Client sends the requests, [http.DefaultClient] is used if it is nil.
+	Client *http.Client
+	// MaxAge is the maximum max-age of the responses that is considered sane.
+	MaxAge time.Duration
+}
+
+// discoveryDocument contains the fields of the discovery document which are checked.
+type discoveryDocument struct {
+	Issuer                           string   `json:"issuer"`
+	JWKSURI                          string   `json:"jwks_uri"`
+	ResponseTypesSupported           []string `json:"response_types_supported"`
+	SubjectTypesSupported            []string `json:"subject_types_supported"`
+	IDTokenSigningAlgValuesSupported []string `json:"id_token_signing_alg_values_supported"`
+}
+
+// response is a fetched document.
+type response struct {
+	url    string
+	header http.Header
+	body   []byte
+}
+
+// Check performs all checks for the issuer. Checks depending on a failed check are skipped.
+func (c *Checker) Check(ctx context.Context, issuer string) Result {
+	var (
+		result = Result{Issuer: issuer}
+		doc    discoveryDocument
+		config *response
+		jwks   *response
+		keySet *jose.JSONWebKeySet
+		// unavailable holds the reason why checks depending on the check cannot be performed.
+		unavailable = map[string]string{}
+	)
+
+	run := func(name string, dependsOn []string, check func() error) {
+		start := time.Now()
+		for _, d := range dependsOn {
+			if reason, ok := unavailable[d]; ok {
+				unavailable[name] = reason
+				result.Checks = append(result.Checks, Check{Name: name, Status: StatusSkipped, Message: reason})
+				return
+			}
+		}
+		if err := check(); err != nil {
+			unavailable[name] = fmt.Sprintf("check %s failed", name)
+			result.Checks = append(result.Checks, Check{Name: name, Status: StatusFailed, Message: err.Error(), Time: time.Since(start)})
+			return
+		}
+		result.Checks = append(result.Checks, Check{Name: name, Status: StatusPassed, Time: time.Since(start)})
+	}
+
+	run(CheckDiscoveryDocument, nil, func() error {
+		var err error
+		if config, err = c.fetch(ctx, strings.TrimSuffix(issuer, "/")+"/.well-known/openid-configuration", "application/json"); err != nil {
+			return err
+		}
+		if err := json.Unmarshal(config.body, &doc); err != nil {
+			return fmt.Errorf("discovery document is not a JSON object: %w", err)
+		}
+		return nil
+	})
+
+	run(CheckIssuer, []string{CheckDiscoveryDocument}, func() error {
+		if doc.Issuer != issuer {
+			return fmt.Errorf("issuer %q does not match the URL %q the discovery document was fetched from", doc.Issuer, issuer)
+		}
+		return checkHTTPSURL("issuer", doc.Issuer)
+	})
+
+	run(CheckRequiredFields, []string{CheckDiscoveryDocument}, func() error {
+		var errs []error
+		for field, present := range map[string]bool{
+			"issuer":                                doc.Issuer != "",
+			"jwks_uri":                              doc.JWKSURI != "",
+			"response_types_supported":              len(doc.ResponseTypesSupported) > 0,
+			"subject_types_supported":               len(doc.SubjectTypesSupported) > 0,
+			"id_token_signing_alg_values_supported": len(doc.IDTokenSigningAlgValuesSupported) > 0,
+		} {
+			if !present {
+				errs = append(errs, fmt.Errorf("required field %s is missing or empty", field))
+			}
+		}
+		return joinSorted(errs)
+	})
+
+	run(CheckJWKS, []string{CheckRequiredFields}, func() error {
+		if err := checkHTTPSURL("jwks_uri", doc.JWKSURI); err != nil {
+			return err
+		}
+		var err error
+		if jwks, err = c.fetch(ctx, doc.JWKSURI, "application/json", "application/jwk-set+json"); err != nil {
+			return err
+		}
+		if keySet, err = utils.LoadKeySet(jwks.body); err != nil {
+			return err
+		}
+		if len(keySet.Keys) == 0 {
+			return errors.New("JWKS does not contain any key")
+		}
+		kids := map[string]struct{}{}
+		for i, k := range keySet.Keys {
+			if !k.Valid() || !k.IsPublic() {
+				return fmt.Errorf("key %d is not a valid public key", i)
+			}
+			if k.KeyID == "" {
+				return fmt.Errorf("key %d does not have a key ID", i)
+			}
+			if _, ok := kids[k.KeyID]; ok {
+				return fmt.Errorf("key ID %q is not unique", k.KeyID)
+			}
+			kids[k.KeyID] = struct{}{}
+		}
+		return nil
+	})
+
+	run(CheckAlgorithms, []string{CheckJWKS}, func() error {
+		if slices.Contains(doc.IDTokenSigningAlgValuesSupported, "none") {
+			return errors.New("id_token_signing_alg_values_supported must not contain none")
+		}
+		for _, k := range keySet.Keys {
+			if k.Use != "" && k.Use != "sig" {
+				return fmt.Errorf("key %q is not a signing key, use is %q", k.KeyID, k.Use)
+			}
+			if k.Algorithm == "" {
+				return fmt.Errorf("key %q does not specify its algorithm", k.KeyID)
+			}
+			if !slices.Contains(doc.IDTokenSigningAlgValuesSupported, k.Algorithm) {
+				return fmt.Errorf("algorithm %s of key %q is not advertised in id_token_signing_alg_values_supported", k.Algorithm, k.KeyID)
+			}
+			if err := checkKeyType(k); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+
+	run(CheckCaching, []string{CheckJWKS}, func() error {
+		return errors.Join(c.checkCaching(config), c.checkCaching(jwks))
+	})
+
+	return result
+}
+
+// fetch gets the document and checks the status code, the media type and the size of the response.
+func (c *Checker) fetch(ctx context.Context, u string, mediaTypes ...string) (*response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", strings.Join(mediaTypes, ", "))
+
+	client := c.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get %s: %w", u, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s returned status code %d", u, resp.StatusCode)
+	}
+	mediaType, _, err := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	if err != nil || !slices.Contains(mediaTypes, mediaType) {
+		return nil, fmt.Errorf("%s returned content type %q, expected one of %s", u, resp.Header.Get("Content-Type"), strings.Join(mediaTypes, ", "))
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, utils.MaxDocumentSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", u, err)
+	}
+	if len(body) > utils.MaxDocumentSize {
+		return nil, fmt.Errorf("%s: %w", u, utils.ErrDocumentTooLarge)
+	}
+	return &response{url: u, header: resp.Header, body: body}, nil
+}
+
+// checkCaching checks that the response may be cached by shared caches for a positive duration not exceeding the maximum max-age.
+func (c *Checker) checkCaching(resp *response) error {
+	cacheControl := resp.header.Get("Cache-Control")
+	if cacheControl == "" {
+		return fmt.Errorf("%s: Cache-Control header is missing", resp.url)
+	}
+
+	maxAge := time.Duration(-1)
+	for directive := range strings.SplitSeq(cacheControl, ",") {
+		name, value, _ := strings.Cut(strings.TrimSpace(directive), "=")
+		switch strings.ToLower(name) {
+		case "no-store", "no-cache", "private":
+			return fmt.Errorf("%s: Cache-Control %q does not allow shared caching", resp.url, cacheControl)
+		case "max-age":
+			seconds, err := strconv.Atoi(value)
+			if err != nil || seconds < 0 {
+				return fmt.Errorf("%s: Cache-Control %q has an invalid max-age", resp.url, cacheControl)
+			}
+			maxAge = time.Duration(seconds) * time.Second
+		}
+	}
+	if maxAge <= 0 {
+		return fmt.Errorf("%s: Cache-Control %q does not have a positive max-age", resp.url, cacheControl)
+	}
+	if c.MaxAge > 0 && maxAge > c.MaxAge {
+		return fmt.Errorf("%s: max-age %s exceeds %s", resp.url, maxAge, c.MaxAge)
+	}
+
+	if expires := resp.header.Get("Expires"); expires != "" {
+		if _, err := http.ParseTime(expires); err != nil {
+			return fmt.Errorf("%s: Expires header %q is invalid: %w", resp.url, expires, err)
+		}
+	}
+	return nil
+}
+
+// checkHTTPSURL checks that the URL uses https and has neither query nor fragment.
+func checkHTTPSURL(field, raw string) error {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return fmt.Errorf("%s is not a valid URL: %w", field, err)
+	}
+	if u.Scheme != "https" || u.Host == "" {
+		return fmt.Errorf("%s %q is not an https URL", field, raw)
+	}
+	if u.RawQuery != "" || u.Fragment != "" {
+		return fmt.Errorf("%s %q must not contain a query or fragment", field, raw)
+	}
+	return nil
+}
+
+// checkKeyType checks that the type of the key matches its algorithm.
+func checkKeyType(k jose.JSONWebKey) error {
+	var expected string
+	switch {
+	case strings.HasPrefix(k.Algorithm, "RS"), strings.HasPrefix(k.Algorithm, "PS"):
+		expected = "RSA"
+	case strings.HasPrefix(k.Algorithm, "ES"):
+		expected = "EC"
+	case k.Algorithm == string(jose.EdDSA):
+		expected = "OKP"
+	default:
+		return fmt.Errorf("algorithm %s of key %q is not an asymmetric signing algorithm", k.Algorithm, k.KeyID)
+	}
+
+	var actual string
+	switch k.Key.(type) {
+	case *rsa.PublicKey:
+		actual = "RSA"
+	case *ecdsa.PublicKey:
+		actual = "EC"
+	case ed25519.PublicKey:
+		actual = "OKP"
+	default:
+		actual = fmt.Sprintf("%T", k.Key)
+	}
+	if actual != expected {
+		return fmt.Errorf("key %q of type %s does not match its algorithm %s", k.KeyID, actual, k.Algorithm)
+	}
+	return nil
+}
+
+func joinSorted(errs []error) error {
+	slices.SortFunc(errs, func(a, b error) int { return strings.Compare(a.Error(), b.Error()) })
+	return errors.Join(errs...)
+}
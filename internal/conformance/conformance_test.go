@@ -0,0 +1,199 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package conformance_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/go-jose/go-jose/v4"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+
+	"github.com/gardener/gardener-discovery-server/internal/conformance"
+)
+
+var _ = Describe("Checker", func() {
+	var (
+		ctx = context.Background()
+
+		server       *httptest.Server
+		issuer       string
+		config       map[string]any
+		keySet       jose.JSONWebKeySet
+		configHeader http.Header
+		jwksHeader   http.Header
+		checker      *conformance.Checker
+	)
+
+	serve := func(w http.ResponseWriter, header http.Header, body any) {
+		for k, v := range header {
+			w.Header()[k] = v
+		}
+		Expect(json.NewEncoder(w).Encode(body)).To(Succeed())
+	}
+
+	BeforeEach(func() {
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		Expect(err).NotTo(HaveOccurred())
+
+		mux := http.NewServeMux()
+		mux.HandleFunc("GET /issuer/.well-known/openid-configuration", func(w http.ResponseWriter, _ *http.Request) {
+			serve(w, configHeader, config)
+		})
+		mux.HandleFunc("GET /issuer/jwks", func(w http.ResponseWriter, _ *http.Request) {
+			serve(w, jwksHeader, keySet)
+		})
+		server = httptest.NewTLSServer(mux)
+		DeferCleanup(server.Close)
+
+		issuer = server.URL + "/issuer"
+		config = map[string]any{
+			"issuer":                                issuer,
+			"jwks_uri":                              issuer + "/jwks",
+			"response_types_supported":              []string{"id_token"},
+			"subject_types_supported":               []string{"public"},
+			"id_token_signing_alg_values_supported": []string{"ES256"},
+		}
+		keySet = jose.JSONWebKeySet{Keys: []jose.JSONWebKey{
+			{Key: &key.PublicKey, KeyID: "key", Algorithm: "ES256", Use: "sig"},
+		}}
+		configHeader = http.Header{
+			"Content-Type":  {"application/json"},
+			"Cache-Control": {"public, max-age=3600"},
+		}
+		jwksHeader = http.Header{
+			"Content-Type":  {"application/jwk-set+json"},
+			"Cache-Control": {"public, max-age=3600"},
+			"Expires":       {time.Now().Add(time.Hour).UTC().Format(http.TimeFormat)},
+		}
+		checker = &conformance.Checker{Client: server.Client(), MaxAge: 24 * time.Hour}
+	})
+
+	status := func(result conformance.Result, name string) conformance.Status {
+		for _, c := range result.Checks {
+			if c.Name == name {
+				return c.Status
+			}
+		}
+		return ""
+	}
+
+	It("should pass all checks for a conforming issuer", func() {
+		result := checker.Check(ctx, issuer)
+		Expect(result.Issuer).To(Equal(issuer))
+		Expect(result.Failed()).To(BeFalse())
+		Expect(result.Checks).To(HaveLen(6))
+		Expect(result.Checks).To(HaveEach(MatchFields(IgnoreExtras, Fields{
+			"Status":  Equal(conformance.StatusPassed),
+			"Message": BeEmpty(),
+		})))
+	})
+
+	It("should skip all checks if the discovery document cannot be fetched", func() {
+		result := checker.Check(ctx, server.URL+"/unknown")
+		Expect(result.Failed()).To(BeTrue())
+		Expect(result.Checks[0]).To(MatchFields(IgnoreExtras, Fields{
+			"Name":    Equal(conformance.CheckDiscoveryDocument),
+			"Status":  Equal(conformance.StatusFailed),
+			"Message": ContainSubstring("status code 404"),
+		}))
+		Expect(result.Checks[1:]).To(HaveEach(MatchFields(IgnoreExtras, Fields{
+			"Status":  Equal(conformance.StatusSkipped),
+			"Message": Equal("check discovery-document failed"),
+		})))
+	})
+
+	It("should fail if the discovery document is not served as JSON", func() {
+		configHeader.Set("Content-Type", "text/plain")
+		result := checker.Check(ctx, issuer)
+		Expect(status(result, conformance.CheckDiscoveryDocument)).To(Equal(conformance.StatusFailed))
+	})
+
+	It("should fail if the issuer does not match", func() {
+		config["issuer"] = issuer + "/other"
+		result := checker.Check(ctx, issuer)
+		Expect(status(result, conformance.CheckIssuer)).To(Equal(conformance.StatusFailed))
+		Expect(status(result, conformance.CheckRequiredFields)).To(Equal(conformance.StatusPassed))
+	})
+
+	It("should fail and skip dependent checks if required fields are missing", func() {
+		delete(config, "subject_types_supported")
+		delete(config, "jwks_uri")
+		result := checker.Check(ctx, issuer)
+		Expect(result.Checks).To(ContainElement(MatchFields(IgnoreExtras, Fields{
+			"Name":    Equal(conformance.CheckRequiredFields),
+			"Status":  Equal(conformance.StatusFailed),
+			"Message": Equal("required field jwks_uri is missing or empty\nrequired field subject_types_supported is missing or empty"),
+		})))
+		Expect(status(result, conformance.CheckJWKS)).To(Equal(conformance.StatusSkipped))
+		Expect(status(result, conformance.CheckAlgorithms)).To(Equal(conformance.StatusSkipped))
+		Expect(status(result, conformance.CheckCaching)).To(Equal(conformance.StatusSkipped))
+	})
+
+	It("should fail if the JWKS is not served via https", func() {
+		config["jwks_uri"] = "http://example.com/jwks"
+		result := checker.Check(ctx, issuer)
+		Expect(status(result, conformance.CheckJWKS)).To(Equal(conformance.StatusFailed))
+	})
+
+	It("should fail if the JWKS is empty", func() {
+		keySet.Keys = nil
+		result := checker.Check(ctx, issuer)
+		Expect(status(result, conformance.CheckJWKS)).To(Equal(conformance.StatusFailed))
+	})
+
+	It("should fail if key IDs are not unique", func() {
+		keySet.Keys = append(keySet.Keys, keySet.Keys[0])
+		result := checker.Check(ctx, issuer)
+		Expect(status(result, conformance.CheckJWKS)).To(Equal(conformance.StatusFailed))
+	})
+
+	DescribeTable("should check the algorithms",
+		func(mutate func(), expected conformance.Status) {
+			mutate()
+			Expect(status(checker.Check(ctx, issuer), conformance.CheckAlgorithms)).To(Equal(expected))
+		},
+		Entry("none is advertised", func() {
+			config["id_token_signing_alg_values_supported"] = []string{"ES256", "none"}
+		}, conformance.StatusFailed),
+		Entry("algorithm is not advertised", func() {
+			config["id_token_signing_alg_values_supported"] = []string{"RS256"}
+		}, conformance.StatusFailed),
+		Entry("algorithm does not match the key type", func() {
+			keySet.Keys[0].Algorithm = "RS256"
+			config["id_token_signing_alg_values_supported"] = []string{"RS256"}
+		}, conformance.StatusFailed),
+		Entry("key is an encryption key", func() {
+			keySet.Keys[0].Use = "enc"
+		}, conformance.StatusFailed),
+		Entry("use is not set", func() {
+			keySet.Keys[0].Use = ""
+		}, conformance.StatusPassed),
+	)
+
+	DescribeTable("should check the caching headers",
+		func(mutate func(), expected conformance.Status) {
+			mutate()
+			Expect(status(checker.Check(ctx, issuer), conformance.CheckCaching)).To(Equal(expected))
+		},
+		Entry("Cache-Control is missing", func() { configHeader.Del("Cache-Control") }, conformance.StatusFailed),
+		Entry("no-store", func() { jwksHeader.Set("Cache-Control", "no-store") }, conformance.StatusFailed),
+		Entry("private", func() { jwksHeader.Set("Cache-Control", "private, max-age=60") }, conformance.StatusFailed),
+		Entry("max-age is zero", func() { configHeader.Set("Cache-Control", "public, max-age=0") }, conformance.StatusFailed),
+		Entry("max-age is too large", func() { configHeader.Set("Cache-Control", "public, max-age=604800") }, conformance.StatusFailed),
+		Entry("Expires is invalid", func() { jwksHeader.Set("Expires", "tomorrow") }, conformance.StatusFailed),
+		Entry("stale-while-revalidate is set", func() {
+			configHeader.Set("Cache-Control", "public, max-age=60, stale-while-revalidate=30")
+		}, conformance.StatusPassed),
+	)
+})
@@ -0,0 +1,135 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package conformance
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"io"
+	"strconv"
+	"time"
+)
+
+// Summary counts the checks by their status.
+type Summary struct {
+	Passed  int `json:"passed"`
+	Failed  int `json:"failed"`
+	Skipped int `json:"skipped"`
+}
+
+// Summarize counts the checks of the results by their status.
+func Summarize(results []Result) Summary {
+	var s Summary
+	for _, r := range results {
+		s.add(r.Checks)
+	}
+	return s
+}
+
+func (s *Summary) add(checks []Check) {
+	for _, c := range checks {
+		switch c.Status {
+		case StatusPassed:
+			s.Passed++
+		case StatusFailed:
+			s.Failed++
+		case StatusSkipped:
+			s.Skipped++
+		}
+	}
+}
+
+// WriteJSON writes the results together with their summary as JSON.
+func WriteJSON(w io.Writer, results []Result) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(struct {
+		Summary Summary  `json:"summary"`
+		Results []Result `json:"results"`
+	}{Summary: Summarize(results), Results: results})
+}
+
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Skipped  int              `xml:"skipped,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name     string          `xml:"name,attr"`
+	Tests    int             `xml:"tests,attr"`
+	Failures int             `xml:"failures,attr"`
+	Skipped  int             `xml:"skipped,attr"`
+	Time     string          `xml:"time,attr"`
+	Cases    []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitMessage `xml:"failure,omitempty"`
+	Skipped   *junitMessage `xml:"skipped,omitempty"`
+}
+
+type junitMessage struct {
+	Message string `xml:"message,attr"`
+}
+
+// WriteJUnit writes the results as JUnit XML with a test suite per issuer and a test case per check.
+func WriteJUnit(w io.Writer, results []Result) error {
+	summary := Summarize(results)
+	suites := junitTestSuites{
+		Name:     "oidc-discovery-conformance",
+		Tests:    summary.Passed + summary.Failed + summary.Skipped,
+		Failures: summary.Failed,
+		Skipped:  summary.Skipped,
+	}
+	for _, r := range results {
+		var (
+			s     Summary
+			total time.Duration
+			cases = make([]junitTestCase, 0, len(r.Checks))
+		)
+		s.add(r.Checks)
+		for _, c := range r.Checks {
+			tc := junitTestCase{Name: c.Name, ClassName: r.Issuer, Time: seconds(c.Time)}
+			switch c.Status {
+			case StatusFailed:
+				tc.Failure = &junitMessage{Message: c.Message}
+			case StatusSkipped:
+				tc.Skipped = &junitMessage{Message: c.Message}
+			}
+			total += c.Time
+			cases = append(cases, tc)
+		}
+		suites.Suites = append(suites.Suites, junitTestSuite{
+			Name:     r.Issuer,
+			Tests:    len(r.Checks),
+			Failures: s.Failed,
+			Skipped:  s.Skipped,
+			Time:     seconds(total),
+			Cases:    cases,
+		})
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(suites); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+func seconds(d time.Duration) string {
+	return strconv.FormatFloat(d.Seconds(), 'f', 3, 64)
+}
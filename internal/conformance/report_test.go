@@ -0,0 +1,75 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package conformance_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/gardener/gardener-discovery-server/internal/conformance"
+)
+
+var _ = Describe("Report", func() {
+	results := []conformance.Result{
+		{
+			Issuer: "https://example.com/a",
+			Checks: []conformance.Check{
+				{Name: conformance.CheckDiscoveryDocument, Status: conformance.StatusPassed, Time: 1500 * time.Millisecond},
+				{Name: conformance.CheckIssuer, Status: conformance.StatusPassed},
+			},
+		},
+		{
+			Issuer: "https://example.com/b",
+			Checks: []conformance.Check{
+				{Name: conformance.CheckDiscoveryDocument, Status: conformance.StatusFailed, Message: "status code 404"},
+				{Name: conformance.CheckIssuer, Status: conformance.StatusSkipped, Message: "check discovery-document failed"},
+			},
+		},
+	}
+
+	It("should summarize the results", func() {
+		Expect(conformance.Summarize(results)).To(Equal(conformance.Summary{Passed: 2, Failed: 1, Skipped: 1}))
+	})
+
+	It("should write the results as JSON", func() {
+		var buf bytes.Buffer
+		Expect(conformance.WriteJSON(&buf, results)).To(Succeed())
+
+		var report struct {
+			Summary conformance.Summary  `json:"summary"`
+			Results []conformance.Result `json:"results"`
+		}
+		Expect(json.Unmarshal(buf.Bytes(), &report)).To(Succeed())
+		Expect(report.Summary).To(Equal(conformance.Summary{Passed: 2, Failed: 1, Skipped: 1}))
+		Expect(report.Results).To(HaveLen(2))
+		Expect(report.Results[1].Checks[0].Message).To(Equal("status code 404"))
+		Expect(buf.String()).NotTo(ContainSubstring("time"))
+	})
+
+	It("should write the results as JUnit XML", func() {
+		var buf bytes.Buffer
+		Expect(conformance.WriteJUnit(&buf, results)).To(Succeed())
+		Expect(buf.String()).To(Equal(`<?xml version="1.0" encoding="UTF-8"?>
+<testsuites name="oidc-discovery-conformance" tests="4" failures="1" skipped="1">
+  <testsuite name="https://example.com/a" tests="2" failures="0" skipped="0" time="1.500">
+    <testcase name="discovery-document" classname="https://example.com/a" time="1.500"></testcase>
+    <testcase name="issuer" classname="https://example.com/a" time="0.000"></testcase>
+  </testsuite>
+  <testsuite name="https://example.com/b" tests="2" failures="1" skipped="1" time="0.000">
+    <testcase name="discovery-document" classname="https://example.com/b" time="0.000">
+      <failure message="status code 404"></failure>
+    </testcase>
+    <testcase name="issuer" classname="https://example.com/b" time="0.000">
+      <skipped message="check discovery-document failed"></skipped>
+    </testcase>
+  </testsuite>
+</testsuites>
+`))
+	})
+})
@@ -5,12 +5,19 @@
 package dynamiccert
 
 import (
+	"crypto/sha1" // #nosec G505 -- SHA-1 thumbprints are required by AWS IAM OIDC providers.
+	"crypto/sha256"
 	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"fmt"
 	"slices"
 	"sync"
 	"time"
 
 	"github.com/go-logr/logr"
+
+	"github.com/gardener/gardener-discovery-server/internal/metrics"
 )
 
 // DynamicCertificate implements [tls.Config.GetCertificate].
@@ -18,16 +25,22 @@ import (
 type DynamicCertificate struct {
 	certFile string
 	keyFile  string
+	name     string
 
 	interval    time.Duration
 	certificate *tls.Certificate
+	chain       []ChainCertificate
 	log         logr.Logger
 	lock        sync.RWMutex
 }
 
 // New returns a new instance of [DynamicCertificate].
 func New(certFile, keyFile string, opts ...Option) (*DynamicCertificate, error) {
-	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	cert, err := loadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return nil, err
+	}
+	chain, err := parseChain(cert)
 	if err != nil {
 		return nil, err
 	}
@@ -35,7 +48,9 @@ func New(certFile, keyFile string, opts ...Option) (*DynamicCertificate, error)
 	dynamicCert := &DynamicCertificate{
 		certFile:    certFile,
 		keyFile:     keyFile,
+		name:        certFile,
 		certificate: &cert,
+		chain:       chain,
 		interval:    time.Minute,
 		log:         logr.Discard(),
 	}
@@ -43,12 +58,14 @@ func New(certFile, keyFile string, opts ...Option) (*DynamicCertificate, error)
 	for _, opt := range opts {
 		opt(dynamicCert)
 	}
+	metrics.SetCertificateExpiration(dynamicCert.name, cert.Leaf.NotAfter)
 
 	go func() {
 		ticker := time.NewTicker(dynamicCert.interval)
 		for range ticker.C {
 			if err := dynamicCert.reloadCert(); err != nil {
 				dynamicCert.log.Error(err, "Failed to reload certificates")
+				metrics.RecordCertificateReload(dynamicCert.name, false)
 			}
 		}
 	}()
@@ -57,7 +74,11 @@ func New(certFile, keyFile string, opts ...Option) (*DynamicCertificate, error)
 }
 
 func (dc *DynamicCertificate) reloadCert() error {
-	cert, err := tls.LoadX509KeyPair(dc.certFile, dc.keyFile)
+	cert, err := loadX509KeyPair(dc.certFile, dc.keyFile)
+	if err != nil {
+		return err
+	}
+	chain, err := parseChain(cert)
 	if err != nil {
 		return err
 	}
@@ -68,10 +89,55 @@ func (dc *DynamicCertificate) reloadCert() error {
 		return nil
 	}
 	dc.certificate = &cert
+	dc.chain = chain
 	dc.log.Info("Certificate was reloaded")
+	metrics.RecordCertificateReload(dc.name, true)
+	metrics.SetCertificateExpiration(dc.name, cert.Leaf.NotAfter)
 	return nil
 }
 
+// loadX509KeyPair loads the key pair and ensures that the leaf certificate is parsed.
+func loadX509KeyPair(certFile, keyFile string) (tls.Certificate, error) {
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return cert, err
+	}
+	if cert.Leaf == nil {
+		if cert.Leaf, err = x509.ParseCertificate(cert.Certificate[0]); err != nil {
+			return cert, err
+		}
+	}
+	return cert, nil
+}
+
+// ChainCertificate is a parsed certificate of the served chain together with its thumbprints.
+type ChainCertificate struct {
+	*x509.Certificate
+	// SHA1 is the hex encoded SHA-1 thumbprint of the certificate.
+	SHA1 string
+	// SHA256 is the hex encoded SHA-256 thumbprint of the certificate.
+	SHA256 string
+}
+
+// parseChain parses the certificates of the chain and computes their thumbprints, starting with the leaf.
+func parseChain(cert tls.Certificate) ([]ChainCertificate, error) {
+	chain := make([]ChainCertificate, 0, len(cert.Certificate))
+	for _, der := range cert.Certificate {
+		parsed, err := x509.ParseCertificate(der)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse certificate of the chain: %w", err)
+		}
+		sha1Sum := sha1.Sum(der) // #nosec G401 -- SHA-1 thumbprints are required by AWS IAM OIDC providers.
+		sha256Sum := sha256.Sum256(der)
+		chain = append(chain, ChainCertificate{
+			Certificate: parsed,
+			SHA1:        hex.EncodeToString(sha1Sum[:]),
+			SHA256:      hex.EncodeToString(sha256Sum[:]),
+		})
+	}
+	return chain, nil
+}
+
 func areEqual(cert1 [][]byte, cert2 [][]byte) bool {
 	if len(cert1) != len(cert2) {
 		return false
@@ -92,6 +158,34 @@ func (dc *DynamicCertificate) GetCertificate(_ *tls.ClientHelloInfo) (*tls.Certi
 	return dc.certificate, nil
 }
 
+// Chain returns the parsed chain of the current loaded certificate, starting with the leaf.
+// It is parsed once the certificate is loaded and must not be modified.
+func (dc *DynamicCertificate) Chain() []ChainCertificate {
+	dc.lock.RLock()
+	defer dc.lock.RUnlock()
+	return dc.chain
+}
+
+// Name returns the name of the certificate used in logs and metrics.
+func (dc *DynamicCertificate) Name() string {
+	return dc.name
+}
+
+// dnsNames returns the DNS names of the current loaded certificate.
+// The common name is used if the certificate has no DNS names.
+func (dc *DynamicCertificate) dnsNames() []string {
+	dc.lock.RLock()
+	defer dc.lock.RUnlock()
+	leaf := dc.certificate.Leaf
+	if len(leaf.DNSNames) > 0 {
+		return leaf.DNSNames
+	}
+	if leaf.Subject.CommonName != "" {
+		return []string{leaf.Subject.CommonName}
+	}
+	return nil
+}
+
 // Option can be used to configure [DynamicCertificate].
 type Option func(*DynamicCertificate)
 
@@ -109,3 +203,11 @@ func WithLogger(log logr.Logger) Option {
 		dc.log = log
 	}
 }
+
+// WithName sets the name of the certificate used in logs and metrics.
+// It defaults to the path of the certificate file.
+func WithName(name string) Option {
+	return func(dc *DynamicCertificate) {
+		dc.name = name
+	}
+}
@@ -5,7 +5,10 @@
 package dynamiccert_test
 
 import (
+	"crypto/sha1" // #nosec G505 -- test only
+	"crypto/sha256"
 	"crypto/tls"
+	"encoding/hex"
 	"time"
 
 	. "github.com/onsi/ginkgo/v2"
@@ -45,6 +48,19 @@ var _ = Describe("#DynamicCertificate", func() {
 		}, "400ms", "100ms").Should(Succeed())
 	})
 
+	It("should return the parsed chain with its thumbprints", func() {
+		cert, err := dynCert.GetCertificate(&tls.ClientHelloInfo{})
+		Expect(err).ToNot(HaveOccurred())
+		sha1Sum := sha1.Sum(cert.Certificate[0]) // #nosec G401 -- test only
+		sha256Sum := sha256.Sum256(cert.Certificate[0])
+
+		chain := dynCert.Chain()
+		Expect(chain).To(HaveLen(1))
+		Expect(chain[0].Raw).To(Equal(cert.Certificate[0]))
+		Expect(chain[0].SHA1).To(Equal(hex.EncodeToString(sha1Sum[:])))
+		Expect(chain[0].SHA256).To(Equal(hex.EncodeToString(sha256Sum[:])))
+	})
+
 	It("should eventually return the new certificate", func() {
 		cert, err := dynCert.GetCertificate(&tls.ClientHelloInfo{})
 		Expect(err).ToNot(HaveOccurred())
@@ -63,6 +79,7 @@ var _ = Describe("#DynamicCertificate", func() {
 			g.Expect(gotCert).ToNot(BeNil())
 			g.Expect(gotCert.Certificate).To(HaveLen(1))
 			g.Expect(cert.Certificate[0]).NotTo(Equal(gotCert.Certificate[0]))
+			g.Expect(dynCert.Chain()[0].Raw).To(Equal(gotCert.Certificate[0]))
 		}, "400ms", "100ms").Should(Succeed())
 	})
 })
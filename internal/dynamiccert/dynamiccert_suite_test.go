@@ -57,6 +57,11 @@ func generateTestData() error {
 		return err
 	}
 
+	return generateCertificate(servercert, serverkey, "localhost")
+}
+
+// generateCertificate writes a self-signed certificate for the given DNS names and its private key.
+func generateCertificate(certPath, keyPath string, dnsNames ...string) error {
 	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
 	if err != nil {
 		return err
@@ -71,11 +76,9 @@ func generateTestData() error {
 	cert := x509.Certificate{
 		SerialNumber: serialNumber,
 		Subject: pkix.Name{
-			CommonName: "localhost",
-		},
-		DNSNames: []string{
-			"localhost",
+			CommonName: dnsNames[0],
 		},
+		DNSNames:              dnsNames,
 		IPAddresses:           []net.IP{net.ParseIP("127.0.0.1")},
 		NotBefore:             now,
 		NotAfter:              now.Add(time.Hour),
@@ -89,7 +92,7 @@ func generateTestData() error {
 		return err
 	}
 
-	certFile, err := os.OpenFile(filepath.Clean(servercert), os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	certFile, err := os.OpenFile(filepath.Clean(certPath), os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
 	if err != nil {
 		return err
 	}
@@ -105,7 +108,7 @@ func generateTestData() error {
 		return err
 	}
 
-	keyFile, err := os.OpenFile(filepath.Clean(serverkey), os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	keyFile, err := os.OpenFile(filepath.Clean(keyPath), os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,87 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package dynamiccert
+
+import (
+	"crypto/tls"
+	"strings"
+
+	"github.com/gardener/gardener-discovery-server/internal/metrics"
+)
+
+// NamedCertificate is a [DynamicCertificate] that is served for a set of server names.
+type NamedCertificate struct {
+	// Names are the server names the certificate is served for.
+	// Wildcard names like "*.example.com" match exactly one label.
+	// If empty, the DNS names of the loaded certificate are used.
+	Names []string
+	// Certificate is the certificate served for the names.
+	Certificate *DynamicCertificate
+}
+
+func (nc NamedCertificate) names() []string {
+	if len(nc.Names) > 0 {
+		return nc.Names
+	}
+	return nc.Certificate.dnsNames()
+}
+
+// SNICertificate implements [tls.Config.GetCertificate].
+// It selects the certificate based on the server name indication of the client,
+// and falls back to a default certificate if no certificate matches.
+type SNICertificate struct {
+	defaultCert *DynamicCertificate
+	named       []NamedCertificate
+}
+
+// NewSNI returns a new instance of [SNICertificate].
+// Exact matches take precedence over wildcard matches. If several certificates
+// match equally, the first one in the given order is selected.
+func NewSNI(defaultCert *DynamicCertificate, named ...NamedCertificate) *SNICertificate {
+	return &SNICertificate{
+		defaultCert: defaultCert,
+		named:       named,
+	}
+}
+
+// GetCertificate returns the certificate matching the server name of the client hello.
+// The selection is recorded as metric, see [SNICertificate.Match] for selecting a certificate outside of handshakes.
+func (s *SNICertificate) GetCertificate(hello *tls.ClientHelloInfo) (*tls.Certificate, error) {
+	dc := s.Match(hello.ServerName)
+	metrics.RecordCertificateSelection(dc.name)
+	return dc.GetCertificate(hello)
+}
+
+// Match returns the certificate served for the server name without recording the selection,
+// e.g. to describe the certificate of a host in a document.
+func (s *SNICertificate) Match(serverName string) *DynamicCertificate {
+	serverName = strings.ToLower(strings.TrimSuffix(serverName, "."))
+	if serverName == "" {
+		return s.defaultCert
+	}
+
+	var wildcard string
+	if i := strings.IndexByte(serverName, '.'); i > 0 {
+		wildcard = "*" + serverName[i:]
+	}
+
+	var wildcardMatch *DynamicCertificate
+	for _, nc := range s.named {
+		for _, name := range nc.names() {
+			name = strings.ToLower(name)
+			if name == serverName {
+				return nc.Certificate
+			}
+			if wildcardMatch == nil && wildcard != "" && name == wildcard {
+				wildcardMatch = nc.Certificate
+			}
+		}
+	}
+
+	if wildcardMatch != nil {
+		return wildcardMatch
+	}
+	return s.defaultCert
+}
@@ -0,0 +1,81 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package dynamiccert_test
+
+import (
+	"crypto/tls"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/gardener/gardener-discovery-server/internal/dynamiccert"
+)
+
+var _ = Describe("#SNICertificate", func() {
+	var (
+		defaultCert, legacyCert, newCert, wildcardCert *dynamiccert.DynamicCertificate
+
+		sni *dynamiccert.SNICertificate
+
+		newDynamicCert = func(name string, dnsNames ...string) *dynamiccert.DynamicCertificate {
+			certPath := filepath.Join(testdataDir, name+".crt")
+			keyPath := filepath.Join(testdataDir, name+".key")
+			Expect(generateCertificate(certPath, keyPath, dnsNames...)).To(Succeed())
+
+			dc, err := dynamiccert.New(certPath, keyPath, dynamiccert.WithName(name), dynamiccert.WithRefreshInterval(time.Hour))
+			Expect(err).ToNot(HaveOccurred())
+			return dc
+		}
+
+		expectSelected = func(serverName string, want *dynamiccert.DynamicCertificate) {
+			GinkgoHelper()
+			got, err := sni.GetCertificate(&tls.ClientHelloInfo{ServerName: serverName})
+			Expect(err).ToNot(HaveOccurred())
+			expected, err := want.GetCertificate(&tls.ClientHelloInfo{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(got).To(BeIdenticalTo(expected), "server name %q selected the wrong certificate", serverName)
+			Expect(sni.Match(serverName)).To(BeIdenticalTo(want), "server name %q matched the wrong certificate", serverName)
+		}
+	)
+
+	BeforeEach(func() {
+		defaultCert = newDynamicCert("default", "default.example.com")
+		legacyCert = newDynamicCert("legacy", "discovery.legacy.example.com")
+		newCert = newDynamicCert("new", "unused.example.com")
+		wildcardCert = newDynamicCert("wildcard", "*.new.example.com")
+
+		sni = dynamiccert.NewSNI(defaultCert,
+			dynamiccert.NamedCertificate{Certificate: legacyCert},
+			dynamiccert.NamedCertificate{Certificate: wildcardCert},
+			dynamiccert.NamedCertificate{Certificate: newCert, Names: []string{"discovery.new.example.com"}},
+		)
+	})
+
+	It("should select the default certificate without server name", func() {
+		expectSelected("", defaultCert)
+	})
+
+	It("should select the default certificate if no name matches", func() {
+		expectSelected("foo.example.org", defaultCert)
+		expectSelected("unused.example.com", defaultCert)
+	})
+
+	It("should select certificates by their DNS names", func() {
+		expectSelected("discovery.legacy.example.com", legacyCert)
+		expectSelected("DISCOVERY.legacy.example.com.", legacyCert)
+	})
+
+	It("should prefer exact matches over wildcard matches", func() {
+		expectSelected("discovery.new.example.com", newCert)
+		expectSelected("other.new.example.com", wildcardCert)
+	})
+
+	It("should match exactly one label with wildcards", func() {
+		expectSelected("new.example.com", defaultCert)
+		expectSelected("a.b.new.example.com", defaultCert)
+	})
+})
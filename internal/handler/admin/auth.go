@@ -0,0 +1,101 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package admin
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/go-logr/logr"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/gardener/gardener-discovery-server/internal/handler"
+)
+
+// User is an authenticated client of the admin API.
+type User struct {
+	Name   string
+	Groups []string
+}
+
+// Authenticator authenticates requests to the admin API.
+// It returns nil if the request does not carry credentials it can verify.
+type Authenticator interface {
+	Authenticate(r *http.Request) (*User, error)
+}
+
+// ClientCertificateAuthenticator authenticates requests by their verified client certificate.
+// The common name is used as user name and the organizations as groups.
+type ClientCertificateAuthenticator struct{}
+
+// Authenticate implements [Authenticator].
+func (ClientCertificateAuthenticator) Authenticate(r *http.Request) (*User, error) {
+	if r.TLS == nil || len(r.TLS.VerifiedChains) == 0 || len(r.TLS.VerifiedChains[0]) == 0 {
+		return nil, nil
+	}
+	cert := r.TLS.VerifiedChains[0][0]
+	return &User{Name: cert.Subject.CommonName, Groups: cert.Subject.Organization}, nil
+}
+
+// TokenReviewAuthenticator authenticates bearer tokens with the TokenReview API of the garden cluster.
+type TokenReviewAuthenticator struct {
+	Client client.Client
+}
+
+// Authenticate implements [Authenticator].
+func (a *TokenReviewAuthenticator) Authenticate(r *http.Request) (*User, error) {
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok || strings.TrimSpace(token) == "" {
+		return nil, nil
+	}
+
+	review := &authenticationv1.TokenReview{Spec: authenticationv1.TokenReviewSpec{Token: strings.TrimSpace(token)}}
+	if err := a.Client.Create(r.Context(), review); err != nil {
+		return nil, fmt.Errorf("failed to review token: %w", err)
+	}
+	if !review.Status.Authenticated {
+		return nil, nil
+	}
+	return &User{Name: review.Status.User.Username, Groups: review.Status.User.Groups}, nil
+}
+
+// WithAuthentication is middleware handler admitting requests of users authenticated by one of the authenticators.
+// If allowed users or groups are given, the user must be one of the users or a member of one of the groups.
+func WithAuthentication(next http.Handler, log logr.Logger, authenticators []Authenticator, allowedUsers, allowedGroups []string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var user *User
+		for _, authn := range authenticators {
+			u, err := authn.Authenticate(r)
+			if err != nil {
+				handler.RequestLog(log, r).Error(err, "Failed to authenticate request")
+				handler.WriteProblem(w, r, log, http.StatusInternalServerError, "authentication failed")
+				return
+			}
+			if u != nil {
+				user = u
+				break
+			}
+		}
+
+		if user == nil {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="gardener-discovery-server-admin"`)
+			handler.WriteProblem(w, r, log, http.StatusUnauthorized, "unauthorized")
+			return
+		}
+
+		if (len(allowedUsers) > 0 || len(allowedGroups) > 0) &&
+			!slices.Contains(allowedUsers, user.Name) &&
+			!slices.ContainsFunc(user.Groups, func(g string) bool { return slices.Contains(allowedGroups, g) }) {
+			handler.RequestLog(log, r).Info("Denied admin request", "user", user.Name)
+			handler.WriteProblem(w, r, log, http.StatusForbidden, "forbidden")
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,120 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package admin_test
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/gardener/gardener/pkg/client/kubernetes"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	logzap "sigs.k8s.io/controller-runtime/pkg/log/zap"
+
+	"github.com/gardener/gardener-discovery-server/internal/handler/admin"
+)
+
+var _ = Describe("#WithAuthentication", func() {
+	var (
+		c              client.Client
+		authenticators []admin.Authenticator
+		allowedUsers   []string
+		allowedGroups  []string
+		reviewErr      error
+
+		serve = func(req *http.Request) int {
+			log := logzap.New(logzap.WriteTo(GinkgoWriter))
+			next := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) { w.WriteHeader(http.StatusOK) })
+			recorder := httptest.NewRecorder()
+			admin.WithAuthentication(next, log, authenticators, allowedUsers, allowedGroups).ServeHTTP(recorder, req)
+			return recorder.Code
+		}
+
+		withToken = func(token string) *http.Request {
+			req := httptest.NewRequest(http.MethodGet, "/admin/stores", nil)
+			req.Header.Set("Authorization", "Bearer "+token)
+			return req
+		}
+
+		withCertificate = func(cn string, orgs ...string) *http.Request {
+			req := httptest.NewRequest(http.MethodGet, "/admin/stores", nil)
+			req.TLS = &tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{{
+				{Subject: pkix.Name{CommonName: cn, Organization: orgs}},
+			}}}
+			return req
+		}
+	)
+
+	BeforeEach(func() {
+		reviewErr = nil
+		allowedUsers = []string{"alice"}
+		allowedGroups = []string{"admins"}
+
+		c = fake.NewClientBuilder().WithScheme(kubernetes.GardenScheme).WithInterceptorFuncs(interceptor.Funcs{
+			Create: func(_ context.Context, _ client.WithWatch, obj client.Object, _ ...client.CreateOption) error {
+				if reviewErr != nil {
+					return reviewErr
+				}
+				review := obj.(*authenticationv1.TokenReview)
+				switch review.Spec.Token {
+				case "alice":
+					review.Status = authenticationv1.TokenReviewStatus{Authenticated: true, User: authenticationv1.UserInfo{Username: "alice"}}
+				case "bob":
+					review.Status = authenticationv1.TokenReviewStatus{Authenticated: true, User: authenticationv1.UserInfo{Username: "bob", Groups: []string{"admins"}}}
+				case "eve":
+					review.Status = authenticationv1.TokenReviewStatus{Authenticated: true, User: authenticationv1.UserInfo{Username: "eve"}}
+				}
+				return nil
+			},
+		}).Build()
+
+		authenticators = []admin.Authenticator{admin.ClientCertificateAuthenticator{}, &admin.TokenReviewAuthenticator{Client: c}}
+	})
+
+	It("should reject requests without credentials", func() {
+		Expect(serve(httptest.NewRequest(http.MethodGet, "/admin/stores", nil))).To(Equal(http.StatusUnauthorized))
+	})
+
+	It("should reject invalid tokens", func() {
+		Expect(serve(withToken("invalid"))).To(Equal(http.StatusUnauthorized))
+	})
+
+	It("should admit allowed users", func() {
+		Expect(serve(withToken("alice"))).To(Equal(http.StatusOK))
+	})
+
+	It("should admit members of allowed groups", func() {
+		Expect(serve(withToken("bob"))).To(Equal(http.StatusOK))
+	})
+
+	It("should forbid other users", func() {
+		Expect(serve(withToken("eve"))).To(Equal(http.StatusForbidden))
+	})
+
+	It("should admit any authenticated user if no users or groups are allowed explicitly", func() {
+		allowedUsers, allowedGroups = nil, nil
+		Expect(serve(withToken("eve"))).To(Equal(http.StatusOK))
+	})
+
+	It("should fail if the token cannot be reviewed", func() {
+		reviewErr = errors.New("boom")
+		Expect(serve(withToken("alice"))).To(Equal(http.StatusInternalServerError))
+	})
+
+	It("should authenticate verified client certificates", func() {
+		Expect(serve(withCertificate("alice"))).To(Equal(http.StatusOK))
+		Expect(serve(withCertificate("carol", "admins"))).To(Equal(http.StatusOK))
+		Expect(serve(withCertificate("eve"))).To(Equal(http.StatusForbidden))
+	})
+})
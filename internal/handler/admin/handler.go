@@ -0,0 +1,235 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package admin
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"net/http"
+	"slices"
+	"sort"
+	"strconv"
+
+	"github.com/go-logr/logr"
+
+	"github.com/gardener/gardener-discovery-server/internal/handler"
+	"github.com/gardener/gardener-discovery-server/internal/store"
+	"github.com/gardener/gardener-discovery-server/internal/tokenverify"
+)
+
+const (
+	defaultLimit = 100
+	maxLimit     = 1000
+)
+
+// Breaker is a deletion breaker guarding a store.
+type Breaker interface {
+	State() store.BreakerState
+	Release() int
+}
+
+// Handler serves the admin API inspecting the published entries.
+type Handler struct {
+	stores   map[string]store.Inspector
+	breakers map[string]Breaker
+	log      logr.Logger
+}
+
+// New constructs a new [Handler] for the stores and their deletion breakers identified by the store name.
+func New(stores map[string]store.Inspector, breakers map[string]Breaker, log logr.Logger) *Handler {
+	return &Handler{
+		stores:   stores,
+		breakers: breakers,
+		log:      log,
+	}
+}
+
+// ListResponse is a page of a list response.
+type ListResponse[T any] struct {
+	Items []T `json:"items"`
+	// Continue is the key to pass as "continue" query parameter to retrieve the next page.
+	// It is empty on the last page.
+	Continue string `json:"continue,omitempty"`
+}
+
+// EntryResponse describes a published or rejected entry.
+type EntryResponse struct {
+	Published bool             `json:"published"`
+	Metadata  *store.Metadata  `json:"metadata,omitempty"`
+	Rejection *store.Rejection `json:"rejection,omitempty"`
+}
+
+// BreakerResponse describes the deletion breaker of a store.
+type BreakerResponse struct {
+	Store string `json:"store"`
+	store.BreakerState
+}
+
+// VerifyTokenRequest is the request to diagnose a token.
+type VerifyTokenRequest struct {
+	Token string `json:"token"`
+	// Audiences are the audiences the token is expected to be issued for, any audience is accepted if it is empty.
+	Audiences []string `json:"audiences,omitempty"`
+}
+
+// ReleaseResponse is the result of releasing a deletion breaker.
+type ReleaseResponse struct {
+	// Released is the number of performed deletions that were paused.
+	Released int `json:"released"`
+}
+
+// HandleStores lists the names of the stores.
+func (h *Handler) HandleStores() http.Handler {
+	log := h.log.WithName("stores")
+	return handler.AllowMethods(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		names := make([]string, 0, len(h.stores))
+		for name := range h.stores {
+			names = append(names, name)
+		}
+		slices.Sort(names)
+		writeJSON(w, log, http.StatusOK, ListResponse[string]{Items: names})
+	}), log, http.MethodGet, http.MethodHead)
+}
+
+// HandleEntries lists the metadata of the published entries.
+// It requires "store" as path parameter and supports the query parameters
+// "project", "limit" and "continue".
+func (h *Handler) HandleEntries() http.Handler {
+	log := h.log.WithName("entries")
+	return h.storeRequest(log, func(w http.ResponseWriter, r *http.Request, s store.Inspector) {
+		writePage(w, r, log, s.List(projectPrefix(r)), func(m store.Metadata) string { return m.Key })
+	})
+}
+
+// HandleRejections lists the last rejection reasons of unpublished entries.
+// It requires "store" as path parameter and supports the query parameters
+// "project", "limit" and "continue".
+func (h *Handler) HandleRejections() http.Handler {
+	log := h.log.WithName("rejections")
+	return h.storeRequest(log, func(w http.ResponseWriter, r *http.Request, s store.Inspector) {
+		writePage(w, r, log, s.Rejections(projectPrefix(r)), func(r store.Rejection) string { return r.Key })
+	})
+}
+
+// HandleEntry shows the metadata of an entry or the reason it is not published.
+// It requires "store" and "key" as path parameters.
+func (h *Handler) HandleEntry() http.Handler {
+	log := h.log.WithName("entry")
+	return h.storeRequest(log, func(w http.ResponseWriter, r *http.Request, s store.Inspector) {
+		key := r.PathValue("key")
+		resp := EntryResponse{}
+		if metadata, ok := s.Metadata(key); ok {
+			resp.Published = true
+			resp.Metadata = &metadata
+		} else if rejection, ok := s.Rejection(key); ok {
+			resp.Rejection = &rejection
+		} else {
+			handler.WriteProblem(w, r, log, http.StatusNotFound, "entry not found")
+			return
+		}
+		writeJSON(w, log, http.StatusOK, resp)
+	})
+}
+
+// HandleBreakers lists the state of the deletion breakers.
+func (h *Handler) HandleBreakers() http.Handler {
+	log := h.log.WithName("breakers")
+	return handler.AllowMethods(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		resp := ListResponse[BreakerResponse]{Items: make([]BreakerResponse, 0, len(h.breakers))}
+		for _, name := range slices.Sorted(maps.Keys(h.breakers)) {
+			resp.Items = append(resp.Items, BreakerResponse{Store: name, BreakerState: h.breakers[name].State()})
+		}
+		writeJSON(w, log, http.StatusOK, resp)
+	}), log, http.MethodGet, http.MethodHead)
+}
+
+// HandleReleaseBreaker performs the paused deletions of a store and closes its deletion breaker.
+// It requires "store" as path parameter.
+func (h *Handler) HandleReleaseBreaker() http.Handler {
+	log := h.log.WithName("release-breaker")
+	return handler.AllowMethods(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		name := r.PathValue("store")
+		b, ok := h.breakers[name]
+		if !ok {
+			handler.WriteProblem(w, r, log, http.StatusNotFound, "breaker not found")
+			return
+		}
+		released := b.Release()
+		handler.RequestLog(log, r).Info("Released deletion breaker", "store", name, "released", released)
+		writeJSON(w, log, http.StatusOK, ReleaseResponse{Released: released})
+	}), log, http.MethodPost)
+}
+
+// HandleVerifyToken diagnoses the token of a [VerifyTokenRequest] against the published JWKS of its issuer.
+// The token itself is never logged.
+func (h *Handler) HandleVerifyToken(verifier *tokenverify.Verifier) http.Handler {
+	log := h.log.WithName("verify-token")
+	return handler.AllowMethods(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req VerifyTokenRequest
+		if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 2*tokenverify.MaxTokenSize)).Decode(&req); err != nil {
+			handler.WriteProblem(w, r, log, http.StatusBadRequest, "request body must be a JSON object with a token")
+			return
+		}
+		if req.Token == "" {
+			handler.WriteProblem(w, r, log, http.StatusBadRequest, "token is required")
+			return
+		}
+		diagnosis := verifier.Verify(req.Token, req.Audiences)
+		handler.RequestLog(log, r).Info("Verified token", "issuer", diagnosis.Issuer, "valid", diagnosis.Valid)
+		writeJSON(w, log, http.StatusOK, diagnosis)
+	}), log, http.MethodPost)
+}
+
+func (h *Handler) storeRequest(log logr.Logger, serve func(http.ResponseWriter, *http.Request, store.Inspector)) http.Handler {
+	return handler.AllowMethods(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s, ok := h.stores[r.PathValue("store")]
+		if !ok {
+			handler.WriteProblem(w, r, log, http.StatusNotFound, "store not found")
+			return
+		}
+		serve(w, r, s)
+	}), log, http.MethodGet, http.MethodHead)
+}
+
+func projectPrefix(r *http.Request) string {
+	if project := r.URL.Query().Get("project"); project != "" {
+		return project + "--"
+	}
+	return ""
+}
+
+// writePage writes the page of the items sorted by key that starts after the "continue" key.
+func writePage[T any](w http.ResponseWriter, r *http.Request, log logr.Logger, items []T, key func(T) string) {
+	limit := defaultLimit
+	if v := r.URL.Query().Get("limit"); v != "" {
+		l, err := strconv.Atoi(v)
+		if err != nil || l <= 0 || l > maxLimit {
+			handler.WriteProblem(w, r, log, http.StatusBadRequest, fmt.Sprintf("limit must be between 1 and %d", maxLimit))
+			return
+		}
+		limit = l
+	}
+
+	if after := r.URL.Query().Get("continue"); after != "" {
+		items = items[sort.Search(len(items), func(i int) bool { return key(items[i]) > after }):]
+	}
+
+	resp := ListResponse[T]{Items: items}
+	if len(items) > limit {
+		resp.Items = items[:limit]
+		resp.Continue = key(items[limit-1])
+	}
+	writeJSON(w, log, http.StatusOK, resp)
+}
+
+func writeJSON(w http.ResponseWriter, log logr.Logger, code int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(code)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		log.Error(err, "Failed writing response")
+	}
+}
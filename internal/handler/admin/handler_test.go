@@ -0,0 +1,220 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package admin_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	logzap "sigs.k8s.io/controller-runtime/pkg/log/zap"
+
+	"github.com/gardener/gardener-discovery-server/internal/handler/admin"
+	"github.com/gardener/gardener-discovery-server/internal/store"
+	oidstore "github.com/gardener/gardener-discovery-server/internal/store/openidmeta"
+	"github.com/gardener/gardener-discovery-server/internal/tokenverify"
+)
+
+type unknownIssuers struct{}
+
+func (unknownIssuers) Resolve(string) (tokenverify.Documents, error) {
+	return tokenverify.Documents{}, errors.New("unknown issuer")
+}
+
+var _ = Describe("#Handler", func() {
+	var (
+		s       *store.Store[oidstore.Data]
+		breaker *store.DeletionBreaker[oidstore.Data]
+		mux     *http.ServeMux
+
+		source = store.ObjectReference{Kind: "Secret", Namespace: "gardener-system-shoot-issuer", Name: "foo--1", ResourceVersion: "42"}
+
+		get = func(uri string, into any) int {
+			req := httptest.NewRequest(http.MethodGet, uri, nil)
+			recorder := httptest.NewRecorder()
+			mux.ServeHTTP(recorder, req)
+			if recorder.Code >= http.StatusBadRequest {
+				Expect(recorder.Header().Get("Content-Type")).To(Equal("application/problem+json"))
+			} else {
+				Expect(recorder.Header().Get("Content-Type")).To(Equal("application/json"))
+			}
+			if into != nil {
+				Expect(json.Unmarshal(recorder.Body.Bytes(), into)).To(Succeed())
+			}
+			return recorder.Code
+		}
+
+		keys = func(items []store.Metadata) []string {
+			out := []string{}
+			for _, item := range items {
+				out = append(out, item.Key)
+			}
+			return out
+		}
+	)
+
+	BeforeEach(func() {
+		s = store.MustNewStore(oidstore.Copy)
+		s.Write("foo--1", oidstore.Data{Config: []byte("config")}, store.WithSource(source))
+		for _, key := range []string{"foo--2", "foo--3", "bar--1", "foobar--1"} {
+			s.Write(key, oidstore.Data{Config: []byte(key)})
+		}
+		s.Write("baz--1", oidstore.Data{})
+		s.Delete("baz--1", store.WithReason("shoot not found"), store.WithSource(source))
+
+		log := logzap.New(logzap.WriteTo(GinkgoWriter))
+		breaker = store.NewDeletionBreaker(s, store.BreakerConfig{Window: time.Hour}, log)
+		h := admin.New(map[string]store.Inspector{"openidmeta": s}, map[string]admin.Breaker{"openidmeta": breaker}, log)
+		mux = http.NewServeMux()
+		mux.Handle("/admin/stores", h.HandleStores())
+		mux.Handle("/admin/stores/{store}/entries", h.HandleEntries())
+		mux.Handle("/admin/stores/{store}/entries/{key}", h.HandleEntry())
+		mux.Handle("/admin/stores/{store}/rejections", h.HandleRejections())
+		mux.Handle("/admin/breakers", h.HandleBreakers())
+		mux.Handle("/admin/breakers/{store}/release", h.HandleReleaseBreaker())
+		mux.Handle("/admin/verify-token", h.HandleVerifyToken(&tokenverify.Verifier{Resolver: unknownIssuers{}}))
+	})
+
+	It("should list the stores", func() {
+		resp := admin.ListResponse[string]{}
+		Expect(get("/admin/stores", &resp)).To(Equal(http.StatusOK))
+		Expect(resp.Items).To(Equal([]string{"openidmeta"}))
+	})
+
+	It("should list all entries", func() {
+		resp := admin.ListResponse[store.Metadata]{}
+		Expect(get("/admin/stores/openidmeta/entries", &resp)).To(Equal(http.StatusOK))
+		Expect(keys(resp.Items)).To(Equal([]string{"bar--1", "foo--1", "foo--2", "foo--3", "foobar--1"}))
+		Expect(resp.Continue).To(BeEmpty())
+	})
+
+	It("should filter entries by project", func() {
+		resp := admin.ListResponse[store.Metadata]{}
+		Expect(get("/admin/stores/openidmeta/entries?project=foo", &resp)).To(Equal(http.StatusOK))
+		Expect(keys(resp.Items)).To(Equal([]string{"foo--1", "foo--2", "foo--3"}))
+	})
+
+	It("should paginate entries", func() {
+		resp := admin.ListResponse[store.Metadata]{}
+		Expect(get("/admin/stores/openidmeta/entries?limit=2", &resp)).To(Equal(http.StatusOK))
+		Expect(keys(resp.Items)).To(Equal([]string{"bar--1", "foo--1"}))
+		Expect(resp.Continue).To(Equal("foo--1"))
+
+		resp = admin.ListResponse[store.Metadata]{}
+		Expect(get("/admin/stores/openidmeta/entries?limit=2&continue=foo--1", &resp)).To(Equal(http.StatusOK))
+		Expect(keys(resp.Items)).To(Equal([]string{"foo--2", "foo--3"}))
+		Expect(resp.Continue).To(Equal("foo--3"))
+
+		resp = admin.ListResponse[store.Metadata]{}
+		Expect(get("/admin/stores/openidmeta/entries?limit=2&continue=foo--3", &resp)).To(Equal(http.StatusOK))
+		Expect(keys(resp.Items)).To(Equal([]string{"foobar--1"}))
+		Expect(resp.Continue).To(BeEmpty())
+	})
+
+	DescribeTable("should reject invalid limits",
+		func(limit string) {
+			Expect(get("/admin/stores/openidmeta/entries?limit="+limit, nil)).To(Equal(http.StatusBadRequest))
+		},
+		Entry("not a number", "foo"),
+		Entry("zero", "0"),
+		Entry("too large", "1001"),
+	)
+
+	It("should show a published entry", func() {
+		resp := admin.EntryResponse{}
+		Expect(get("/admin/stores/openidmeta/entries/foo--1", &resp)).To(Equal(http.StatusOK))
+		Expect(resp.Published).To(BeTrue())
+		Expect(resp.Rejection).To(BeNil())
+		Expect(resp.Metadata.Key).To(Equal("foo--1"))
+		Expect(resp.Metadata.Source).To(Equal(&source))
+		Expect(resp.Metadata.Hash).To(HaveLen(64))
+		Expect(resp.Metadata.LastWrite).ToNot(BeZero())
+	})
+
+	It("should show the rejection reason of an unpublished entry", func() {
+		resp := admin.EntryResponse{}
+		Expect(get("/admin/stores/openidmeta/entries/baz--1", &resp)).To(Equal(http.StatusOK))
+		Expect(resp.Published).To(BeFalse())
+		Expect(resp.Metadata).To(BeNil())
+		Expect(resp.Rejection.Reason).To(Equal("shoot not found"))
+		Expect(resp.Rejection.Source).To(Equal(&source))
+	})
+
+	It("should list the rejections", func() {
+		resp := admin.ListResponse[store.Rejection]{}
+		Expect(get("/admin/stores/openidmeta/rejections", &resp)).To(Equal(http.StatusOK))
+		Expect(resp.Items).To(HaveLen(1))
+		Expect(resp.Items[0].Key).To(Equal("baz--1"))
+
+		resp = admin.ListResponse[store.Rejection]{}
+		Expect(get("/admin/stores/openidmeta/rejections?project=foo", &resp)).To(Equal(http.StatusOK))
+		Expect(resp.Items).To(BeEmpty())
+	})
+
+	It("should not find unknown entries and stores", func() {
+		Expect(get("/admin/stores/openidmeta/entries/unknown--1", nil)).To(Equal(http.StatusNotFound))
+		Expect(get("/admin/stores/unknown/entries", nil)).To(Equal(http.StatusNotFound))
+	})
+
+	It("should only allow reading", func() {
+		req := httptest.NewRequest(http.MethodDelete, "/admin/stores/openidmeta/entries/foo--1", nil)
+		recorder := httptest.NewRecorder()
+		mux.ServeHTTP(recorder, req)
+		Expect(recorder.Code).To(Equal(http.StatusMethodNotAllowed))
+	})
+
+	It("should show and release the deletion breakers", func() {
+		breaker.Delete("foo--3", store.WithReason("secret not found"))
+
+		breakers := admin.ListResponse[admin.BreakerResponse]{}
+		Expect(get("/admin/breakers", &breakers)).To(Equal(http.StatusOK))
+		Expect(breakers.Items).To(HaveLen(1))
+		Expect(breakers.Items[0].Store).To(Equal("openidmeta"))
+		Expect(breakers.Items[0].Open).To(BeTrue())
+		Expect(breakers.Items[0].PausedDeletions).To(Equal([]string{"foo--3"}))
+
+		Expect(get("/admin/breakers/openidmeta/release", nil)).To(Equal(http.StatusMethodNotAllowed))
+
+		req := httptest.NewRequest(http.MethodPost, "/admin/breakers/openidmeta/release", nil)
+		recorder := httptest.NewRecorder()
+		mux.ServeHTTP(recorder, req)
+		Expect(recorder.Code).To(Equal(http.StatusOK))
+		Expect(recorder.Body.String()).To(MatchJSON(`{"released":1}`))
+		Expect(breaker.State().Open).To(BeFalse())
+		_, ok := s.Read("foo--3")
+		Expect(ok).To(BeFalse())
+
+		req = httptest.NewRequest(http.MethodPost, "/admin/breakers/unknown/release", nil)
+		recorder = httptest.NewRecorder()
+		mux.ServeHTTP(recorder, req)
+		Expect(recorder.Code).To(Equal(http.StatusNotFound))
+	})
+
+	It("should diagnose tokens", func() {
+		post := func(body string) *httptest.ResponseRecorder {
+			recorder := httptest.NewRecorder()
+			mux.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/admin/verify-token", strings.NewReader(body)))
+			return recorder
+		}
+
+		Expect(get("/admin/verify-token", nil)).To(Equal(http.StatusMethodNotAllowed))
+		Expect(post("token").Code).To(Equal(http.StatusBadRequest))
+		Expect(post(`{"audiences":["foo"]}`).Code).To(Equal(http.StatusBadRequest))
+		Expect(post(`{"token":"` + strings.Repeat("a", 2*tokenverify.MaxTokenSize) + `"}`).Code).To(Equal(http.StatusBadRequest))
+
+		recorder := post(`{"token":"not-a-token","audiences":["foo"]}`)
+		Expect(recorder.Code).To(Equal(http.StatusOK))
+		diagnosis := tokenverify.Diagnosis{}
+		Expect(json.Unmarshal(recorder.Body.Bytes(), &diagnosis)).To(Succeed())
+		Expect(diagnosis.Valid).To(BeFalse())
+		Expect(diagnosis.Checks).To(HaveLen(1))
+		Expect(diagnosis.Checks[0].Name).To(Equal(tokenverify.CheckFormat))
+	})
+})
@@ -0,0 +1,75 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const noCacheControl = "no-store"
+
+// CachePolicy configures the caching headers of successful responses.
+type CachePolicy struct {
+	MaxAge time.Duration
+	// StaleWhileRevalidate is the duration a stale response may be served while it is revalidated.
+	StaleWhileRevalidate time.Duration
+	// StaleIfError is the duration a stale response may be served if revalidation fails.
+	StaleIfError time.Duration
+	// Expires indicates whether the max-age is capped to the expiration hint of the data.
+	// The Expires header is only set if the hint is within the max-age, later hints such as the end of the
+	// validity of a certificate say nothing about when the document is rotated.
+	Expires bool
+}
+
+// CachePolicies are the cache policies per endpoint type.
+type CachePolicies struct {
+	OpenIDConfiguration CachePolicy
+	JWKS                CachePolicy
+	CABundle            CachePolicy
+	SPIFFEBundle        CachePolicy
+}
+
+// DefaultCachePolicy is the cache policy used if none is configured.
+var DefaultCachePolicy = CachePolicy{MaxAge: time.Hour}
+
+// DefaultCachePolicies uses the [DefaultCachePolicy] for all endpoint types.
+var DefaultCachePolicies = CachePolicies{
+	OpenIDConfiguration: DefaultCachePolicy,
+	JWKS:                DefaultCachePolicy,
+	CABundle:            DefaultCachePolicy,
+	SPIFFEBundle:        DefaultCachePolicy,
+}
+
+// Expirer can be implemented by data with an expiration hint, e.g. the end of the validity of a certificate.
+type Expirer interface {
+	// Expiration returns the expiration hint, the zero time means the data does not expire.
+	Expiration() time.Time
+}
+
+// SetHeaders sets the caching headers of a successful response for data expiring at the given time.
+// The zero time means the data does not expire.
+func (p CachePolicy) SetHeaders(h http.Header, expires time.Time) {
+	maxAge := p.MaxAge
+	if until := time.Until(expires); p.Expires && !expires.IsZero() && until < maxAge {
+		maxAge = max(until, 0)
+		h.Set(headerExpires, expires.UTC().Format(http.TimeFormat))
+	}
+
+	directives := []string{"public", "max-age=" + seconds(maxAge)}
+	if p.StaleWhileRevalidate > 0 {
+		directives = append(directives, "stale-while-revalidate="+seconds(p.StaleWhileRevalidate))
+	}
+	if p.StaleIfError > 0 {
+		directives = append(directives, "stale-if-error="+seconds(p.StaleIfError))
+	}
+	h.Set(headerCacheControl, strings.Join(directives, ", "))
+}
+
+func seconds(d time.Duration) string {
+	return strconv.FormatInt(int64(d/time.Second), 10)
+}
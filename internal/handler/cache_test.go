@@ -0,0 +1,60 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package handler_test
+
+import (
+	"net/http"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/gardener/gardener-discovery-server/internal/handler"
+)
+
+var _ = Describe("#CachePolicy", func() {
+	var header http.Header
+
+	BeforeEach(func() {
+		header = http.Header{}
+	})
+
+	It("should set the max-age", func() {
+		handler.DefaultCachePolicy.SetHeaders(header, time.Time{})
+		Expect(header).To(Equal(http.Header{"Cache-Control": {"public, max-age=3600"}}))
+	})
+
+	It("should set the stale directives", func() {
+		policy := handler.CachePolicy{MaxAge: 5 * time.Minute, StaleWhileRevalidate: time.Minute, StaleIfError: 24 * time.Hour}
+		policy.SetHeaders(header, time.Now().Add(time.Minute))
+		Expect(header).To(Equal(http.Header{"Cache-Control": {"public, max-age=300, stale-while-revalidate=60, stale-if-error=86400"}}))
+	})
+
+	It("should set Expires and cap the max-age to the expiration", func() {
+		expires := time.Now().Add(10 * time.Minute).Truncate(time.Second)
+		policy := handler.CachePolicy{MaxAge: time.Hour, Expires: true}
+		policy.SetHeaders(header, expires)
+		Expect(header.Get("Expires")).To(Equal(expires.UTC().Format(http.TimeFormat)))
+		Expect(header.Get("Cache-Control")).To(MatchRegexp(`^public, max-age=(599|600)$`))
+	})
+
+	It("should not set a negative max-age for expired data", func() {
+		policy := handler.CachePolicy{MaxAge: time.Hour, Expires: true}
+		policy.SetHeaders(header, time.Now().Add(-time.Minute))
+		Expect(header.Get("Cache-Control")).To(Equal("public, max-age=0"))
+	})
+
+	It("should not set Expires if the data expires after the max-age", func() {
+		policy := handler.CachePolicy{MaxAge: time.Hour, Expires: true}
+		policy.SetHeaders(header, time.Now().AddDate(1, 0, 0))
+		Expect(header).To(Equal(http.Header{"Cache-Control": {"public, max-age=3600"}}))
+	})
+
+	It("should keep the max-age if the data does not expire", func() {
+		policy := handler.CachePolicy{MaxAge: time.Hour, Expires: true}
+		policy.SetHeaders(header, time.Time{})
+		Expect(header).To(Equal(http.Header{"Cache-Control": {"public, max-age=3600"}}))
+	})
+})
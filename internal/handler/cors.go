@@ -0,0 +1,124 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package handler
+
+import (
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+)
+
+const (
+	headerOrigin                      = "Origin"
+	headerAccessControlRequestMethod  = "Access-Control-Request-Method"
+	headerAccessControlRequestHeaders = "Access-Control-Request-Headers"
+	headerAccessControlAllowOrigin    = "Access-Control-Allow-Origin"
+	headerAccessControlAllowMethods   = "Access-Control-Allow-Methods"
+	headerAccessControlAllowHeaders   = "Access-Control-Allow-Headers"
+	headerAccessControlExposeHeaders  = "Access-Control-Expose-Headers"
+	headerAccessControlMaxAge         = "Access-Control-Max-Age"
+
+	anyOrigin = "*"
+)
+
+// CORSPolicy configures the CORS headers of responses to cross-origin requests from browsers.
+type CORSPolicy struct {
+	// AllowedOrigins are the origins allowed to read the responses. An origin is either exact, e.g. https://portal.example.com,
+	// a wildcard for its subdomains, e.g. https://*.example.com, or * for any origin. CORS is disabled if it is empty.
+	AllowedOrigins []string
+	// ExposedHeaders are the response headers readable by the browser besides the CORS-safelisted ones, e.g. ETag.
+	ExposedHeaders []string
+	// MaxAge is the duration the browser may cache the result of a preflight request.
+	MaxAge time.Duration
+}
+
+// CORSPolicies are the CORS policies per route group.
+type CORSPolicies struct {
+	// Shoot applies to the documents published for the shoots.
+	Shoot CORSPolicy
+	// Garden applies to the documents published for the garden itself, e.g. of the workload identity issuer.
+	Garden CORSPolicy
+}
+
+func (p CORSPolicy) allowsOrigin(origin string) bool {
+	for _, allowed := range p.AllowedOrigins {
+		if allowed == anyOrigin || allowed == origin {
+			return true
+		}
+		prefix, suffix, ok := strings.Cut(allowed, "://*.")
+		if !ok {
+			continue
+		}
+		// The wildcard matches one or more subdomain labels but not the scheme, port or the domain itself.
+		sub, ok := strings.CutPrefix(origin, prefix+"://")
+		if ok && strings.HasSuffix(sub, "."+suffix) && !strings.ContainsAny(strings.TrimSuffix(sub, "."+suffix), "/:@") {
+			return true
+		}
+	}
+	return false
+}
+
+// CORS is middleware handler setting the CORS headers of the policy.
+// Preflight requests are answered for the allowed methods, all other requests are passed to the next handler.
+// Credentials are never allowed as the served documents are public.
+func CORS(next http.Handler, log logr.Logger, policy CORSPolicy, allowedMethods ...string) http.Handler {
+	if len(policy.AllowedOrigins) == 0 {
+		return next
+	}
+
+	var (
+		anyAllowed     = slices.Contains(policy.AllowedOrigins, anyOrigin)
+		exposedHeaders = strings.Join(policy.ExposedHeaders, ", ")
+		methods        = strings.Join(allowedMethods, ", ")
+	)
+	allowOrigin := func(h http.Header, origin string) {
+		if anyAllowed {
+			h.Set(headerAccessControlAllowOrigin, anyOrigin)
+			return
+		}
+		h.Set(headerAccessControlAllowOrigin, origin)
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		h := w.Header()
+		// The origin is reflected unless any origin is allowed, caches have to distinguish the responses by it.
+		if !anyAllowed {
+			h.Add(headerVary, headerOrigin)
+		}
+
+		origin := r.Header.Get(headerOrigin)
+		requestMethod := r.Header.Get(headerAccessControlRequestMethod)
+		if r.Method == http.MethodOptions && origin != "" && requestMethod != "" {
+			h.Add(headerVary, headerAccessControlRequestMethod)
+			h.Add(headerVary, headerAccessControlRequestHeaders)
+			if !policy.allowsOrigin(origin) || !slices.Contains(allowedMethods, requestMethod) {
+				WriteProblem(w, r, log, http.StatusForbidden, "cross-origin request not allowed")
+				return
+			}
+
+			allowOrigin(h, origin)
+			h.Set(headerAccessControlAllowMethods, methods)
+			if requestHeaders := r.Header.Get(headerAccessControlRequestHeaders); requestHeaders != "" {
+				h.Set(headerAccessControlAllowHeaders, requestHeaders)
+			}
+			if policy.MaxAge > 0 {
+				h.Set(headerAccessControlMaxAge, seconds(policy.MaxAge))
+			}
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		if origin != "" && policy.allowsOrigin(origin) {
+			allowOrigin(h, origin)
+			if exposedHeaders != "" {
+				h.Set(headerAccessControlExposeHeaders, exposedHeaders)
+			}
+		}
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,131 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package handler_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	logzap "sigs.k8s.io/controller-runtime/pkg/log/zap"
+
+	"github.com/gardener/gardener-discovery-server/internal/handler"
+)
+
+var _ = Describe("#CORS", func() {
+	var (
+		policy handler.CORSPolicy
+		next   = http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.Header().Set("ETag", `"foo"`)
+			_, _ = w.Write([]byte(`{}`))
+		})
+
+		serve = func(method, origin string, header ...string) *httptest.ResponseRecorder {
+			req := httptest.NewRequest(method, "/foo", nil)
+			if origin != "" {
+				req.Header.Set("Origin", origin)
+			}
+			for i := 0; i+1 < len(header); i += 2 {
+				req.Header.Set(header[i], header[i+1])
+			}
+			recorder := httptest.NewRecorder()
+			h := handler.CORS(next, logzap.New(logzap.WriteTo(GinkgoWriter)), policy, http.MethodGet, http.MethodHead)
+			h.ServeHTTP(recorder, req)
+			return recorder
+		}
+	)
+
+	BeforeEach(func() {
+		policy = handler.CORSPolicy{
+			AllowedOrigins: []string{"https://portal.example.com", "https://*.example.org"},
+			ExposedHeaders: []string{"ETag", "JWS-Signature"},
+			MaxAge:         time.Hour,
+		}
+	})
+
+	It("should not set any headers if no origin is allowed", func() {
+		policy = handler.CORSPolicy{}
+		recorder := serve(http.MethodGet, "https://portal.example.com")
+		Expect(recorder.Code).To(Equal(http.StatusOK))
+		Expect(recorder.Header()).To(Equal(http.Header{"Etag": {`"foo"`}, "Content-Type": {"text/plain; charset=utf-8"}}))
+	})
+
+	It("should reflect an exact origin and expose the headers", func() {
+		recorder := serve(http.MethodGet, "https://portal.example.com")
+		Expect(recorder.Code).To(Equal(http.StatusOK))
+		Expect(recorder.Header().Get("Access-Control-Allow-Origin")).To(Equal("https://portal.example.com"))
+		Expect(recorder.Header().Get("Access-Control-Expose-Headers")).To(Equal("ETag, JWS-Signature"))
+		Expect(recorder.Header().Values("Vary")).To(ConsistOf("Origin"))
+		Expect(recorder.Header()).ToNot(HaveKey("Access-Control-Allow-Credentials"))
+	})
+
+	DescribeTable("should match the wildcard origins",
+		func(origin string, allowed bool) {
+			recorder := serve(http.MethodGet, origin)
+			Expect(recorder.Code).To(Equal(http.StatusOK))
+			if allowed {
+				Expect(recorder.Header().Get("Access-Control-Allow-Origin")).To(Equal(origin))
+			} else {
+				Expect(recorder.Header()).ToNot(HaveKey("Access-Control-Allow-Origin"))
+			}
+		},
+		Entry("subdomain", "https://foo.example.org", true),
+		Entry("nested subdomain", "https://foo.bar.example.org", true),
+		Entry("domain itself", "https://example.org", false),
+		Entry("other scheme", "http://foo.example.org", false),
+		Entry("port", "https://foo.example.org:8443", false),
+		Entry("suffix of another domain", "https://fooexample.org", false),
+		Entry("other origin", "https://evil.example.com", false),
+	)
+
+	It("should not set the headers for requests without origin", func() {
+		recorder := serve(http.MethodGet, "")
+		Expect(recorder.Code).To(Equal(http.StatusOK))
+		Expect(recorder.Header()).ToNot(HaveKey("Access-Control-Allow-Origin"))
+		Expect(recorder.Header().Values("Vary")).To(ConsistOf("Origin"))
+	})
+
+	It("should allow any origin without varying by it", func() {
+		policy.AllowedOrigins = []string{"*"}
+		recorder := serve(http.MethodGet, "https://any.example.net")
+		Expect(recorder.Header().Get("Access-Control-Allow-Origin")).To(Equal("*"))
+		Expect(recorder.Header()).ToNot(HaveKey("Vary"))
+	})
+
+	It("should answer preflight requests", func() {
+		recorder := serve(http.MethodOptions, "https://portal.example.com",
+			"Access-Control-Request-Method", http.MethodGet,
+			"Access-Control-Request-Headers", "if-none-match",
+		)
+		Expect(recorder.Code).To(Equal(http.StatusNoContent))
+		Expect(recorder.Body.String()).To(BeEmpty())
+		Expect(recorder.Header().Get("Access-Control-Allow-Origin")).To(Equal("https://portal.example.com"))
+		Expect(recorder.Header().Get("Access-Control-Allow-Methods")).To(Equal("GET, HEAD"))
+		Expect(recorder.Header().Get("Access-Control-Allow-Headers")).To(Equal("if-none-match"))
+		Expect(recorder.Header().Get("Access-Control-Max-Age")).To(Equal("3600"))
+		Expect(recorder.Header().Values("Vary")).To(ConsistOf("Origin", "Access-Control-Request-Method", "Access-Control-Request-Headers"))
+	})
+
+	It("should reject preflight requests from other origins", func() {
+		recorder := serve(http.MethodOptions, "https://evil.example.com", "Access-Control-Request-Method", http.MethodGet)
+		Expect(recorder.Code).To(Equal(http.StatusForbidden))
+		Expect(recorder.Header().Get("Cache-Control")).To(Equal("no-store"))
+		Expect(recorder.Header()).ToNot(HaveKey("Access-Control-Allow-Origin"))
+	})
+
+	It("should reject preflight requests for other methods", func() {
+		recorder := serve(http.MethodOptions, "https://portal.example.com", "Access-Control-Request-Method", http.MethodPost)
+		Expect(recorder.Code).To(Equal(http.StatusForbidden))
+		Expect(recorder.Header()).ToNot(HaveKey("Access-Control-Allow-Methods"))
+	})
+
+	It("should pass OPTIONS requests which are not preflight requests", func() {
+		recorder := serve(http.MethodOptions, "https://portal.example.com")
+		Expect(recorder.Code).To(Equal(http.StatusOK))
+		Expect(recorder.Body.String()).To(Equal(`{}`))
+	})
+})
@@ -0,0 +1,326 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package federation
+
+import (
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+
+	"github.com/gardener/gardener-discovery-server/internal/dynamiccert"
+	"github.com/gardener/gardener-discovery-server/internal/handler"
+	"github.com/gardener/gardener-discovery-server/internal/precompress"
+	"github.com/gardener/gardener-discovery-server/internal/signing"
+	"github.com/gardener/gardener-discovery-server/internal/store"
+	"github.com/gardener/gardener-discovery-server/internal/store/openidmeta"
+	"github.com/gardener/gardener-discovery-server/internal/utils"
+)
+
+// Placeholders of the subjects in the Azure federated credential,
+// they have to be replaced with the service account or workload identity tokens are issued for.
+const (
+	ShootSubject  = "system:serviceaccount:<namespace>:<name>"
+	GardenSubject = "gardener.cloud:workloadidentity:<namespace>:<name>:<uid>"
+)
+
+// Audiences expected by the cloud providers.
+const (
+	AudienceAWS   = "sts.amazonaws.com"
+	AudienceAzure = "api://AzureADTokenExchange"
+)
+
+// Document contains the information needed to federate an issuer with cloud providers.
+type Document struct {
+	Issuer string `json:"issuer"`
+	// Certificates is the serving certificate chain of the issuer host, starting with the leaf.
+	Certificates []Certificate `json:"certificates"`
+	AWS          AWS           `json:"aws"`
+	GCP          GCP           `json:"gcp"`
+	Azure        Azure         `json:"azure"`
+}
+
+// Certificate describes a certificate of the serving certificate chain.
+type Certificate struct {
+	Subject  string    `json:"subject"`
+	Issuer   string    `json:"issuer"`
+	NotAfter time.Time `json:"notAfter"`
+	SHA1     string    `json:"sha1"`
+	SHA256   string    `json:"sha256"`
+}
+
+// AWS is the input of `aws iam create-open-id-connect-provider --cli-input-json`.
+type AWS struct {
+	URL            string   `json:"Url"`
+	ClientIDList   []string `json:"ClientIDList"`
+	ThumbprintList []string `json:"ThumbprintList"`
+}
+
+// GCP is the request body of a workload identity pool OIDC provider.
+type GCP struct {
+	OIDC             GCPOIDC           `json:"oidc"`
+	AttributeMapping map[string]string `json:"attributeMapping"`
+}
+
+// GCPOIDC is the OIDC configuration of a workload identity pool provider.
+type GCPOIDC struct {
+	IssuerURI string `json:"issuerUri"`
+}
+
+// Azure is the request body of a federated identity credential.
+type Azure struct {
+	Issuer    string   `json:"issuer"`
+	Subject   string   `json:"subject"`
+	Audiences []string `json:"audiences"`
+}
+
+// CertificateMatcher selects the serving certificate of a host without recording a TLS handshake,
+// it is implemented by [dynamiccert.SNICertificate].
+type CertificateMatcher interface {
+	Match(serverName string) *dynamiccert.DynamicCertificate
+}
+
+// Source is the store of the shoot issuers.
+type Source interface {
+	store.Reader[openidmeta.Data]
+	Metadata(key string) (store.Metadata, bool)
+	OnUpdate(fn func(key string))
+}
+
+// Handler serves the federation helper documents of the shoot issuers and the garden workload identity issuer.
+// The documents are built and signed once and kept until the issuer, its serving certificate or the signing key changes.
+type Handler struct {
+	store    Source
+	certs    CertificateMatcher
+	signer   signing.Signer
+	policies handler.CachePolicies
+	log      logr.Logger
+
+	lock      sync.Mutex
+	documents map[string]cachedDocument
+}
+
+// New constructs a new [Handler]. The thumbprints are taken from the chain of the certificate
+// matched by certs for the host of the issuer, they are computed once the certificate is loaded.
+// The store may be nil if only the workload identity document is served and the signer may be nil,
+// then the documents are not signed.
+func New(store Source, certs CertificateMatcher, signer signing.Signer, policies handler.CachePolicies, log logr.Logger) *Handler {
+	h := &Handler{
+		store:     store,
+		certs:     certs,
+		signer:    signer,
+		policies:  policies,
+		log:       log,
+		documents: map[string]cachedDocument{},
+	}
+	if store != nil {
+		store.OnUpdate(h.forget)
+	}
+	return h
+}
+
+// HandleShoot handles /issuer/federation of shoots.
+// It requires "projectName" and "shootUID" as path parameters.
+func (h *Handler) HandleShoot() http.Handler {
+	log := h.log.WithName("shoot")
+	reader := &documentReader{handler: h, log: log}
+	return handler.SetHSTS(
+		handler.AllowMethods(handler.StoreRequest(log, reader, h.policies.OpenIDConfiguration,
+			func(d document) handler.Content {
+				return handler.Content{Body: d.raw, Variants: d.variants, Signature: d.signature}
+			},
+		),
+			log, http.MethodGet, http.MethodHead,
+		),
+	)
+}
+
+// workloadIdentityKey is the key of the workload identity document, it is never a key of a shoot issuer.
+const workloadIdentityKey = ""
+
+// HandleWorkloadIdentity handles /issuer/federation of the garden workload identity issuer.
+func (h *Handler) HandleWorkloadIdentity(openIDConfig []byte) (http.Handler, error) {
+	conf, err := utils.LoadOpenIDConfig(openIDConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load openid configuration: %w", err)
+	}
+
+	log := h.log.WithName("workload-identity")
+	return handler.SetHSTS(
+		handler.AllowMethods(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			d, ok := h.cached(workloadIdentityKey, "")
+			if !ok {
+				if d, err = h.build(workloadIdentityKey, "", conf.Issuer, GardenSubject); err != nil {
+					handler.RequestLog(log, r).Error(err, "Failed building federation document")
+					handler.WriteProblem(w, r, log, http.StatusInternalServerError, "failed building federation document")
+					return
+				}
+			}
+			h.policies.OpenIDConfiguration.SetHeaders(w.Header(), d.expires)
+			w.Header().Set("Content-Type", "application/json")
+			if d.signature != "" {
+				w.Header().Set(handler.HeaderJWSSignature, d.signature)
+			}
+			if err := handler.WriteBody(w, r, d.raw, d.variants); err != nil {
+				handler.RequestLog(log, r).Error(err, "Failed writing response")
+			}
+		}),
+			log, http.MethodGet, http.MethodHead,
+		),
+	), nil
+}
+
+// document is an encoded and signed [Document] which implements [handler.Expirer].
+type document struct {
+	raw       []byte
+	variants  precompress.Variants
+	signature string
+	expires   time.Time
+}
+
+func (d document) Expiration() time.Time {
+	return d.expires
+}
+
+// cachedDocument is a built document together with what it was built from.
+type cachedDocument struct {
+	document
+	// hash is the hash of the store entry of the issuer.
+	hash string
+	host string
+	// leaf is the parsed leaf certificate the document was built from, it is replaced when the certificate is reloaded.
+	leaf  *x509.Certificate
+	keyID string
+}
+
+// cached returns the document built for the key unless the store entry, the serving certificate or the signing key changed since.
+func (h *Handler) cached(key, hash string) (document, bool) {
+	h.lock.Lock()
+	c, ok := h.documents[key]
+	h.lock.Unlock()
+	if !ok || c.hash != hash || c.keyID != keyID(h.signer) {
+		return document{}, false
+	}
+	chain := h.certs.Match(c.host).Chain()
+	if len(chain) == 0 || chain[0].Certificate != c.leaf {
+		return document{}, false
+	}
+	return c.document, true
+}
+
+// forget drops the document built for the key, it is called whenever the store entry is written or removed.
+func (h *Handler) forget(key string) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	delete(h.documents, key)
+}
+
+// documentReader builds the documents of the shoot issuers from the stored openid configurations.
+type documentReader struct {
+	handler *Handler
+	log     logr.Logger
+}
+
+var _ store.Reader[document] = (*documentReader)(nil)
+
+func (r *documentReader) Read(key string) (document, bool) {
+	meta, ok := r.handler.store.Metadata(key)
+	if !ok {
+		return document{}, false
+	}
+	if d, ok := r.handler.cached(key, meta.Hash); ok {
+		return d, true
+	}
+	data, ok := r.handler.store.Read(key)
+	if !ok {
+		return document{}, false
+	}
+	conf, err := utils.LoadOpenIDConfig(data.Config)
+	if err != nil {
+		r.log.Error(err, "Failed loading openid configuration", "key", key)
+		return document{}, false
+	}
+	d, err := r.handler.build(key, meta.Hash, conf.Issuer, ShootSubject)
+	if err != nil {
+		r.log.Error(err, "Failed building federation document", "key", key)
+		return document{}, false
+	}
+	return d, true
+}
+
+// build returns the signed document of the issuer and caches it for the key,
+// it expires together with the serving certificate of the issuer host.
+func (h *Handler) build(key, hash, issuer, subject string) (document, error) {
+	issuerURL, err := url.Parse(issuer)
+	if err != nil {
+		return document{}, fmt.Errorf("failed to parse issuer url: %w", err)
+	}
+	host := issuerURL.Hostname()
+	chain := h.certs.Match(host).Chain()
+	if len(chain) == 0 {
+		return document{}, errors.New("no serving certificate")
+	}
+
+	certificates := make([]Certificate, 0, len(chain))
+	for _, cert := range chain {
+		certificates = append(certificates, Certificate{
+			Subject:  cert.Subject.String(),
+			Issuer:   cert.Issuer.String(),
+			NotAfter: cert.NotAfter,
+			SHA1:     cert.SHA1,
+			SHA256:   cert.SHA256,
+		})
+	}
+
+	raw, err := json.Marshal(Document{
+		Issuer:       issuer,
+		Certificates: certificates,
+		AWS: AWS{
+			URL:          issuer,
+			ClientIDList: []string{AudienceAWS},
+			// AWS expects the thumbprint of the top intermediate CA of the served chain.
+			ThumbprintList: []string{certificates[len(certificates)-1].SHA1},
+		},
+		GCP: GCP{
+			OIDC:             GCPOIDC{IssuerURI: issuer},
+			AttributeMapping: map[string]string{"google.subject": "assertion.sub"},
+		},
+		Azure: Azure{
+			Issuer:    issuer,
+			Subject:   subject,
+			Audiences: []string{AudienceAzure},
+		},
+	})
+	if err != nil {
+		return document{}, err
+	}
+
+	// The key ID is taken before signing, so that a document signed during a key switch is signed again on the next read.
+	signedWith := keyID(h.signer)
+	signature, err := signing.Sign(h.signer, raw)
+	if err != nil {
+		return document{}, fmt.Errorf("failed to sign federation document: %w", err)
+	}
+
+	d := document{raw: raw, variants: precompress.Compress(raw), signature: signature, expires: chain[0].NotAfter}
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	h.documents[key] = cachedDocument{document: d, hash: hash, host: host, leaf: chain[0].Certificate, keyID: signedWith}
+	return d, nil
+}
+
+// keyID returns the ID of the key the signer signs with, it is empty if signing is disabled.
+func keyID(signer signing.Signer) string {
+	if signer == nil {
+		return ""
+	}
+	return signer.KeyID()
+}
@@ -0,0 +1,212 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package federation_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha1" // #nosec G505 -- test only
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	logzap "sigs.k8s.io/controller-runtime/pkg/log/zap"
+
+	"github.com/gardener/gardener-discovery-server/internal/dynamiccert"
+	"github.com/gardener/gardener-discovery-server/internal/handler"
+	"github.com/gardener/gardener-discovery-server/internal/handler/federation"
+	"github.com/gardener/gardener-discovery-server/internal/store"
+	oidstore "github.com/gardener/gardener-discovery-server/internal/store/openidmeta"
+)
+
+var _ = Describe("#Handler", func() {
+	const (
+		shootIssuer  = "https://discovery.example.com/projects/foo/shoots/a6475c90-d533-43c4-bbb0-d99200b491b1/issuer"
+		gardenIssuer = "https://discovery.example.com/garden/workload-identity/issuer"
+	)
+
+	var (
+		s            *store.Store[oidstore.Data]
+		mux          *http.ServeMux
+		chain        [][]byte
+		leafNotAfter time.Time
+		certs        *recordingMatcher
+		signer       *countingSigner
+
+		get = func(uri string) (*httptest.ResponseRecorder, federation.Document) {
+			recorder := httptest.NewRecorder()
+			mux.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, uri, nil))
+			var doc federation.Document
+			if recorder.Code == http.StatusOK {
+				Expect(json.Unmarshal(recorder.Body.Bytes(), &doc)).To(Succeed())
+			}
+			return recorder, doc
+		}
+		thumbprints = func(der []byte) (string, string) {
+			sha1Sum := sha1.Sum(der) // #nosec G401 -- test only
+			sha256Sum := sha256.Sum256(der)
+			return hex.EncodeToString(sha1Sum[:]), hex.EncodeToString(sha256Sum[:])
+		}
+	)
+
+	BeforeEach(func() {
+		caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		Expect(err).ToNot(HaveOccurred())
+		caTemplate := &x509.Certificate{
+			SerialNumber:          big.NewInt(1),
+			Subject:               pkix.Name{CommonName: "intermediate"},
+			NotBefore:             time.Now().Add(-time.Hour),
+			NotAfter:              time.Now().Add(48 * time.Hour),
+			IsCA:                  true,
+			BasicConstraintsValid: true,
+			KeyUsage:              x509.KeyUsageCertSign,
+		}
+		caDER, err := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate, caKey.Public(), caKey)
+		Expect(err).ToNot(HaveOccurred())
+
+		leafKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		Expect(err).ToNot(HaveOccurred())
+		leafNotAfter = time.Now().Add(24 * time.Hour).Truncate(time.Second)
+		leafTemplate := &x509.Certificate{
+			SerialNumber: big.NewInt(2),
+			Subject:      pkix.Name{CommonName: "discovery.example.com"},
+			DNSNames:     []string{"discovery.example.com"},
+			NotBefore:    time.Now().Add(-time.Hour),
+			NotAfter:     leafNotAfter,
+		}
+		leafDER, err := x509.CreateCertificate(rand.Reader, leafTemplate, caTemplate, leafKey.Public(), caKey)
+		Expect(err).ToNot(HaveOccurred())
+		chain = [][]byte{leafDER, caDER}
+
+		dir := GinkgoT().TempDir()
+		certPath, keyPath := filepath.Join(dir, "tls.crt"), filepath.Join(dir, "tls.key")
+		var certPEM []byte
+		for _, der := range chain {
+			certPEM = append(certPEM, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})...)
+		}
+		Expect(os.WriteFile(certPath, certPEM, 0600)).To(Succeed())
+		keyDER, err := x509.MarshalECPrivateKey(leafKey)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(os.WriteFile(keyPath, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0600)).To(Succeed())
+
+		dc, err := dynamiccert.New(certPath, keyPath, dynamiccert.WithRefreshInterval(time.Hour))
+		Expect(err).ToNot(HaveOccurred())
+		certs = &recordingMatcher{SNICertificate: dynamiccert.NewSNI(dc)}
+		signer = &countingSigner{keyID: "a"}
+
+		s = store.MustNewStore(oidstore.Copy)
+		s.Write("foo--a6475c90-d533-43c4-bbb0-d99200b491b1", oidstore.Data{
+			Config: []byte(`{"issuer":"` + shootIssuer + `","jwks_uri":"` + shootIssuer + `/jwks"}`),
+		})
+	})
+
+	JustBeforeEach(func() {
+		log := logzap.New(logzap.WriteTo(GinkgoWriter))
+		h := federation.New(s, certs, signer, handler.DefaultCachePolicies, log)
+		workloadIdentity, err := h.HandleWorkloadIdentity([]byte(`{"issuer":"` + gardenIssuer + `","jwks_uri":"` + gardenIssuer + `/jwks"}`))
+		Expect(err).ToNot(HaveOccurred())
+
+		mux = http.NewServeMux()
+		mux.Handle("/projects/{projectName}/shoots/{shootUID}/issuer/federation", h.HandleShoot())
+		mux.Handle("/garden/workload-identity/issuer/federation", workloadIdentity)
+	})
+
+	It("should return the thumbprints and provider configurations of a shoot issuer", func() {
+		recorder, doc := get("/projects/foo/shoots/a6475c90-d533-43c4-bbb0-d99200b491b1/issuer/federation")
+		Expect(recorder.Code).To(Equal(http.StatusOK))
+		Expect(recorder.Header().Get("Cache-Control")).To(Equal("public, max-age=3600"))
+		Expect(certs.serverName).To(Equal("discovery.example.com"))
+		Expect(recorder.Header().Get("JWS-Signature")).To(Equal("a:" + recorder.Body.String()))
+
+		leafSHA1, leafSHA256 := thumbprints(chain[0])
+		caSHA1, caSHA256 := thumbprints(chain[1])
+		Expect(doc.Issuer).To(Equal(shootIssuer))
+		Expect(doc.Certificates).To(HaveLen(2))
+		Expect(doc.Certificates[0].Subject).To(Equal("CN=discovery.example.com"))
+		Expect(doc.Certificates[0].Issuer).To(Equal("CN=intermediate"))
+		Expect(doc.Certificates[0].NotAfter).To(BeTemporally("==", leafNotAfter))
+		Expect(doc.Certificates[0].SHA1).To(Equal(leafSHA1))
+		Expect(doc.Certificates[0].SHA256).To(Equal(leafSHA256))
+		Expect(doc.Certificates[1].SHA1).To(Equal(caSHA1))
+		Expect(doc.Certificates[1].SHA256).To(Equal(caSHA256))
+
+		Expect(doc.AWS).To(Equal(federation.AWS{URL: shootIssuer, ClientIDList: []string{"sts.amazonaws.com"}, ThumbprintList: []string{caSHA1}}))
+		Expect(doc.GCP.OIDC.IssuerURI).To(Equal(shootIssuer))
+		Expect(doc.Azure).To(Equal(federation.Azure{Issuer: shootIssuer, Subject: federation.ShootSubject, Audiences: []string{"api://AzureADTokenExchange"}}))
+	})
+
+	It("should return the document of the garden workload identity issuer", func() {
+		recorder, doc := get("/garden/workload-identity/issuer/federation")
+		Expect(recorder.Code).To(Equal(http.StatusOK))
+		Expect(recorder.Header().Get("Content-Type")).To(Equal("application/json"))
+		Expect(doc.Issuer).To(Equal(gardenIssuer))
+		Expect(doc.Azure.Subject).To(Equal(federation.GardenSubject))
+		Expect(doc.Certificates).To(HaveLen(2))
+		Expect(recorder.Header().Get("JWS-Signature")).To(Equal("a:" + recorder.Body.String()))
+	})
+
+	It("should only build and sign the documents again if the issuer or the signing key changed", func() {
+		const path = "/projects/foo/shoots/a6475c90-d533-43c4-bbb0-d99200b491b1/issuer/federation"
+		get(path)
+		get(path)
+		get("/garden/workload-identity/issuer/federation")
+		get("/garden/workload-identity/issuer/federation")
+		Expect(signer.signed).To(Equal(2))
+
+		signer.keyID = "b"
+		recorder, _ := get(path)
+		Expect(recorder.Header().Get("JWS-Signature")).To(Equal("b:" + recorder.Body.String()))
+		Expect(signer.signed).To(Equal(3))
+
+		const otherIssuer = "https://discovery.example.com/projects/foo/shoots/a6475c90-d533-43c4-bbb0-d99200b491b1/other"
+		s.Write("foo--a6475c90-d533-43c4-bbb0-d99200b491b1", oidstore.Data{
+			Config: []byte(`{"issuer":"` + otherIssuer + `","jwks_uri":"` + otherIssuer + `/jwks"}`),
+		})
+		_, doc := get(path)
+		Expect(doc.Issuer).To(Equal(otherIssuer))
+		Expect(signer.signed).To(Equal(4))
+	})
+
+	It("should return not found for unknown shoots", func() {
+		recorder, _ := get("/projects/foo/shoots/1e4914ca-c837-451d-a1cf-c559d131cb57/issuer/federation")
+		Expect(recorder.Code).To(Equal(http.StatusNotFound))
+	})
+})
+
+// countingSigner signs payloads by prefixing them with the key ID and counts the signatures.
+type countingSigner struct {
+	keyID  string
+	signed int
+}
+
+func (c *countingSigner) Sign(payload []byte) (string, error) {
+	c.signed++
+	return c.keyID + ":" + string(payload), nil
+}
+
+func (c *countingSigner) KeyID() string { return c.keyID }
+
+// recordingMatcher records the server name the certificate was matched for.
+type recordingMatcher struct {
+	*dynamiccert.SNICertificate
+	serverName string
+}
+
+func (m *recordingMatcher) Match(serverName string) *dynamiccert.DynamicCertificate {
+	m.serverName = serverName
+	return m.SNICertificate.Match(serverName)
+}
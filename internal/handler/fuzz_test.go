@@ -0,0 +1,58 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package handler_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-logr/logr"
+
+	"github.com/gardener/gardener-discovery-server/internal/handler"
+	"github.com/gardener/gardener-discovery-server/internal/precompress"
+	"github.com/gardener/gardener-discovery-server/internal/store"
+)
+
+func FuzzStoreRequest(f *testing.F) {
+	const (
+		projectName = "test"
+		shootUID    = "a6475c90-d533-43c4-bbb0-d99200b491b1"
+	)
+
+	s := store.MustNewStore(func(s string) string { return s })
+	s.Write(projectName+"--"+shootUID, "entry")
+	h := handler.StoreRequest(logr.Discard(), s, handler.DefaultCachePolicy, func(data string) handler.Content {
+		return handler.Content{Body: []byte(data), Variants: precompress.Compress([]byte(data))}
+	})
+
+	f.Add(projectName, shootUID)
+	f.Add(projectName, "A6475C90-D533-43C4-BBB0-D99200B491B1")
+	f.Add(projectName, "{a6475c90-d533-43c4-bbb0-d99200b491b1}")
+	f.Add(projectName, "urn:uuid:a6475c90-d533-43c4-bbb0-d99200b491b1")
+	f.Add(projectName, "a6475c90d53343c4bbb0d99200b491b1")
+	f.Add("test--"+shootUID, shootUID)
+	f.Add("", "")
+	f.Add("../..", "%2e%2e")
+
+	f.Fuzz(func(t *testing.T, project, uid string) {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.SetPathValue("projectName", project)
+		req.SetPathValue("shootUID", uid)
+		resp := httptest.NewRecorder()
+
+		h.ServeHTTP(resp, req)
+
+		switch resp.Code {
+		case http.StatusOK:
+			if project != projectName || uid != shootUID {
+				t.Fatalf("served entry for project %q and shoot UID %q", project, uid)
+			}
+		case http.StatusBadRequest, http.StatusNotFound:
+		default:
+			t.Fatalf("unexpected status code %d for project %q and shoot UID %q", resp.Code, project, uid)
+		}
+	})
+}
@@ -6,24 +6,40 @@ package handler
 
 import (
 	"net/http"
+	"strconv"
+	"strings"
 	"sync"
+	"time"
 
 	"github.com/go-logr/logr"
-	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/trace"
+	"k8s.io/apimachinery/pkg/util/validation"
 
+	"github.com/gardener/gardener-discovery-server/internal/precompress"
 	"github.com/gardener/gardener-discovery-server/internal/store"
+	"github.com/gardener/gardener-discovery-server/internal/tracing"
+	"github.com/gardener/gardener-discovery-server/internal/utils"
 )
 
+// HeaderJWSSignature is the response header carrying the detached JWS over the uncompressed response body.
+const HeaderJWSSignature = "JWS-Signature"
+
 const (
 	headerCacheControl = "Cache-Control"
-	pubCacheControl    = "public, max-age=3600"
+	headerExpires      = "Expires"
 
-	headerContentType = "Content-Type"
-	mimeAppJSON       = "application/json"
+	headerContentType     = "Content-Type"
+	headerContentEncoding = "Content-Encoding"
+	headerContentLength   = "Content-Length"
+	headerAcceptEncoding  = "Accept-Encoding"
+	headerVary            = "Vary"
+	mimeAppJSON           = "application/json"
 )
 
-var (
-	responseInvalidUID = []byte(`{"code":400,"message":"invalid UID"}`)
+const (
+	detailInvalidUID         = "invalid UID"
+	detailInvalidShootName   = "invalid shoot name"
+	detailInvalidProjectName = "invalid project name"
 )
 
 // SetHSTS is middleware handler setting Strict-Transport-Security header.
@@ -38,21 +54,13 @@ func SetHSTS(next http.Handler) http.Handler {
 
 // AllowMethods is middleware handler restricting the allowed http methods.
 func AllowMethods(next http.Handler, log logr.Logger, allowedMethods ...string) http.Handler {
-	var (
-		responseMethodNotAllowed = []byte(`{"code":405,"message":"method not allowed"}`)
-		methods                  = sync.Map{}
-	)
+	methods := sync.Map{}
 	for _, m := range allowedMethods {
 		methods.Store(m, nil)
 	}
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if _, ok := methods.Load(r.Method); !ok {
-			w.Header().Set(headerContentType, mimeAppJSON)
-			w.WriteHeader(http.StatusMethodNotAllowed)
-			if _, err := w.Write(responseMethodNotAllowed); err != nil {
-				log.Error(err, "Failed writing response")
-				return
-			}
+			WriteProblem(w, r, log, http.StatusMethodNotAllowed, "method not allowed")
 			return
 		}
 		next.ServeHTTP(w, r)
@@ -61,47 +69,133 @@ func AllowMethods(next http.Handler, log logr.Logger, allowedMethods ...string)
 
 // NotFound is handler replying with not found.
 func NotFound(log logr.Logger) http.Handler {
-	var responseNotFound = []byte(`{"code":404,"message":"not found"}`)
-
-	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
-		w.Header().Set(headerContentType, mimeAppJSON)
-		w.WriteHeader(http.StatusNotFound)
-		if _, err := w.Write(responseNotFound); err != nil {
-			log.Error(err, "Failed writing not found response")
-			return
-		}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		WriteProblem(w, r, log, http.StatusNotFound, "not found")
 	})
 }
 
+// Content is the body of a response read from [Store].
+type Content struct {
+	Body []byte
+	// Variants are the precompressed variants of Body.
+	Variants precompress.Variants
+	// Signature is the detached JWS over Body, it is not sent if empty.
+	Signature string
+	// ContentType is the media type of Body, it defaults to application/json.
+	ContentType string
+}
+
 // StoreRequest handles requests that read data from [Store].
 // It requires "projectName" and "shootUID" as path parameters.
 // The data is read from the store and the content is extracted using the getContent function.
-// The returned result from getContent should be in JSON format.
-func StoreRequest[T any](log logr.Logger, s store.Reader[T], getContent func(T) []byte) http.Handler {
+// The body of the returned content should be in JSON format unless the content states its type.
+// The caching headers are set according to the policy.
+func StoreRequest[T any](log logr.Logger, s store.Reader[T], policy CachePolicy, getContent func(T) Content) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		shootUID := r.PathValue("shootUID")
-		if _, err := uuid.Parse(shootUID); err != nil {
-			w.Header().Set(headerContentType, mimeAppJSON)
-			w.WriteHeader(http.StatusBadRequest)
-			if _, err := w.Write(responseInvalidUID); err != nil {
-				log.Error(err, "Failed writing bad request response")
-				return
-			}
+		var (
+			projectName = r.PathValue("projectName")
+			shootUID    = r.PathValue("shootUID")
+		)
+		trace.SpanFromContext(r.Context()).SetAttributes(
+			tracing.AttributeProjectName.String(projectName),
+			tracing.AttributeShootUID.String(shootUID),
+		)
+
+		if !utils.IsProjectName(projectName) {
+			WriteProblem(w, r, log, http.StatusBadRequest, detailInvalidProjectName)
+			return
+		}
+
+		if !utils.IsShootUID(shootUID) {
+			WriteProblem(w, r, log, http.StatusBadRequest, detailInvalidUID)
 			return
 		}
 
-		projectName := r.PathValue("projectName")
 		data, ok := s.Read(projectName + "--" + shootUID)
 		if !ok {
 			NotFound(log).ServeHTTP(w, r)
 			return
 		}
 
-		w.Header().Set(headerCacheControl, pubCacheControl)
-		w.Header().Set(headerContentType, mimeAppJSON)
-		if _, err := w.Write(getContent(data)); err != nil {
-			log.Error(err, "Failed writing response")
+		var expires time.Time
+		if e, ok := any(data).(Expirer); ok {
+			expires = e.Expiration()
+		}
+		policy.SetHeaders(w.Header(), expires)
+		content := getContent(data)
+		if content.ContentType != "" {
+			w.Header().Set(headerContentType, content.ContentType)
+		} else {
+			w.Header().Set(headerContentType, mimeAppJSON)
+		}
+		if content.Signature != "" {
+			w.Header().Set(HeaderJWSSignature, content.Signature)
+		}
+		if err := WriteBody(w, r, content.Body, content.Variants); err != nil {
+			RequestLog(log, r).Error(err, "Failed writing response")
 			return
 		}
 	})
 }
+
+// WriteBody writes the body in the precompressed variant preferred by the client.
+// The response varies by Accept-Encoding and carries the length of the written variant.
+func WriteBody(w http.ResponseWriter, r *http.Request, body []byte, variants precompress.Variants) error {
+	encoding, content := variants.Select(r.Header.Get(headerAcceptEncoding), body)
+	w.Header().Add(headerVary, headerAcceptEncoding)
+	if encoding != precompress.EncodingIdentity {
+		w.Header().Set(headerContentEncoding, encoding)
+	}
+	w.Header().Set(headerContentLength, strconv.Itoa(len(content)))
+	_, err := w.Write(content)
+	return err
+}
+
+// AliasRequest handles requests addressing a shoot by name instead of UID.
+// It requires "projectName" and "shootName" as path parameters and resolves the alias projectName--shootName.
+// If redirect is set, the client is redirected to the canonical path with the shoot UID,
+// otherwise the request is served by the canonical handler with the "shootUID" path value set.
+func AliasRequest(log logr.Logger, resolver store.Resolver, redirect bool, canonical http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var (
+			projectName = r.PathValue("projectName")
+			shootName   = r.PathValue("shootName")
+		)
+		trace.SpanFromContext(r.Context()).SetAttributes(
+			tracing.AttributeProjectName.String(projectName),
+		)
+
+		if !utils.IsProjectName(projectName) {
+			WriteProblem(w, r, log, http.StatusBadRequest, detailInvalidProjectName)
+			return
+		}
+
+		if len(validation.IsDNS1123Label(shootName)) > 0 {
+			WriteProblem(w, r, log, http.StatusBadRequest, detailInvalidShootName)
+			return
+		}
+
+		key, ok := resolver.Resolve(projectName + "--" + shootName)
+		if !ok {
+			NotFound(log).ServeHTTP(w, r)
+			return
+		}
+		_, shootUID, err := utils.SplitProjectNameAndShootUID(key)
+		if err != nil {
+			RequestLog(log, r).Error(err, "Resolved invalid key", "key", key)
+			NotFound(log).ServeHTTP(w, r)
+			return
+		}
+
+		if redirect {
+			location := strings.Replace(r.URL.EscapedPath(), "/shoots/by-name/"+shootName+"/", "/shoots/"+shootUID+"/", 1)
+			w.Header().Set(headerCacheControl, noCacheControl)
+			http.Redirect(w, r, location, http.StatusTemporaryRedirect)
+			return
+		}
+
+		r = r.Clone(r.Context())
+		r.SetPathValue("shootUID", shootUID)
+		canonical.ServeHTTP(w, r)
+	})
+}
@@ -5,8 +5,12 @@
 package handler_test
 
 import (
+	"compress/gzip"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
+	"strings"
 
 	"github.com/go-logr/logr"
 	"github.com/google/uuid"
@@ -15,6 +19,7 @@ import (
 	logzap "sigs.k8s.io/controller-runtime/pkg/log/zap"
 
 	"github.com/gardener/gardener-discovery-server/internal/handler"
+	"github.com/gardener/gardener-discovery-server/internal/precompress"
 	"github.com/gardener/gardener-discovery-server/internal/store"
 )
 
@@ -24,6 +29,11 @@ var _ = Describe("#Handler", func() {
 		log         logr.Logger
 	)
 
+	problem := func(status int, detail, instance string) string {
+		return `{"type":"about:blank","title":"` + http.StatusText(status) + `","status":` + strconv.Itoa(status) +
+			`,"detail":"` + detail + `","instance":"` + instance + `","code":` + strconv.Itoa(status) + `,"message":"` + detail + `"}`
+	}
+
 	BeforeEach(func() {
 		noOpHandler = http.HandlerFunc(func(_ http.ResponseWriter, _ *http.Request) {})
 		log = logzap.New(logzap.WriteTo(GinkgoWriter))
@@ -79,8 +89,8 @@ var _ = Describe("#Handler", func() {
 
 			h.ServeHTTP(resp, req)
 			Expect(resp).To(HaveHTTPStatus(http.StatusMethodNotAllowed))
-			Expect(resp).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
-			Expect(resp).To(HaveHTTPBody(`{"code":405,"message":"method not allowed"}`))
+			Expect(resp).To(HaveHTTPHeaderWithValue("Content-Type", "application/problem+json"))
+			Expect(resp).To(HaveHTTPBody(MatchJSON(problem(http.StatusMethodNotAllowed, "method not allowed", req.URL.Path))))
 		})
 	})
 
@@ -93,13 +103,20 @@ var _ = Describe("#Handler", func() {
 
 			h.ServeHTTP(resp, req)
 			Expect(resp).To(HaveHTTPStatus(http.StatusNotFound))
-			Expect(resp).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
-			Expect(resp).To(HaveHTTPBody(`{"code":404,"message":"not found"}`))
+			Expect(resp).To(HaveHTTPHeaderWithValue("Content-Type", "application/problem+json"))
+			Expect(resp).To(HaveHTTPBody(MatchJSON(problem(http.StatusNotFound, "not found", req.URL.Path))))
 		})
 	})
 
 	Describe("#StoreRequest", func() {
-		var s *store.Store[string]
+		var (
+			s *store.Store[string]
+
+			getContent = func(data string) handler.Content {
+				content := []byte(`{"data":"` + data + `"}`)
+				return handler.Content{Body: content, Variants: precompress.Compress(content)}
+			}
+		)
 
 		BeforeEach(func() {
 			var err error
@@ -120,15 +137,65 @@ var _ = Describe("#Handler", func() {
 
 			resp := httptest.NewRecorder()
 
-			h := handler.StoreRequest(log, s, func(data string) []byte { return []byte(`{"data":"` + data + `"}`) })
+			h := handler.StoreRequest(log, s, handler.DefaultCachePolicy, getContent)
 			h.ServeHTTP(resp, req)
 
 			Expect(resp).To(HaveHTTPStatus(http.StatusOK))
 			Expect(resp).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
 			Expect(resp).To(HaveHTTPHeaderWithValue("Cache-Control", "public, max-age=3600"))
+			Expect(resp).To(HaveHTTPHeaderWithValue("Vary", "Accept-Encoding"))
+			Expect(resp).To(HaveHTTPHeaderWithValue("Content-Length", "16"))
+			Expect(resp.Header().Get("Content-Encoding")).To(BeEmpty())
+			Expect(resp.Header().Get("JWS-Signature")).To(BeEmpty())
 			Expect(resp).To(HaveHTTPBody(`{"data":"entry"}`))
 		})
 
+		It("should return the signature of the content", func() {
+			id := uuid.NewString()
+			s.Write("test--"+id, "entry")
+
+			req := httptest.NewRequest(http.MethodGet, "/projects/test/shoots/"+id+"/test", nil)
+			req.SetPathValue("projectName", "test")
+			req.SetPathValue("shootUID", id)
+
+			resp := httptest.NewRecorder()
+
+			h := handler.StoreRequest(log, s, handler.DefaultCachePolicy, func(data string) handler.Content {
+				return handler.Content{Body: []byte(data), Signature: "header..signature"}
+			})
+			h.ServeHTTP(resp, req)
+
+			Expect(resp).To(HaveHTTPStatus(http.StatusOK))
+			Expect(resp).To(HaveHTTPHeaderWithValue("JWS-Signature", "header..signature"))
+		})
+
+		It("should return the precompressed data preferred by the client", func() {
+			id := uuid.NewString()
+			entry := strings.Repeat("entry", 100)
+			s.Write("test--"+id, entry)
+
+			req := httptest.NewRequest(http.MethodGet, "/projects/test/shoots/"+id+"/test", nil)
+			req.Header.Set("Accept-Encoding", "br;q=0.5, gzip")
+			req.SetPathValue("projectName", "test")
+			req.SetPathValue("shootUID", id)
+
+			resp := httptest.NewRecorder()
+
+			h := handler.StoreRequest(log, s, handler.DefaultCachePolicy, getContent)
+			h.ServeHTTP(resp, req)
+
+			Expect(resp).To(HaveHTTPStatus(http.StatusOK))
+			Expect(resp).To(HaveHTTPHeaderWithValue("Content-Encoding", "gzip"))
+			Expect(resp).To(HaveHTTPHeaderWithValue("Vary", "Accept-Encoding"))
+			Expect(resp).To(HaveHTTPHeaderWithValue("Content-Length", strconv.Itoa(resp.Body.Len())))
+
+			reader, err := gzip.NewReader(resp.Body)
+			Expect(err).ToNot(HaveOccurred())
+			body, err := io.ReadAll(reader)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(body)).To(Equal(`{"data":"` + entry + `"}`))
+		})
+
 		It("should return not found if data is not in store", func() {
 			id := uuid.NewString()
 			req := httptest.NewRequest(http.MethodGet, "/projects/test/shoots/"+id+"/test", nil)
@@ -138,12 +205,12 @@ var _ = Describe("#Handler", func() {
 
 			resp := httptest.NewRecorder()
 
-			h := handler.StoreRequest(log, s, func(data string) []byte { return []byte(`{"data":"` + data + `"}`) })
+			h := handler.StoreRequest(log, s, handler.DefaultCachePolicy, getContent)
 			h.ServeHTTP(resp, req)
 
 			Expect(resp).To(HaveHTTPStatus(http.StatusNotFound))
-			Expect(resp).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
-			Expect(resp).To(HaveHTTPBody(`{"code":404,"message":"not found"}`))
+			Expect(resp).To(HaveHTTPHeaderWithValue("Content-Type", "application/problem+json"))
+			Expect(resp).To(HaveHTTPBody(MatchJSON(problem(http.StatusNotFound, "not found", req.URL.Path))))
 		})
 
 		It("should return bad request if path value shootUID is invalid", func() {
@@ -155,12 +222,108 @@ var _ = Describe("#Handler", func() {
 
 			resp := httptest.NewRecorder()
 
-			h := handler.StoreRequest(log, s, func(data string) []byte { return []byte(`{"data":"` + data + `"}`) })
+			h := handler.StoreRequest(log, s, handler.DefaultCachePolicy, getContent)
 			h.ServeHTTP(resp, req)
 
 			Expect(resp).To(HaveHTTPStatus(http.StatusBadRequest))
-			Expect(resp).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
-			Expect(resp).To(HaveHTTPBody(`{"code":400,"message":"invalid UID"}`))
+			Expect(resp).To(HaveHTTPHeaderWithValue("Content-Type", "application/problem+json"))
+			Expect(resp).To(HaveHTTPBody(MatchJSON(problem(http.StatusBadRequest, "invalid UID", req.URL.Path))))
+		})
+
+		It("should return bad request if path value shootUID is not in canonical form", func() {
+			id := uuid.NewString()
+			s.Write("test--"+id, "entry")
+			req := httptest.NewRequest(http.MethodGet, "/projects/test/shoots/"+id+"/test", nil)
+			req.Pattern = "/projects/{projectName}/shoots/{shootUID}/test"
+			req.SetPathValue("projectName", "test")
+			req.SetPathValue("shootUID", "{"+strings.ToUpper(id)+"}")
+
+			resp := httptest.NewRecorder()
+
+			h := handler.StoreRequest(log, s, handler.DefaultCachePolicy, getContent)
+			h.ServeHTTP(resp, req)
+
+			Expect(resp).To(HaveHTTPStatus(http.StatusBadRequest))
+			Expect(resp).To(HaveHTTPBody(MatchJSON(problem(http.StatusBadRequest, "invalid UID", req.URL.Path))))
+		})
+
+		It("should return bad request if path value projectName is invalid", func() {
+			id := uuid.NewString()
+			req := httptest.NewRequest(http.MethodGet, "/projects/test/shoots/"+id+"/test", nil)
+			req.Pattern = "/projects/{projectName}/shoots/{shootUID}/test"
+			req.SetPathValue("projectName", strings.Repeat("a", 64))
+			req.SetPathValue("shootUID", id)
+
+			resp := httptest.NewRecorder()
+
+			h := handler.StoreRequest(log, s, handler.DefaultCachePolicy, getContent)
+			h.ServeHTTP(resp, req)
+
+			Expect(resp).To(HaveHTTPStatus(http.StatusBadRequest))
+			Expect(resp).To(HaveHTTPHeaderWithValue("Content-Type", "application/problem+json"))
+			Expect(resp).To(HaveHTTPBody(MatchJSON(problem(http.StatusBadRequest, "invalid project name", req.URL.Path))))
+		})
+	})
+
+	Describe("#AliasRequest", func() {
+		var (
+			s         *store.Store[string]
+			id        string
+			canonical http.Handler
+			newReq    = func(shootName string) *http.Request {
+				req := httptest.NewRequest(http.MethodGet, "/projects/test/shoots/by-name/"+shootName+"/cluster-ca", nil)
+				req.Pattern = "/projects/{projectName}/shoots/by-name/{shootName}/cluster-ca"
+				req.SetPathValue("projectName", "test")
+				req.SetPathValue("shootName", shootName)
+				return req
+			}
+		)
+
+		BeforeEach(func() {
+			s = store.MustNewStore(func(s string) string { return s })
+			id = uuid.NewString()
+			s.Write("test--"+id, "foo", store.WithAlias("test--local"))
+			canonical = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_, _ = w.Write([]byte(r.PathValue("projectName") + "/" + r.PathValue("shootUID")))
+			})
+		})
+
+		It("should redirect to the canonical path", func() {
+			resp := httptest.NewRecorder()
+
+			handler.AliasRequest(log, s, true, canonical).ServeHTTP(resp, newReq("local"))
+
+			Expect(resp).To(HaveHTTPStatus(http.StatusTemporaryRedirect))
+			Expect(resp).To(HaveHTTPHeaderWithValue("Location", "/projects/test/shoots/"+id+"/cluster-ca"))
+			Expect(resp).To(HaveHTTPHeaderWithValue("Cache-Control", "no-store"))
+		})
+
+		It("should serve the canonical handler", func() {
+			resp := httptest.NewRecorder()
+
+			handler.AliasRequest(log, s, false, canonical).ServeHTTP(resp, newReq("local"))
+
+			Expect(resp).To(HaveHTTPStatus(http.StatusOK))
+			Expect(resp).To(HaveHTTPBody("test/" + id))
+		})
+
+		It("should return not found for unknown shoot names", func() {
+			resp := httptest.NewRecorder()
+
+			handler.AliasRequest(log, s, false, canonical).ServeHTTP(resp, newReq("unknown"))
+
+			Expect(resp).To(HaveHTTPStatus(http.StatusNotFound))
+			Expect(resp).To(HaveHTTPHeaderWithValue("Cache-Control", "no-store"))
+			Expect(resp).To(HaveHTTPBody(MatchJSON(problem(http.StatusNotFound, "not found", "/projects/test/shoots/by-name/unknown/cluster-ca"))))
+		})
+
+		It("should return bad request if path value shootName is invalid", func() {
+			resp := httptest.NewRecorder()
+
+			handler.AliasRequest(log, s, false, canonical).ServeHTTP(resp, newReq("Invalid_Name"))
+
+			Expect(resp).To(HaveHTTPStatus(http.StatusBadRequest))
+			Expect(resp).To(HaveHTTPBody(MatchJSON(problem(http.StatusBadRequest, "invalid shoot name", "/projects/test/shoots/by-name/Invalid_Name/cluster-ca"))))
 		})
 	})
 })
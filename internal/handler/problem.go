@@ -0,0 +1,55 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-logr/logr"
+)
+
+const mimeAppProblemJSON = "application/problem+json"
+
+// ProblemTypeDefault is the problem type of errors which are sufficiently described by their status code.
+const ProblemTypeDefault = "about:blank"
+
+// Problem is the body of error responses as defined by RFC 7807.
+type Problem struct {
+	Type     string `json:"type"`
+	Title    string `json:"title"`
+	Status   int    `json:"status"`
+	Detail   string `json:"detail,omitempty"`
+	Instance string `json:"instance,omitempty"`
+
+	// Code is the status code, it is kept as extension member for clients of the former error responses.
+	Code int `json:"code"`
+	// Message is the detail, it is kept as extension member for clients of the former error responses.
+	Message string `json:"message"`
+}
+
+// NewProblem returns the problem of the request with the status code and the detail.
+func NewProblem(r *http.Request, status int, detail string) Problem {
+	return Problem{
+		Type:     ProblemTypeDefault,
+		Title:    http.StatusText(status),
+		Status:   status,
+		Detail:   detail,
+		Instance: r.URL.EscapedPath(),
+		Code:     status,
+		Message:  detail,
+	}
+}
+
+// WriteProblem replies with the status code and a problem+json body carrying the detail.
+// Error responses are never cached.
+func WriteProblem(w http.ResponseWriter, r *http.Request, log logr.Logger, status int, detail string) {
+	w.Header().Set(headerCacheControl, noCacheControl)
+	w.Header().Set(headerContentType, mimeAppProblemJSON)
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(NewProblem(r, status, detail)); err != nil {
+		RequestLog(log, r).Error(err, "Failed writing problem response", "status", status)
+	}
+}
@@ -0,0 +1,70 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package handler
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/go-logr/logr"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/gardener/gardener-discovery-server/internal/tracing"
+)
+
+// HeaderRequestID is the header carrying the ID of a request, it is propagated from the client or generated.
+const HeaderRequestID = "X-Request-ID"
+
+// maxRequestIDLength is the maximum length of a request ID propagated from the client.
+const maxRequestIDLength = 128
+
+type requestIDKey struct{}
+
+// RequestID is middleware handler propagating the request ID of the client or generating one if it is missing or invalid.
+// The ID is set on the response, on the request passed to the next handler and on the span of the request.
+func RequestID(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := r.Header.Get(HeaderRequestID)
+		if !isRequestID(id) {
+			id = uuid.NewString()
+		}
+		w.Header().Set(HeaderRequestID, id)
+		trace.SpanFromContext(r.Context()).SetAttributes(tracing.AttributeRequestID.String(id))
+
+		r = r.WithContext(context.WithValue(r.Context(), requestIDKey{}, id))
+		// The header is replaced so that the ID is forwarded along with the request, e.g. to the owning shard.
+		r.Header = r.Header.Clone()
+		r.Header.Set(HeaderRequestID, id)
+		next.ServeHTTP(w, r)
+	})
+}
+
+// RequestIDFrom returns the request ID set by [RequestID], it is empty if there is none.
+func RequestIDFrom(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
+// RequestLog returns the logger with the ID of the request attached.
+func RequestLog(log logr.Logger, r *http.Request) logr.Logger {
+	if id := RequestIDFrom(r.Context()); id != "" {
+		return log.WithValues("requestID", id)
+	}
+	return log
+}
+
+// isRequestID reports whether the ID propagated from the client is safe to be logged and echoed.
+func isRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+	for _, c := range []byte(id) {
+		if c <= ' ' || c >= 0x7f || c == '"' || c == '\\' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,79 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package handler_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/gardener/gardener-discovery-server/internal/handler"
+)
+
+var _ = Describe("#RequestID", func() {
+	var (
+		seen  string
+		serve = func(id string) *httptest.ResponseRecorder {
+			req := httptest.NewRequest(http.MethodGet, "/foo", nil)
+			if id != "" {
+				req.Header.Set(handler.HeaderRequestID, id)
+			}
+			recorder := httptest.NewRecorder()
+			handler.RequestID(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+				Expect(r.Header.Get(handler.HeaderRequestID)).To(Equal(handler.RequestIDFrom(r.Context())))
+				seen = handler.RequestIDFrom(r.Context())
+			})).ServeHTTP(recorder, req)
+			return recorder
+		}
+	)
+
+	BeforeEach(func() {
+		seen = ""
+	})
+
+	It("should propagate the request ID of the client", func() {
+		recorder := serve("foo-123")
+		Expect(recorder).To(HaveHTTPHeaderWithValue(handler.HeaderRequestID, "foo-123"))
+		Expect(seen).To(Equal("foo-123"))
+	})
+
+	It("should generate a request ID if the client does not send one", func() {
+		recorder := serve("")
+		Expect(uuid.Parse(recorder.Header().Get(handler.HeaderRequestID))).Error().ToNot(HaveOccurred())
+		Expect(seen).To(Equal(recorder.Header().Get(handler.HeaderRequestID)))
+	})
+
+	DescribeTable("should replace invalid request IDs",
+		func(id string) {
+			recorder := serve(id)
+			Expect(recorder.Header().Get(handler.HeaderRequestID)).ToNot(Equal(id))
+			Expect(uuid.Parse(seen)).Error().ToNot(HaveOccurred())
+		},
+		Entry("too long", strings.Repeat("a", 129)),
+		Entry("space", "foo bar"),
+		Entry("quote", `foo"bar`),
+		Entry("non-ASCII", "föö"),
+	)
+
+	It("should not have a request ID outside of the middleware", func() {
+		Expect(handler.RequestIDFrom(httptest.NewRequest(http.MethodGet, "/", nil).Context())).To(BeEmpty())
+	})
+})
+
+var _ = Describe("#WriteProblem", func() {
+	It("should write a problem with the extension members", func() {
+		recorder := httptest.NewRecorder()
+		handler.WriteProblem(recorder, httptest.NewRequest(http.MethodGet, "/foo%20bar?baz=1", nil), GinkgoLogr, http.StatusForbidden, "nope")
+
+		Expect(recorder).To(HaveHTTPStatus(http.StatusForbidden))
+		Expect(recorder).To(HaveHTTPHeaderWithValue("Content-Type", "application/problem+json"))
+		Expect(recorder).To(HaveHTTPHeaderWithValue("Cache-Control", "no-store"))
+		Expect(recorder).To(HaveHTTPBody(MatchJSON(`{"type":"about:blank","title":"Forbidden","status":403,"detail":"nope","instance":"/foo%20bar","code":403,"message":"nope"}`)))
+	})
+})
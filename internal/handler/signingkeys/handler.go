@@ -0,0 +1,92 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package signingkeys
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+
+	"github.com/gardener/gardener-discovery-server/internal/handler"
+	"github.com/gardener/gardener-discovery-server/internal/precompress"
+	"github.com/gardener/gardener-discovery-server/internal/signing"
+)
+
+// KeySet provides the JSON encoded key set of the public signing keys and signs with the current one.
+type KeySet interface {
+	signing.Signer
+	JWKS() ([]byte, error)
+}
+
+// Handler serves the public keys the responses are signed with.
+// The key set is signed with the current key, so that verifiers trusting it can verify the keys it is rotated to.
+type Handler struct {
+	keys     KeySet
+	policies handler.CachePolicies
+	log      logr.Logger
+
+	lock      sync.Mutex
+	jwks      []byte
+	keyID     string
+	signature string
+}
+
+// New constructs a new [Handler].
+func New(keys KeySet, policies handler.CachePolicies, log logr.Logger) *Handler {
+	return &Handler{
+		keys:     keys,
+		policies: policies,
+		log:      log,
+	}
+}
+
+// HandleJWKS handles the JWKS of the signing keys.
+func (h *Handler) HandleJWKS() http.Handler {
+	log := h.log.WithName("jwks")
+	return handler.SetHSTS(
+		handler.AllowMethods(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			jwks, signature, err := h.signedJWKS()
+			if err != nil {
+				handler.RequestLog(log, r).Error(err, "Failed encoding signing keys")
+				handler.WriteProblem(w, r, log, http.StatusInternalServerError, "failed encoding signing keys")
+				return
+			}
+			h.policies.JWKS.SetHeaders(w.Header(), time.Time{})
+			w.Header().Set("Content-Type", "application/json")
+			w.Header().Set(handler.HeaderJWSSignature, signature)
+			if err := handler.WriteBody(w, r, jwks, precompress.Variants{}); err != nil {
+				handler.RequestLog(log, r).Error(err, "Failed writing response")
+			}
+		}),
+			log, http.MethodGet, http.MethodHead,
+		),
+	)
+}
+
+// signedJWKS returns the key set and its signature. The signature is only renewed if the key set
+// or the key it is signed with changed.
+func (h *Handler) signedJWKS() ([]byte, string, error) {
+	jwks, err := h.keys.JWKS()
+	if err != nil {
+		return nil, "", err
+	}
+	keyID := h.keys.KeyID()
+
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	if h.signature != "" && h.keyID == keyID && bytes.Equal(h.jwks, jwks) {
+		return h.jwks, h.signature, nil
+	}
+	signature, err := h.keys.Sign(jwks)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to sign signing keys: %w", err)
+	}
+	h.jwks, h.keyID, h.signature = jwks, keyID, signature
+	return jwks, signature, nil
+}
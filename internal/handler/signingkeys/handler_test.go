@@ -0,0 +1,93 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package signingkeys_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	logzap "sigs.k8s.io/controller-runtime/pkg/log/zap"
+
+	"github.com/gardener/gardener-discovery-server/internal/handler"
+	"github.com/gardener/gardener-discovery-server/internal/handler/signingkeys"
+)
+
+var _ = Describe("#HandleJWKS", func() {
+	var (
+		keys *fakeKeySet
+		h    http.Handler
+	)
+
+	BeforeEach(func() {
+		keys = &fakeKeySet{jwks: []byte(`{"keys":[]}`), keyID: "a"}
+		h = signingkeys.New(keys, handler.DefaultCachePolicies, logzap.New(logzap.WriteTo(GinkgoWriter))).HandleJWKS()
+	})
+
+	It("should serve the signing keys", func() {
+		recorder := httptest.NewRecorder()
+		h.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/garden/discovery-server/signing-keys", nil))
+
+		Expect(recorder).To(HaveHTTPStatus(http.StatusOK))
+		Expect(recorder).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
+		Expect(recorder).To(HaveHTTPHeaderWithValue("Cache-Control", "public, max-age=3600"))
+		Expect(recorder).To(HaveHTTPHeaderWithValue("JWS-Signature", `a:{"keys":[]}`))
+		Expect(recorder).To(HaveHTTPBody(`{"keys":[]}`))
+	})
+
+	It("should only sign the keys again if they or the current key changed", func() {
+		get := func() *httptest.ResponseRecorder {
+			recorder := httptest.NewRecorder()
+			h.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/garden/discovery-server/signing-keys", nil))
+			Expect(recorder).To(HaveHTTPStatus(http.StatusOK))
+			return recorder
+		}
+
+		get()
+		get()
+		Expect(keys.signed).To(Equal(1))
+
+		keys.jwks = []byte(`{"keys":[{"kid":"b"}]}`)
+		Expect(get()).To(HaveHTTPHeaderWithValue("JWS-Signature", `a:{"keys":[{"kid":"b"}]}`))
+		Expect(keys.signed).To(Equal(2))
+
+		keys.keyID = "b"
+		Expect(get()).To(HaveHTTPHeaderWithValue("JWS-Signature", `b:{"keys":[{"kid":"b"}]}`))
+		Expect(keys.signed).To(Equal(3))
+	})
+
+	It("should fail if the keys cannot be encoded", func() {
+		keys.err = errors.New("fake")
+
+		recorder := httptest.NewRecorder()
+		h.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/garden/discovery-server/signing-keys", nil))
+
+		Expect(recorder).To(HaveHTTPStatus(http.StatusInternalServerError))
+		Expect(recorder).To(HaveHTTPHeaderWithValue("Cache-Control", "no-store"))
+	})
+})
+
+// fakeKeySet signs payloads by prefixing them with the current key ID.
+type fakeKeySet struct {
+	jwks   []byte
+	keyID  string
+	err    error
+	signed int
+}
+
+func (f *fakeKeySet) JWKS() ([]byte, error) {
+	return f.jwks, f.err
+}
+
+func (f *fakeKeySet) Sign(payload []byte) (string, error) {
+	f.signed++
+	return f.keyID + ":" + string(payload), nil
+}
+
+func (f *fakeKeySet) KeyID() string {
+	return f.keyID
+}
@@ -0,0 +1,259 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package spiffe
+
+import (
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/go-jose/go-jose/v4"
+	"github.com/go-logr/logr"
+
+	"github.com/gardener/gardener-discovery-server/internal/handler"
+	"github.com/gardener/gardener-discovery-server/internal/precompress"
+	"github.com/gardener/gardener-discovery-server/internal/signing"
+	"github.com/gardener/gardener-discovery-server/internal/store"
+	"github.com/gardener/gardener-discovery-server/internal/store/certificate"
+	"github.com/gardener/gardener-discovery-server/internal/store/openidmeta"
+	spiffestore "github.com/gardener/gardener-discovery-server/internal/store/spiffe"
+	"github.com/gardener/gardener-discovery-server/internal/utils"
+)
+
+// Uses of the keys of a SPIFFE bundle.
+const (
+	UseX509SVID = "x509-svid"
+	UseJWTSVID  = "jwt-svid"
+)
+
+// Source is a store the bundles are composed of.
+type Source[T any] interface {
+	store.Reader[T]
+	Metadata(key string) (store.Metadata, bool)
+	OnUpdate(fn func(key string))
+}
+
+// Composer composes the SPIFFE trust bundles of shoots of the service account issuer JWKS and the cluster CA bundle.
+// A bundle is composed whenever one of the entries it is composed of is written or removed,
+// so that requests are served from the stored bundle without decoding or encoding it again.
+type Composer struct {
+	oidStore    Source[openidmeta.Data]
+	certStore   Source[certificate.Data]
+	bundles     *store.Store[spiffestore.Data]
+	refreshHint time.Duration
+	signer      signing.Signer
+	log         logr.Logger
+
+	// mutex serializes the composition, so that a bundle composed of outdated entries never overwrites a later one.
+	mutex sync.Mutex
+}
+
+// NewComposer returns a [Composer] writing the bundles composed of the entries of the stores to the bundle store.
+// The signer may be nil, then the bundles are not signed.
+func NewComposer(
+	oidStore Source[openidmeta.Data],
+	certStore Source[certificate.Data],
+	bundles *store.Store[spiffestore.Data],
+	policies handler.CachePolicies,
+	signer signing.Signer,
+	log logr.Logger,
+) *Composer {
+	c := &Composer{
+		oidStore:    oidStore,
+		certStore:   certStore,
+		bundles:     bundles,
+		refreshHint: policies.SPIFFEBundle.MaxAge,
+		signer:      signer,
+		log:         log,
+	}
+	oidStore.OnUpdate(c.Compose)
+	certStore.OnUpdate(c.Compose)
+	return c
+}
+
+// Compose composes the bundle of the shoot, the bundle exists if at least one of the stores has an entry for the key.
+// The sequence is the highest resource version of the source objects. It is derived from the content, so that
+// all replicas serve the same sequence for the same bundle, and it never decreases, e.g. if one of the entries is removed.
+func (c *Composer) Compose(key string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	var (
+		out         = Bundle{Keys: []jose.JSONWebKey{}, RefreshHint: int64(c.refreshHint / time.Second)}
+		expirations []time.Time
+		found       bool
+	)
+
+	if data, ok := c.oidStore.Read(key); ok {
+		keys, err := jwtAuthorities(data.JWKS)
+		if err != nil {
+			c.log.Error(err, "Failed composing JWT authorities", "key", key)
+			c.bundles.Delete(key, store.WithReason("invalid JWKS: "+err.Error()))
+			return
+		}
+		out.Keys = append(out.Keys, keys...)
+		expirations = append(expirations, data.Expiration())
+		out.Sequence = max(out.Sequence, sourceVersion(c.oidStore, key))
+		found = true
+	}
+
+	if data, ok := c.certStore.Read(key); ok {
+		keys, err := x509Authorities(data.CABundle)
+		if err != nil {
+			c.log.Error(err, "Failed composing X.509 authorities", "key", key)
+			c.bundles.Delete(key, store.WithReason("invalid CA bundle: "+err.Error()))
+			return
+		}
+		out.Keys = append(out.Keys, keys...)
+		expirations = append(expirations, data.Expiration())
+		out.Sequence = max(out.Sequence, sourceVersion(c.certStore, key))
+		found = true
+	}
+
+	if !found {
+		c.bundles.Delete(key)
+		return
+	}
+	if previous, ok := c.bundles.Read(key); ok {
+		out.Sequence = max(out.Sequence, previous.Sequence)
+	}
+
+	raw, err := json.Marshal(out)
+	if err != nil {
+		c.log.Error(err, "Failed encoding SPIFFE bundle", "key", key)
+		return
+	}
+	signature, err := signing.Sign(c.signer, raw)
+	if err != nil {
+		c.log.Error(err, "Failed signing SPIFFE bundle", "key", key)
+		return
+	}
+	c.bundles.Write(key, spiffestore.Data{
+		Bundle:           raw,
+		CompressedBundle: precompress.Compress(raw),
+		Sequence:         out.Sequence,
+		Expires:          earliest(expirations),
+		BundleSignature:  signature,
+	})
+}
+
+// sourceVersion returns the resource version of the object the entry was published from.
+// Resource versions are opaque, but the ones of etcd are increasing integers. Zero is returned for any other.
+func sourceVersion[T any](s Source[T], key string) uint64 {
+	meta, ok := s.Metadata(key)
+	if !ok || meta.Source == nil {
+		return 0
+	}
+	version, err := strconv.ParseUint(meta.Source.ResourceVersion, 10, 64)
+	if err != nil {
+		return 0
+	}
+	return version
+}
+
+// Handler is capable of serving the SPIFFE trust bundles of shoots composed by the [Composer].
+type Handler struct {
+	bundles  store.Reader[spiffestore.Data]
+	policies handler.CachePolicies
+	log      logr.Logger
+}
+
+// New constructs a new [Handler].
+func New(bundles store.Reader[spiffestore.Data], policies handler.CachePolicies, log logr.Logger) *Handler {
+	return &Handler{
+		bundles:  bundles,
+		policies: policies,
+		log:      log,
+	}
+}
+
+// HandleBundle handles /spiffe-bundle.
+// It requires "projectName" and "shootUID" as path parameters.
+func (h *Handler) HandleBundle() http.Handler {
+	log := h.log.WithName("spiffe-bundle")
+	return handler.SetHSTS(
+		handler.AllowMethods(handler.StoreRequest(log, h.bundles, h.policies.SPIFFEBundle,
+			func(data spiffestore.Data) handler.Content {
+				return handler.Content{Body: data.Bundle, Variants: data.CompressedBundle, Signature: data.BundleSignature}
+			},
+		),
+			log, http.MethodGet, http.MethodHead,
+		),
+	)
+}
+
+// Bundle is a SPIFFE trust bundle as specified by the SPIFFE Trust Domain and Bundle specification.
+type Bundle struct {
+	Keys []jose.JSONWebKey `json:"keys"`
+	// Sequence increases whenever the content of the bundle changes.
+	Sequence uint64 `json:"spiffe_sequence"`
+	// RefreshHint is the number of seconds after which the bundle should be refreshed.
+	RefreshHint int64 `json:"spiffe_refresh_hint,omitempty"`
+}
+
+// jwtAuthorities returns the keys of the JWKS with the jwt-svid use.
+// Keys without key ID are skipped as the specification requires one.
+func jwtAuthorities(jwks []byte) ([]jose.JSONWebKey, error) {
+	keySet, err := utils.LoadKeySet(jwks)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]jose.JSONWebKey, 0, len(keySet.Keys))
+	for _, key := range keySet.Keys {
+		if key.KeyID == "" {
+			continue
+		}
+		key.Use = UseJWTSVID
+		out = append(out, key)
+	}
+	return out, nil
+}
+
+// x509Authorities returns a key with the x509-svid use for every certificate in the CA bundle.
+func x509Authorities(caBundle []byte) ([]jose.JSONWebKey, error) {
+	var bundle struct {
+		Certs string `json:"certs"`
+	}
+	if err := json.Unmarshal(caBundle, &bundle); err != nil {
+		return nil, fmt.Errorf("failed to decode CA bundle: %w", err)
+	}
+
+	var (
+		out  []jose.JSONWebKey
+		rest = []byte(bundle.Certs)
+	)
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			return out, nil
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse certificate: %w", err)
+		}
+		out = append(out, jose.JSONWebKey{
+			Key:          cert.PublicKey,
+			Certificates: []*x509.Certificate{cert},
+			Use:          UseX509SVID,
+		})
+	}
+}
+
+// earliest returns the earliest non-zero time, or the zero time if there is none.
+func earliest(times []time.Time) time.Time {
+	var out time.Time
+	for _, t := range times {
+		if !t.IsZero() && (out.IsZero() || t.Before(out)) {
+			out = t
+		}
+	}
+	return out
+}
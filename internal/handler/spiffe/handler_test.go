@@ -0,0 +1,182 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package spiffe_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/go-jose/go-jose/v4"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	logzap "sigs.k8s.io/controller-runtime/pkg/log/zap"
+
+	"github.com/gardener/gardener-discovery-server/internal/handler"
+	"github.com/gardener/gardener-discovery-server/internal/handler/spiffe"
+	"github.com/gardener/gardener-discovery-server/internal/store"
+	certstore "github.com/gardener/gardener-discovery-server/internal/store/certificate"
+	oidstore "github.com/gardener/gardener-discovery-server/internal/store/openidmeta"
+	spiffestore "github.com/gardener/gardener-discovery-server/internal/store/spiffe"
+)
+
+var _ = Describe("#HandleBundle", func() {
+	const (
+		key = "foo--a6475c90-d533-43c4-bbb0-d99200b491b1"
+		uri = "/projects/foo/shoots/a6475c90-d533-43c4-bbb0-d99200b491b1/spiffe-bundle"
+	)
+
+	var (
+		oidStore  *store.Store[oidstore.Data]
+		certStore *store.Store[certstore.Data]
+		mux       *http.ServeMux
+
+		jwks    []byte
+		caCert  *x509.Certificate
+		caBytes []byte
+
+		get = func() (*httptest.ResponseRecorder, spiffe.Bundle) {
+			recorder := httptest.NewRecorder()
+			mux.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, uri, nil))
+			var bundle spiffe.Bundle
+			if recorder.Code == http.StatusOK {
+				Expect(json.Unmarshal(recorder.Body.Bytes(), &bundle)).To(Succeed())
+			}
+			return recorder, bundle
+		}
+	)
+
+	BeforeEach(func() {
+		signingKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		Expect(err).ToNot(HaveOccurred())
+		jwks, err = json.Marshal(jose.JSONWebKeySet{Keys: []jose.JSONWebKey{
+			{Key: signingKey.Public(), KeyID: "sa-key", Algorithm: string(jose.ES256), Use: "sig"},
+			{Key: signingKey.Public(), Algorithm: string(jose.ES256), Use: "sig"},
+		}})
+		Expect(err).ToNot(HaveOccurred())
+
+		caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		Expect(err).ToNot(HaveOccurred())
+		template := &x509.Certificate{
+			SerialNumber:          big.NewInt(1),
+			Subject:               pkix.Name{CommonName: "ca"},
+			NotBefore:             time.Now().Add(-time.Hour),
+			NotAfter:              time.Now().Add(24 * time.Hour),
+			IsCA:                  true,
+			BasicConstraintsValid: true,
+			KeyUsage:              x509.KeyUsageCertSign,
+		}
+		der, err := x509.CreateCertificate(rand.Reader, template, template, caKey.Public(), caKey)
+		Expect(err).ToNot(HaveOccurred())
+		caCert, err = x509.ParseCertificate(der)
+		Expect(err).ToNot(HaveOccurred())
+		caBytes, err = json.Marshal(struct {
+			Certs string `json:"certs"`
+		}{Certs: string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))})
+		Expect(err).ToNot(HaveOccurred())
+
+		oidStore = store.MustNewStore(oidstore.Copy)
+		certStore = store.MustNewStore(certstore.Copy)
+		bundleStore := store.MustNewStore(spiffestore.Copy)
+
+		log := logzap.New(logzap.WriteTo(GinkgoWriter))
+		policies := handler.DefaultCachePolicies
+		policies.SPIFFEBundle = handler.CachePolicy{MaxAge: 5 * time.Minute}
+		spiffe.NewComposer(oidStore, certStore, bundleStore, policies, prefixSigner{}, log)
+		mux = http.NewServeMux()
+		mux.Handle("/projects/{projectName}/shoots/{shootUID}/spiffe-bundle", spiffe.New(bundleStore, policies, log).HandleBundle())
+	})
+
+	It("should compose the bundle of the JWKS and the CA bundle", func() {
+		oidStore.Write(key, oidstore.Data{JWKS: jwks}, withResourceVersion("12"))
+		certStore.Write(key, certstore.Data{CABundle: caBytes}, withResourceVersion("10"))
+
+		recorder, bundle := get()
+		Expect(recorder.Code).To(Equal(http.StatusOK))
+		Expect(recorder.Header().Get("Content-Type")).To(Equal("application/json"))
+		Expect(recorder.Header().Get("Cache-Control")).To(Equal("public, max-age=300"))
+		Expect(recorder.Header().Get("JWS-Signature")).To(Equal("signed:" + recorder.Body.String()))
+
+		Expect(bundle.RefreshHint).To(Equal(int64(300)))
+		Expect(bundle.Sequence).To(Equal(uint64(12)))
+
+		Expect(bundle.Keys).To(HaveLen(2))
+		Expect(bundle.Keys[0].Use).To(Equal(spiffe.UseJWTSVID))
+		Expect(bundle.Keys[0].KeyID).To(Equal("sa-key"))
+		Expect(bundle.Keys[1].Use).To(Equal(spiffe.UseX509SVID))
+		Expect(bundle.Keys[1].Certificates).To(HaveLen(1))
+		Expect(bundle.Keys[1].Certificates[0].Equal(caCert)).To(BeTrue())
+	})
+
+	It("should serve the precompressed variant of the bundle", func() {
+		oidStore.Write(key, oidstore.Data{JWKS: jwks})
+		certStore.Write(key, certstore.Data{CABundle: caBytes})
+
+		req := httptest.NewRequest(http.MethodGet, uri, nil)
+		req.Header.Set("Accept-Encoding", "gzip")
+		recorder := httptest.NewRecorder()
+		mux.ServeHTTP(recorder, req)
+		Expect(recorder.Code).To(Equal(http.StatusOK))
+		Expect(recorder.Header().Get("Content-Encoding")).To(Equal("gzip"))
+	})
+
+	It("should serve a partial bundle if only one of the documents is published", func() {
+		certStore.Write(key, certstore.Data{CABundle: caBytes})
+
+		recorder, bundle := get()
+		Expect(recorder.Code).To(Equal(http.StatusOK))
+		Expect(bundle.Keys).To(HaveLen(1))
+		Expect(bundle.Keys[0].Use).To(Equal(spiffe.UseX509SVID))
+	})
+
+	It("should derive the sequence from the resource versions and never decrease it", func() {
+		oidStore.Write(key, oidstore.Data{JWKS: jwks}, withResourceVersion("5"))
+		certStore.Write(key, certstore.Data{CABundle: caBytes}, withResourceVersion("7"))
+		_, bundle := get()
+		Expect(bundle.Sequence).To(Equal(uint64(7)))
+
+		oidStore.Write(key, oidstore.Data{JWKS: jwks}, withResourceVersion("9"))
+		_, bundle = get()
+		Expect(bundle.Sequence).To(Equal(uint64(9)))
+
+		oidStore.Delete(key)
+		_, bundle = get()
+		Expect(bundle.Keys).To(HaveLen(1))
+		Expect(bundle.Sequence).To(Equal(uint64(9)))
+	})
+
+	It("should remove the bundle once none of the documents is published", func() {
+		oidStore.Write(key, oidstore.Data{JWKS: jwks})
+		certStore.Write(key, certstore.Data{CABundle: caBytes})
+
+		oidStore.Delete(key)
+		certStore.Delete(key)
+		recorder, _ := get()
+		Expect(recorder.Code).To(Equal(http.StatusNotFound))
+	})
+
+	It("should return not found if none of the documents is published", func() {
+		recorder, _ := get()
+		Expect(recorder.Code).To(Equal(http.StatusNotFound))
+	})
+})
+
+func withResourceVersion(resourceVersion string) store.Option {
+	return store.WithSource(store.ObjectReference{Kind: "Secret", Name: "foo", ResourceVersion: resourceVersion})
+}
+
+type prefixSigner struct{}
+
+func (prefixSigner) Sign(payload []byte) (string, error) { return "signed:" + string(payload), nil }
+
+func (prefixSigner) KeyID() string { return "prefix" }
@@ -9,30 +9,37 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"time"
 
 	"github.com/go-logr/logr"
 
 	"github.com/gardener/gardener-discovery-server/internal/handler"
+	"github.com/gardener/gardener-discovery-server/internal/precompress"
+	"github.com/gardener/gardener-discovery-server/internal/signing"
 	"github.com/gardener/gardener-discovery-server/internal/utils"
 )
 
 const (
-	headerCacheControl = "Cache-Control"
-	pubCacheControl    = "public, max-age=3600"
-
 	headerContentType = "Content-Type"
 	mimeAppJSON       = "application/json"
 )
 
 // Handler implements handler functions for the openid configuration and JWKS endpoints.
 type Handler struct {
-	oidc []byte
-	jwks []byte
-	log  logr.Logger
+	oidc           []byte
+	jwks           []byte
+	compressedOIDC precompress.Variants
+	compressedJWKS precompress.Variants
+	expires        time.Time
+	oidcSignature  *signing.Signature
+	jwksSignature  *signing.Signature
+	policies       handler.CachePolicies
+	log            logr.Logger
 }
 
 // New creates new workload identity handler.
-func New(openIDConfig, jwks []byte, logger logr.Logger) (*Handler, error) {
+// The documents are signed with the signer unless it is nil.
+func New(openIDConfig, jwks []byte, signer signing.Signer, policies handler.CachePolicies, logger logr.Logger) (*Handler, error) {
 	conf, err := utils.LoadOpenIDConfig(openIDConfig)
 	if err != nil {
 		return nil, fmt.Errorf("failed to load openid configuration: %w", err)
@@ -71,18 +78,27 @@ func New(openIDConfig, jwks []byte, logger logr.Logger) (*Handler, error) {
 		}
 	}
 
-	return &Handler{
-		oidc: openIDConfig,
-		jwks: jwks,
-		log:  logger,
-	}, nil
+	h := &Handler{
+		oidc:           openIDConfig,
+		jwks:           jwks,
+		compressedOIDC: precompress.Compress(openIDConfig),
+		compressedJWKS: precompress.Compress(jwks),
+		expires:        utils.KeySetExpiration(keySet),
+		policies:       policies,
+		log:            logger,
+	}
+	if signer != nil {
+		h.oidcSignature = signing.NewSignature(signer, openIDConfig)
+		h.jwksSignature = signing.NewSignature(signer, jwks)
+	}
+	return h, nil
 }
 
 // HandleOpenIDConfiguration handles /.well-known/openid-configuration.
 func (h *Handler) HandleOpenIDConfiguration() http.Handler {
 	log := h.log.WithName("openid-configuration")
 	return handler.SetHSTS(
-		handler.AllowMethods(handleRequest(log, h.oidc),
+		handler.AllowMethods(handleRequest(log, h.oidc, h.compressedOIDC, h.oidcSignature, h.policies.OpenIDConfiguration, h.expires),
 			log, http.MethodGet, http.MethodHead,
 		),
 	)
@@ -92,19 +108,27 @@ func (h *Handler) HandleOpenIDConfiguration() http.Handler {
 func (h *Handler) HandleJWKS() http.Handler {
 	log := h.log.WithName("jwks")
 	return handler.SetHSTS(
-		handler.AllowMethods(handleRequest(log, h.jwks),
+		handler.AllowMethods(handleRequest(log, h.jwks, h.compressedJWKS, h.jwksSignature, h.policies.JWKS, h.expires),
 			log, http.MethodGet, http.MethodHead,
 		),
 	)
 }
 
-func handleRequest(log logr.Logger, responseData []byte) http.Handler {
-	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
-		w.Header().Set(headerCacheControl, pubCacheControl)
+func handleRequest(log logr.Logger, responseData []byte, compressed precompress.Variants, signature *signing.Signature, policy handler.CachePolicy, expires time.Time) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		policy.SetHeaders(w.Header(), expires)
 		w.Header().Set(headerContentType, mimeAppJSON)
+		if signature != nil {
+			// The response is still served if signing fails, consumers verifying signatures reject it.
+			if sig, err := signature.Get(); err != nil {
+				handler.RequestLog(log, r).Error(err, "Failed signing response")
+			} else {
+				w.Header().Set(handler.HeaderJWSSignature, sig)
+			}
+		}
 
-		if _, err := w.Write(responseData); err != nil {
-			log.Error(err, "Failed writing response")
+		if err := handler.WriteBody(w, r, responseData, compressed); err != nil {
+			handler.RequestLog(log, r).Error(err, "Failed writing response")
 			return
 		}
 	})
@@ -24,6 +24,7 @@ import (
 	"k8s.io/utils/ptr"
 	logzap "sigs.k8s.io/controller-runtime/pkg/log/zap"
 
+	discoveryhandler "github.com/gardener/gardener-discovery-server/internal/handler"
 	"github.com/gardener/gardener-discovery-server/internal/handler/workloadidentity"
 	"github.com/gardener/gardener-discovery-server/internal/utils"
 )
@@ -37,7 +38,8 @@ var _ = Describe("#WorkloadIdentity", func() {
 		mux          *http.ServeMux
 		logger       logr.Logger
 
-		headers map[string]string
+		headers      map[string]string
+		errorHeaders map[string]string
 	)
 
 	BeforeEach(func() {
@@ -60,7 +62,7 @@ var _ = Describe("#WorkloadIdentity", func() {
 		jwks, err = createJWKS(publicKey, kid)
 		Expect(err).ToNot(HaveOccurred())
 
-		handler, err = workloadidentity.New(openIDConfig, jwks, logger)
+		handler, err = workloadidentity.New(openIDConfig, jwks, nil, discoveryhandler.DefaultCachePolicies, logger)
 		Expect(err).ToNot(HaveOccurred())
 
 		mux = http.NewServeMux()
@@ -72,6 +74,11 @@ var _ = Describe("#WorkloadIdentity", func() {
 			"Content-Type":              "application/json",
 			"Cache-Control":             "public, max-age=3600",
 		}
+		errorHeaders = map[string]string{
+			"Strict-Transport-Security": "max-age=31536000; includeSubDomains",
+			"Content-Type":              "application/problem+json",
+			"Cache-Control":             "no-store",
+		}
 	})
 
 	Describe("#New", func() {
@@ -80,7 +87,7 @@ var _ = Describe("#WorkloadIdentity", func() {
 				openIDConfig, err := createOpenIDMeta(iss, iss+"/jwks")
 				Expect(err).ToNot(HaveOccurred())
 
-				_, err = workloadidentity.New(openIDConfig, jwks, logger)
+				_, err = workloadidentity.New(openIDConfig, jwks, nil, discoveryhandler.DefaultCachePolicies, logger)
 				Expect(err).To(matcher)
 			},
 			Entry("should not allow issuer url with control characters", "https://foo.\n.bar", MatchError(ContainSubstring("failed to parse issuer url"))),
@@ -95,7 +102,7 @@ var _ = Describe("#WorkloadIdentity", func() {
 				openIDConfig, err := createOpenIDMeta("https://foo.bar", jwkURL)
 				Expect(err).ToNot(HaveOccurred())
 
-				_, err = workloadidentity.New(openIDConfig, jwks, logger)
+				_, err = workloadidentity.New(openIDConfig, jwks, nil, discoveryhandler.DefaultCachePolicies, logger)
 				Expect(err).To(matcher)
 			},
 			Entry("should not allow jwks url with control characters", "https://foo.\n.bar/jwks", MatchError(ContainSubstring("failed to parse jwks url"))),
@@ -111,22 +118,35 @@ var _ = Describe("#WorkloadIdentity", func() {
 			jwks, err := createJWKS(privateKey, kid)
 			Expect(err).ToNot(HaveOccurred())
 
-			_, err = workloadidentity.New(openIDConfig, jwks, logger)
+			_, err = workloadidentity.New(openIDConfig, jwks, nil, discoveryhandler.DefaultCachePolicies, logger)
 			Expect(err).To(HaveOccurred())
 			Expect(err).To(MatchError(fmt.Errorf("jwks key with id %q is not public", kid)))
 		})
 
 		It("should fail to load openid configuration", func() {
-			_, err := workloadidentity.New([]byte(`invalid openid configuration}`), jwks, logger)
+			_, err := workloadidentity.New([]byte(`invalid openid configuration}`), jwks, nil, discoveryhandler.DefaultCachePolicies, logger)
 			Expect(err).To(MatchError(ContainSubstring("failed to load openid configuration")))
 		})
 
 		It("should fail to load json web key set", func() {
-			_, err := workloadidentity.New(openIDConfig, []byte(`invalid json web key set}`), logger)
+			_, err := workloadidentity.New(openIDConfig, []byte(`invalid json web key set}`), nil, discoveryhandler.DefaultCachePolicies, logger)
 			Expect(err).To(MatchError(ContainSubstring("failed to load json web key set")))
 		})
 	})
 
+	It("should send the signatures of the documents", func() {
+		signed, err := workloadidentity.New(openIDConfig, jwks, &prefixSigner{}, discoveryhandler.DefaultCachePolicies, logger)
+		Expect(err).ToNot(HaveOccurred())
+
+		recorder := httptest.NewRecorder()
+		signed.HandleJWKS().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, pathPrefix+"/jwks", nil))
+		Expect(recorder).To(HaveHTTPHeaderWithValue("JWS-Signature", "signed:"+string(jwks)))
+
+		recorder = httptest.NewRecorder()
+		signed.HandleOpenIDConfiguration().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, pathPrefix+"/.well-known/openid-configuration", nil))
+		Expect(recorder).To(HaveHTTPHeaderWithValue("JWS-Signature", "signed:"+string(openIDConfig)))
+	})
+
 	DescribeTable("#handleRequest",
 		func(method, path string, expectedStatus int, expectedResponse *[]byte, expectedHeaders map[string]string) {
 			request := httptest.NewRequest(method, path, nil)
@@ -144,18 +164,24 @@ var _ = Describe("#WorkloadIdentity", func() {
 		Entry("[OpenIDConfiguration] it should successfully head document",
 			http.MethodHead, pathPrefix+"/.well-known/openid-configuration", http.StatusOK, &openIDConfig, headers),
 		Entry("[OpenIDConfiguration] it should fail post document",
-			http.MethodPost, pathPrefix+"/.well-known/openid-configuration", http.StatusMethodNotAllowed, ptr.To([]byte(`{"code":405,"message":"method not allowed"}`)), headers),
+			http.MethodPost, pathPrefix+"/.well-known/openid-configuration", http.StatusMethodNotAllowed, ptr.To([]byte(`{"type":"about:blank","title":"Method Not Allowed","status":405,"detail":"method not allowed","instance":"`+pathPrefix+"/.well-known/openid-configuration"+`","code":405,"message":"method not allowed"}`+"\n")), errorHeaders),
 		Entry("[JWKS] it should successfully get document",
 			http.MethodGet, pathPrefix+"/jwks", http.StatusOK, &jwks, headers),
 		Entry("[JWKS] it should successfully head document",
 			http.MethodHead, pathPrefix+"/jwks", http.StatusOK, &jwks, headers),
 		Entry("[JWKS] it should fail post document",
-			http.MethodPost, pathPrefix+"/jwks", http.StatusMethodNotAllowed, ptr.To([]byte(`{"code":405,"message":"method not allowed"}`)), headers),
+			http.MethodPost, pathPrefix+"/jwks", http.StatusMethodNotAllowed, ptr.To([]byte(`{"type":"about:blank","title":"Method Not Allowed","status":405,"detail":"method not allowed","instance":"`+pathPrefix+"/jwks"+`","code":405,"message":"method not allowed"}`+"\n")), errorHeaders),
 		Entry("it should return not found on other paths",
 			http.MethodGet, pathPrefix, http.StatusNotFound, ptr.To([]byte("404 page not found\n")), nil),
 	)
 })
 
+type prefixSigner struct{}
+
+func (prefixSigner) Sign(payload []byte) (string, error) { return "signed:" + string(payload), nil }
+
+func (prefixSigner) KeyID() string { return "prefix" }
+
 func getKeyID(publicKey crypto.PublicKey) (string, error) {
 	marshaled, err := x509.MarshalPKIXPublicKey(publicKey)
 	if err != nil {
@@ -0,0 +1,65 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package listener
+
+import (
+	"net"
+	"net/netip"
+	"slices"
+
+	"github.com/go-logr/logr"
+
+	"github.com/gardener/gardener-discovery-server/internal/metrics"
+)
+
+const reasonSourceNotAllowed = "source_not_allowed"
+
+// AllowCIDRs returns a [net.Listener] that only accepts connections whose remote address
+// is contained in one of the given prefixes. Other connections are closed immediately after they are accepted.
+// If no prefixes are given l is returned unchanged.
+func AllowCIDRs(l net.Listener, prefixes []netip.Prefix, log logr.Logger) net.Listener {
+	if len(prefixes) == 0 {
+		return l
+	}
+	return &allowListener{
+		Listener: l,
+		prefixes: prefixes,
+		log:      log,
+	}
+}
+
+type allowListener struct {
+	net.Listener
+
+	prefixes []netip.Prefix
+	log      logr.Logger
+}
+
+// Accept waits for and returns the next connection from an allowed source.
+func (l *allowListener) Accept() (net.Conn, error) {
+	for {
+		conn, err := l.Listener.Accept()
+		if err != nil {
+			return nil, err
+		}
+
+		if l.allowed(conn.RemoteAddr()) {
+			return conn, nil
+		}
+
+		l.log.V(1).Info("Rejecting connection", "remoteAddress", conn.RemoteAddr().String(), "reason", reasonSourceNotAllowed)
+		metrics.RecordRejectedConnection(reasonSourceNotAllowed)
+		_ = conn.Close()
+	}
+}
+
+func (l *allowListener) allowed(addr net.Addr) bool {
+	ap, err := netip.ParseAddrPort(addr.String())
+	if err != nil {
+		return false
+	}
+	ip := ap.Addr().Unmap()
+	return slices.ContainsFunc(l.prefixes, func(p netip.Prefix) bool { return p.Contains(ip) })
+}
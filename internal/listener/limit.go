@@ -0,0 +1,118 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package listener
+
+import (
+	"net"
+	"sync"
+
+	"github.com/go-logr/logr"
+
+	"github.com/gardener/gardener-discovery-server/internal/metrics"
+)
+
+const (
+	reasonMaxConnections      = "max_connections"
+	reasonMaxConnectionsPerIP = "max_connections_per_ip"
+)
+
+// LimitListener returns a [net.Listener] that accepts at most maxConns simultaneous
+// connections and at most maxConnsPerIP simultaneous connections from a single IP address.
+// Connections exceeding the limits are closed immediately after they are accepted.
+// A limit of zero means no limit. If both limits are zero l is returned unchanged.
+func LimitListener(l net.Listener, maxConns, maxConnsPerIP int, log logr.Logger) net.Listener {
+	if maxConns <= 0 && maxConnsPerIP <= 0 {
+		return l
+	}
+	return &limitListener{
+		Listener:      l,
+		maxConns:      maxConns,
+		maxConnsPerIP: maxConnsPerIP,
+		perIP:         make(map[string]int),
+		log:           log,
+	}
+}
+
+type limitListener struct {
+	net.Listener
+
+	maxConns      int
+	maxConnsPerIP int
+	log           logr.Logger
+
+	mutex sync.Mutex
+	total int
+	perIP map[string]int
+}
+
+// Accept waits for and returns the next connection that does not exceed the limits.
+func (l *limitListener) Accept() (net.Conn, error) {
+	for {
+		conn, err := l.Listener.Accept()
+		if err != nil {
+			return nil, err
+		}
+
+		ip := remoteIP(conn)
+		if reason, ok := l.acquire(ip); !ok {
+			l.log.V(1).Info("Rejecting connection", "remoteAddress", conn.RemoteAddr().String(), "reason", reason)
+			metrics.RecordRejectedConnection(reason)
+			_ = conn.Close()
+			continue
+		}
+
+		return &limitConn{Conn: conn, release: func() { l.release(ip) }}, nil
+	}
+}
+
+func (l *limitListener) acquire(ip string) (string, bool) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	if l.maxConns > 0 && l.total >= l.maxConns {
+		return reasonMaxConnections, false
+	}
+	if l.maxConnsPerIP > 0 && l.perIP[ip] >= l.maxConnsPerIP {
+		return reasonMaxConnectionsPerIP, false
+	}
+
+	l.total++
+	l.perIP[ip]++
+	return "", true
+}
+
+func (l *limitListener) release(ip string) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	l.total--
+	if l.perIP[ip] <= 1 {
+		delete(l.perIP, ip)
+		return
+	}
+	l.perIP[ip]--
+}
+
+func remoteIP(conn net.Conn) string {
+	addr := conn.RemoteAddr().String()
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return addr
+	}
+	return host
+}
+
+type limitConn struct {
+	net.Conn
+	releaseOnce sync.Once
+	release     func()
+}
+
+// Close closes the connection and frees its slot in the listener.
+func (c *limitConn) Close() error {
+	err := c.Conn.Close()
+	c.releaseOnce.Do(c.release)
+	return err
+}
@@ -0,0 +1,113 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package listener_test
+
+import (
+	"io"
+	"net"
+	"time"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/gardener/gardener-discovery-server/internal/listener"
+)
+
+var _ = Describe("#LimitListener", func() {
+	var (
+		inner    net.Listener
+		accepted chan net.Conn
+
+		serve = func(ln net.Listener) {
+			go func() {
+				for {
+					conn, err := ln.Accept()
+					if err != nil {
+						return
+					}
+					accepted <- conn
+				}
+			}()
+		}
+
+		expectClosedByServer = func(conn net.Conn) {
+			Expect(conn.SetReadDeadline(time.Now().Add(2 * time.Second))).To(Succeed())
+			_, err := conn.Read(make([]byte, 1))
+			Expect(err).To(MatchError(io.EOF))
+		}
+	)
+
+	BeforeEach(func() {
+		var err error
+		inner, err = net.Listen("tcp", "127.0.0.1:0")
+		Expect(err).ToNot(HaveOccurred())
+		DeferCleanup(inner.Close)
+		accepted = make(chan net.Conn, 10)
+	})
+
+	It("should return the listener unchanged if no limits are set", func() {
+		Expect(listener.LimitListener(inner, 0, 0, logr.Discard())).To(BeIdenticalTo(inner))
+	})
+
+	It("should reject connections exceeding the per IP limit", func() {
+		serve(listener.LimitListener(inner, 0, 1, logr.Discard()))
+
+		first, err := net.Dial("tcp", inner.Addr().String())
+		Expect(err).ToNot(HaveOccurred())
+		defer first.Close()
+		var firstServerConn net.Conn
+		Eventually(accepted).Should(Receive(&firstServerConn))
+
+		second, err := net.Dial("tcp", inner.Addr().String())
+		Expect(err).ToNot(HaveOccurred())
+		defer second.Close()
+		expectClosedByServer(second)
+		Consistently(accepted, "100ms").ShouldNot(Receive())
+
+		By("freeing the slot when the connection is closed")
+		Expect(firstServerConn.Close()).To(Succeed())
+		third, err := net.Dial("tcp", inner.Addr().String())
+		Expect(err).ToNot(HaveOccurred())
+		defer third.Close()
+		Eventually(accepted).Should(Receive())
+	})
+
+	It("should reject connections exceeding the total limit", func() {
+		serve(listener.LimitListener(inner, 2, 0, logr.Discard()))
+
+		for range 2 {
+			conn, err := net.Dial("tcp", inner.Addr().String())
+			Expect(err).ToNot(HaveOccurred())
+			defer conn.Close()
+			Eventually(accepted).Should(Receive())
+		}
+
+		conn, err := net.Dial("tcp", inner.Addr().String())
+		Expect(err).ToNot(HaveOccurred())
+		defer conn.Close()
+		expectClosedByServer(conn)
+	})
+
+	It("should release the slot only once if a connection is closed multiple times", func() {
+		serve(listener.LimitListener(inner, 1, 0, logr.Discard()))
+
+		conn, err := net.Dial("tcp", inner.Addr().String())
+		Expect(err).ToNot(HaveOccurred())
+		defer conn.Close()
+		var serverConn net.Conn
+		Eventually(accepted).Should(Receive(&serverConn))
+		Expect(serverConn.Close()).To(Succeed())
+		Expect(serverConn.Close()).ToNot(Succeed())
+
+		for range 2 {
+			conn, err := net.Dial("tcp", inner.Addr().String())
+			Expect(err).ToNot(HaveOccurred())
+			defer conn.Close()
+		}
+		Eventually(accepted).Should(Receive())
+		Consistently(accepted, "100ms").ShouldNot(Receive())
+	})
+})
@@ -0,0 +1,301 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package listener
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/netip"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+
+	"github.com/gardener/gardener-discovery-server/internal/metrics"
+)
+
+const (
+	reasonInvalidProxyHeader = "invalid_proxy_header"
+
+	// proxyV1MaxLength is the maximum length of a PROXY protocol v1 header including CRLF.
+	proxyV1MaxLength = 107
+
+	// acceptRetryDelay and acceptMaxRetryDelay bound the backoff after temporary accept errors, see [net/http.Server.Serve].
+	acceptRetryDelay    = 5 * time.Millisecond
+	acceptMaxRetryDelay = time.Second
+)
+
+var proxyV2Signature = []byte{0x0D, 0x0A, 0x0D, 0x0A, 0x00, 0x0D, 0x0A, 0x51, 0x55, 0x49, 0x54, 0x0A}
+
+// ProxyProtocol returns a [net.Listener] that requires every connection to start with a
+// PROXY protocol v1 or v2 header. The remote address of returned connections is the client address
+// conveyed in the header. Connections without a valid header within headerTimeout are closed.
+// Headers are read concurrently so that slow clients do not block accepting other connections,
+// the number of connections whose header is pending has to be limited by the wrapped listener.
+// Temporary accept errors of the wrapped listener are retried with backoff.
+func ProxyProtocol(l net.Listener, headerTimeout time.Duration, log logr.Logger) net.Listener {
+	pl := &proxyListener{
+		Listener:      l,
+		headerTimeout: headerTimeout,
+		log:           log,
+		conns:         make(chan net.Conn),
+		failed:        make(chan struct{}),
+		done:          make(chan struct{}),
+	}
+	go pl.acceptLoop()
+	return pl
+}
+
+type proxyListener struct {
+	net.Listener
+
+	headerTimeout time.Duration
+	log           logr.Logger
+
+	conns chan net.Conn
+	// err is the permanent error of the wrapped listener, it is set before failed is closed.
+	err       error
+	failed    chan struct{}
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+func (l *proxyListener) acceptLoop() {
+	var delay time.Duration
+	for {
+		conn, err := l.Listener.Accept()
+		if err != nil {
+			if ne, ok := err.(net.Error); ok && ne.Temporary() { //nolint:staticcheck
+				delay = min(max(2*delay, acceptRetryDelay), acceptMaxRetryDelay)
+				l.log.Error(err, "Failed accepting connection, retrying", "delay", delay)
+				select {
+				case <-time.After(delay):
+					continue
+				case <-l.done:
+					return
+				}
+			}
+			l.err = err
+			close(l.failed)
+			return
+		}
+		delay = 0
+
+		go func() {
+			pc, err := readProxyHeader(conn, l.headerTimeout)
+			if err != nil {
+				l.log.V(1).Info("Rejecting connection", "remoteAddress", conn.RemoteAddr().String(), "reason", reasonInvalidProxyHeader, "error", err.Error())
+				metrics.RecordRejectedConnection(reasonInvalidProxyHeader)
+				_ = conn.Close()
+				return
+			}
+
+			select {
+			case l.conns <- pc:
+			case <-l.done:
+				_ = conn.Close()
+			}
+		}()
+	}
+}
+
+// Accept waits for and returns the next connection with a valid PROXY protocol header.
+func (l *proxyListener) Accept() (net.Conn, error) {
+	select {
+	case conn := <-l.conns:
+		return conn, nil
+	case <-l.failed:
+		return nil, l.err
+	case <-l.done:
+		return nil, net.ErrClosed
+	}
+}
+
+// Close closes the listener.
+func (l *proxyListener) Close() error {
+	l.closeOnce.Do(func() { close(l.done) })
+	return l.Listener.Close()
+}
+
+type proxyConn struct {
+	net.Conn
+	reader     *bufio.Reader
+	remoteAddr net.Addr
+	localAddr  net.Addr
+}
+
+// Read reads data from the connection including data buffered while parsing the header.
+func (c *proxyConn) Read(b []byte) (int, error) {
+	return c.reader.Read(b)
+}
+
+// RemoteAddr returns the source address conveyed in the PROXY protocol header.
+func (c *proxyConn) RemoteAddr() net.Addr {
+	return c.remoteAddr
+}
+
+// LocalAddr returns the destination address conveyed in the PROXY protocol header.
+func (c *proxyConn) LocalAddr() net.Addr {
+	return c.localAddr
+}
+
+func readProxyHeader(conn net.Conn, timeout time.Duration) (net.Conn, error) {
+	if err := conn.SetReadDeadline(time.Now().Add(timeout)); err != nil {
+		return nil, err
+	}
+
+	pc := &proxyConn{
+		Conn:       conn,
+		reader:     bufio.NewReader(conn),
+		remoteAddr: conn.RemoteAddr(),
+		localAddr:  conn.LocalAddr(),
+	}
+
+	// both a v1 header and the v2 signature are at least 12 bytes long
+	prefix, err := pc.reader.Peek(len(proxyV2Signature))
+	if err != nil {
+		return nil, fmt.Errorf("failed reading header: %w", err)
+	}
+
+	switch {
+	case bytes.Equal(prefix, proxyV2Signature):
+		err = pc.parseV2()
+	case bytes.HasPrefix(prefix, []byte("PROXY ")):
+		err = pc.parseV1()
+	default:
+		err = errors.New("missing PROXY protocol header")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := conn.SetReadDeadline(time.Time{}); err != nil {
+		return nil, err
+	}
+	return pc, nil
+}
+
+// parseV1 parses the human-readable header format, e.g. "PROXY TCP4 192.0.2.1 198.51.100.1 56324 443\r\n".
+func (c *proxyConn) parseV1() error {
+	var line []byte
+	for {
+		b, err := c.reader.ReadByte()
+		if err != nil {
+			return fmt.Errorf("failed reading v1 header: %w", err)
+		}
+		line = append(line, b)
+		if b == '\n' {
+			break
+		}
+		if len(line) >= proxyV1MaxLength {
+			return errors.New("v1 header exceeds maximum length")
+		}
+	}
+
+	header, ok := strings.CutSuffix(string(line), "\r\n")
+	if !ok {
+		return errors.New("v1 header is not terminated by CRLF")
+	}
+
+	fields := strings.Split(header, " ")
+	if len(fields) >= 2 && fields[1] == "UNKNOWN" {
+		// the proxy could not determine the client address, keep the connection addresses
+		return nil
+	}
+	if len(fields) != 6 || (fields[1] != "TCP4" && fields[1] != "TCP6") {
+		return fmt.Errorf("invalid v1 header %q", header)
+	}
+
+	src, err := parseV1Address(fields[2], fields[4], fields[1])
+	if err != nil {
+		return fmt.Errorf("invalid v1 source address: %w", err)
+	}
+	dst, err := parseV1Address(fields[3], fields[5], fields[1])
+	if err != nil {
+		return fmt.Errorf("invalid v1 destination address: %w", err)
+	}
+
+	c.remoteAddr, c.localAddr = src, dst
+	return nil
+}
+
+func parseV1Address(ip, port, protocol string) (*net.TCPAddr, error) {
+	addr, err := netip.ParseAddr(ip)
+	if err != nil {
+		return nil, err
+	}
+	if (protocol == "TCP4") != addr.Is4() {
+		return nil, fmt.Errorf("address %q does not match protocol %s", ip, protocol)
+	}
+	// ports must not have leading zeros or signs
+	if port == "" || (len(port) > 1 && port[0] == '0') {
+		return nil, fmt.Errorf("invalid port %q", port)
+	}
+	p, err := strconv.ParseUint(port, 10, 16)
+	if err != nil {
+		return nil, err
+	}
+	return net.TCPAddrFromAddrPort(netip.AddrPortFrom(addr, uint16(p))), nil
+}
+
+// parseV2 parses the binary header format.
+func (c *proxyConn) parseV2() error {
+	header := make([]byte, 16)
+	if _, err := io.ReadFull(c.reader, header); err != nil {
+		return fmt.Errorf("failed reading v2 header: %w", err)
+	}
+
+	var (
+		version = header[12] >> 4
+		command = header[12] & 0x0F
+		family  = header[13]
+		length  = int(binary.BigEndian.Uint16(header[14:16]))
+		payload = make([]byte, length)
+	)
+	if version != 2 {
+		return fmt.Errorf("unsupported version %d", version)
+	}
+	if command > 0x1 {
+		return fmt.Errorf("unsupported command %d", command)
+	}
+	if _, err := io.ReadFull(c.reader, payload); err != nil {
+		return fmt.Errorf("failed reading v2 addresses: %w", err)
+	}
+
+	if command == 0x0 {
+		// LOCAL command used by health checks of the proxy itself, keep the connection addresses
+		return nil
+	}
+
+	switch family {
+	case 0x11: // TCP over IPv4
+		if length < 12 {
+			return errors.New("v2 header too short for IPv4 addresses")
+		}
+		c.remoteAddr = v2Address(payload[0:4], payload[8:10])
+		c.localAddr = v2Address(payload[4:8], payload[10:12])
+	case 0x21: // TCP over IPv6
+		if length < 36 {
+			return errors.New("v2 header too short for IPv6 addresses")
+		}
+		c.remoteAddr = v2Address(payload[0:16], payload[32:34])
+		c.localAddr = v2Address(payload[16:32], payload[34:36])
+	default:
+		// unspecified or non TCP families, keep the connection addresses
+	}
+	return nil
+}
+
+func v2Address(ip, port []byte) *net.TCPAddr {
+	addr, _ := netip.AddrFromSlice(ip)
+	return net.TCPAddrFromAddrPort(netip.AddrPortFrom(addr, binary.BigEndian.Uint16(port)))
+}
@@ -0,0 +1,270 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package listener_test
+
+import (
+	"encoding/binary"
+	"io"
+	"net"
+	"net/netip"
+	"time"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/gardener/gardener-discovery-server/internal/listener"
+)
+
+var _ = Describe("#ProxyProtocol", func() {
+	var (
+		ln       net.Listener
+		accepted chan net.Conn
+
+		dial = func(header []byte) net.Conn {
+			conn, err := net.Dial("tcp", ln.Addr().String())
+			Expect(err).ToNot(HaveOccurred())
+			DeferCleanup(conn.Close)
+			if len(header) > 0 {
+				_, err = conn.Write(header)
+				Expect(err).ToNot(HaveOccurred())
+			}
+			return conn
+		}
+
+		expectAccepted = func(remoteAddr, localAddr string) net.Conn {
+			GinkgoHelper()
+			var conn net.Conn
+			Eventually(accepted).Should(Receive(&conn))
+			DeferCleanup(conn.Close)
+			Expect(conn.RemoteAddr().String()).To(Equal(remoteAddr))
+			if localAddr != "" {
+				Expect(conn.LocalAddr().String()).To(Equal(localAddr))
+			}
+			return conn
+		}
+
+		expectRejected = func(conn net.Conn) {
+			GinkgoHelper()
+			Expect(conn.SetReadDeadline(time.Now().Add(2 * time.Second))).To(Succeed())
+			_, err := conn.Read(make([]byte, 1))
+			Expect(err).To(MatchError(io.EOF))
+			Consistently(accepted, "50ms").ShouldNot(Receive())
+		}
+
+		v2Header = func(command, family byte, addresses []byte) []byte {
+			header := []byte{0x0D, 0x0A, 0x0D, 0x0A, 0x00, 0x0D, 0x0A, 0x51, 0x55, 0x49, 0x54, 0x0A, 0x20 | command, family, 0, 0}
+			binary.BigEndian.PutUint16(header[14:], uint16(len(addresses))) // #nosec G115 -- test data is short
+			return append(header, addresses...)
+		}
+	)
+
+	BeforeEach(func() {
+		inner, err := net.Listen("tcp", "127.0.0.1:0")
+		Expect(err).ToNot(HaveOccurred())
+		ln = listener.ProxyProtocol(inner, 300*time.Millisecond, logr.Discard())
+		DeferCleanup(func() { _ = ln.Close() })
+
+		accepted = make(chan net.Conn, 10)
+		go func() {
+			for {
+				conn, err := ln.Accept()
+				if err != nil {
+					return
+				}
+				accepted <- conn
+			}
+		}()
+	})
+
+	It("should parse v1 headers and keep the following data", func() {
+		dial([]byte("PROXY TCP4 192.0.2.1 198.51.100.1 56324 443\r\nGET / HTTP/1.1\r\n"))
+		conn := expectAccepted("192.0.2.1:56324", "198.51.100.1:443")
+
+		buf := make([]byte, len("GET / HTTP/1.1\r\n"))
+		_, err := io.ReadFull(conn, buf)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(buf)).To(Equal("GET / HTTP/1.1\r\n"))
+	})
+
+	It("should parse v1 headers with IPv6 addresses", func() {
+		dial([]byte("PROXY TCP6 2001:db8::1 2001:db8::2 56324 443\r\n"))
+		expectAccepted("[2001:db8::1]:56324", "[2001:db8::2]:443")
+	})
+
+	It("should keep the connection address for v1 UNKNOWN headers", func() {
+		conn := dial([]byte("PROXY UNKNOWN\r\n"))
+		expectAccepted(conn.LocalAddr().String(), "")
+	})
+
+	DescribeTable("should reject invalid v1 headers",
+		func(header string) {
+			expectRejected(dial([]byte(header)))
+		},
+		Entry("missing CRLF", "PROXY TCP4 192.0.2.1 198.51.100.1 56324 443\n"),
+		Entry("wrong protocol", "PROXY UDP4 192.0.2.1 198.51.100.1 56324 443\r\n"),
+		Entry("protocol mismatch", "PROXY TCP4 2001:db8::1 198.51.100.1 56324 443\r\n"),
+		Entry("invalid port", "PROXY TCP4 192.0.2.1 198.51.100.1 65536 443\r\n"),
+		Entry("leading zero port", "PROXY TCP4 192.0.2.1 198.51.100.1 0443 443\r\n"),
+		Entry("missing fields", "PROXY TCP4 192.0.2.1 198.51.100.1 56324\r\n"),
+		Entry("too long", "PROXY TCP4 "+string(make([]byte, 120))+"\r\n"),
+	)
+
+	It("should parse v2 headers with IPv4 addresses and TLVs", func() {
+		addresses := []byte{192, 0, 2, 1, 198, 51, 100, 1, 0xDC, 0x04, 0x01, 0xBB}
+		// append a TLV which has to be skipped
+		addresses = append(addresses, 0x04, 0x00, 0x01, 0xFF)
+		dial(append(v2Header(0x1, 0x11, addresses), []byte("data")...))
+		conn := expectAccepted("192.0.2.1:56324", "198.51.100.1:443")
+
+		buf := make([]byte, 4)
+		_, err := io.ReadFull(conn, buf)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(buf)).To(Equal("data"))
+	})
+
+	It("should parse v2 headers with IPv6 addresses", func() {
+		src := net.ParseIP("2001:db8::1").To16()
+		dst := net.ParseIP("2001:db8::2").To16()
+		addresses := append(append(append([]byte{}, src...), dst...), 0xDC, 0x04, 0x01, 0xBB)
+		dial(v2Header(0x1, 0x21, addresses))
+		expectAccepted("[2001:db8::1]:56324", "[2001:db8::2]:443")
+	})
+
+	It("should keep the connection address for v2 LOCAL commands", func() {
+		conn := dial(v2Header(0x0, 0x00, nil))
+		expectAccepted(conn.LocalAddr().String(), "")
+	})
+
+	It("should reject v2 headers with truncated addresses", func() {
+		expectRejected(dial(v2Header(0x1, 0x11, []byte{192, 0, 2, 1})))
+	})
+
+	It("should reject connections without header", func() {
+		expectRejected(dial([]byte("GET / HTTP/1.1\r\nHost: localhost\r\n\r\n")))
+	})
+
+	It("should reject connections not sending the header in time", func() {
+		expectRejected(dial([]byte("PROXY")))
+	})
+
+	It("should not block other connections while waiting for a slow header", func() {
+		dial([]byte("PRO"))
+		dial([]byte("PROXY TCP4 192.0.2.1 198.51.100.1 56324 443\r\n"))
+		expectAccepted("192.0.2.1:56324", "198.51.100.1:443")
+	})
+
+	It("should stop accepting after close", func() {
+		Expect(ln.Close()).To(Succeed())
+		_, err := ln.Accept()
+		Expect(err).To(MatchError(net.ErrClosed))
+	})
+
+	Context("accept errors", func() {
+		var inner *failingListener
+
+		BeforeEach(func() {
+			l, err := net.Listen("tcp", "127.0.0.1:0")
+			Expect(err).ToNot(HaveOccurred())
+			inner = &failingListener{Listener: l, errs: make(chan error, 2)}
+		})
+
+		It("should retry temporary errors", func() {
+			inner.errs <- temporaryError{}
+			pl := listener.ProxyProtocol(inner, 300*time.Millisecond, logr.Discard())
+			DeferCleanup(func() { _ = pl.Close() })
+
+			go func() {
+				defer GinkgoRecover()
+				conn, err := net.Dial("tcp", inner.Addr().String())
+				Expect(err).ToNot(HaveOccurred())
+				DeferCleanup(conn.Close)
+				_, err = conn.Write([]byte("PROXY TCP4 192.0.2.1 198.51.100.1 56324 443\r\n"))
+				Expect(err).ToNot(HaveOccurred())
+			}()
+
+			conn, err := pl.Accept()
+			Expect(err).ToNot(HaveOccurred())
+			DeferCleanup(conn.Close)
+			Expect(conn.RemoteAddr().String()).To(Equal("192.0.2.1:56324"))
+		})
+
+		It("should return permanent errors on every accept", func() {
+			inner.errs <- io.ErrUnexpectedEOF
+			pl := listener.ProxyProtocol(inner, 300*time.Millisecond, logr.Discard())
+			DeferCleanup(func() { _ = pl.Close() })
+
+			for range 2 {
+				_, err := pl.Accept()
+				Expect(err).To(MatchError(io.ErrUnexpectedEOF))
+			}
+		})
+	})
+})
+
+// failingListener returns the queued errors before accepting connections.
+type failingListener struct {
+	net.Listener
+	errs chan error
+}
+
+func (l *failingListener) Accept() (net.Conn, error) {
+	select {
+	case err := <-l.errs:
+		return nil, err
+	default:
+		return l.Listener.Accept()
+	}
+}
+
+type temporaryError struct{}
+
+func (temporaryError) Error() string   { return "too many open files" }
+func (temporaryError) Timeout() bool   { return false }
+func (temporaryError) Temporary() bool { return true }
+
+var _ = Describe("#AllowCIDRs", func() {
+	It("should only accept connections from allowed sources", func() {
+		for _, tc := range []struct {
+			cidr    string
+			allowed bool
+		}{
+			{cidr: "127.0.0.0/8", allowed: true},
+			{cidr: "10.0.0.0/8", allowed: false},
+		} {
+			inner, err := net.Listen("tcp", "127.0.0.1:0")
+			Expect(err).ToNot(HaveOccurred())
+			ln := listener.AllowCIDRs(inner, []netip.Prefix{netip.MustParsePrefix(tc.cidr)}, logr.Discard())
+			DeferCleanup(ln.Close)
+
+			accepted := make(chan net.Conn, 1)
+			go func() {
+				if conn, err := ln.Accept(); err == nil {
+					accepted <- conn
+				}
+			}()
+
+			conn, err := net.Dial("tcp", inner.Addr().String())
+			Expect(err).ToNot(HaveOccurred())
+			DeferCleanup(conn.Close)
+
+			if tc.allowed {
+				Eventually(accepted).Should(Receive())
+			} else {
+				Expect(conn.SetReadDeadline(time.Now().Add(2 * time.Second))).To(Succeed())
+				_, err := conn.Read(make([]byte, 1))
+				Expect(err).To(MatchError(io.EOF))
+				Consistently(accepted, "50ms").ShouldNot(Receive())
+			}
+		}
+	})
+
+	It("should return the listener unchanged if no prefixes are given", func() {
+		inner, err := net.Listen("tcp", "127.0.0.1:0")
+		Expect(err).ToNot(HaveOccurred())
+		DeferCleanup(inner.Close)
+		Expect(listener.AllowCIDRs(inner, nil, logr.Discard())).To(BeIdenticalTo(inner))
+	})
+})
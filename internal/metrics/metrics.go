@@ -5,7 +5,10 @@
 package metrics
 
 import (
+	"crypto/tls"
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
@@ -13,8 +16,10 @@ import (
 )
 
 func init() {
-	prometheus.MustRegister(requestLatency, requestTotal, requestInFlight)
-	metrics.Registry.MustRegister(requestLatency, requestTotal, requestInFlight)
+	prometheus.MustRegister(requestLatency, requestTotal, requestInFlight, rejectedConnections, tlsHandshakes,
+		certificateExpiration, certificateReloads, certificateSelections, shardMembers, gardenUp, forwardedRequests, storeCollector)
+	metrics.Registry.MustRegister(requestLatency, requestTotal, requestInFlight, rejectedConnections, tlsHandshakes,
+		certificateExpiration, certificateReloads, certificateSelections, shardMembers, gardenUp, forwardedRequests, storeCollector)
 }
 
 const (
@@ -45,6 +50,70 @@ var (
 	},
 		[]string{"path"},
 	)
+
+	rejectedConnections = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name:      "rejected_connections_total",
+		Subsystem: subsystemName,
+		Help:      "Total number of connections rejected by the listener by reason.",
+	},
+		[]string{"reason"},
+	)
+
+	tlsHandshakes = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name:      "tls_handshakes_total",
+		Subsystem: subsystemName,
+		Help:      "Total number of completed TLS handshakes by negotiated version and cipher suite.",
+	},
+		[]string{"version", "cipher_suite"},
+	)
+
+	certificateExpiration = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name:      "certificate_expiration_timestamp_seconds",
+		Subsystem: subsystemName,
+		Help:      "Expiration time of the currently served certificate in seconds since epoch.",
+	},
+		[]string{"certificate"},
+	)
+
+	certificateReloads = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name:      "certificate_reloads_total",
+		Subsystem: subsystemName,
+		Help:      "Total number of certificate reloads by certificate and success.",
+	},
+		[]string{"certificate", "success"},
+	)
+
+	certificateSelections = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name:      "certificate_selections_total",
+		Subsystem: subsystemName,
+		Help:      "Total number of TLS handshakes by selected certificate.",
+	},
+		[]string{"certificate"},
+	)
+
+	shardMembers = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name:      "shard_members",
+		Subsystem: subsystemName,
+		Help:      "Number of replicas sharing the projects of the garden in sharded mode.",
+	},
+		[]string{"garden"},
+	)
+
+	gardenUp = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name:      "garden_up",
+		Subsystem: subsystemName,
+		Help:      "Whether the manager of the garden is running, 0 while it is restarting and after it failed or stopped.",
+	},
+		[]string{"garden"},
+	)
+
+	forwardedRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name:      "forwarded_requests_total",
+		Subsystem: subsystemName,
+		Help:      "Total number of requests for projects of other shards by result.",
+	},
+		[]string{"result"},
+	)
 )
 
 // InstrumentHandler instruments the http handler with request generic metrics.
@@ -64,3 +133,49 @@ func InstrumentHandler(path string, handler http.Handler) http.Handler {
 		),
 	)
 }
+
+// RecordRejectedConnection increments the counter of connections rejected for the given reason.
+func RecordRejectedConnection(reason string) {
+	rejectedConnections.WithLabelValues(reason).Inc()
+}
+
+// RecordTLSHandshake increments the counter of TLS handshakes for the negotiated version and cipher suite.
+func RecordTLSHandshake(version, cipherSuite uint16) {
+	tlsHandshakes.WithLabelValues(tls.VersionName(version), tls.CipherSuiteName(cipherSuite)).Inc()
+}
+
+// SetCertificateExpiration sets the expiration time of the served certificate.
+func SetCertificateExpiration(certificate string, notAfter time.Time) {
+	certificateExpiration.WithLabelValues(certificate).Set(float64(notAfter.Unix()))
+}
+
+// RecordCertificateReload increments the counter of certificate reloads.
+func RecordCertificateReload(certificate string, success bool) {
+	certificateReloads.WithLabelValues(certificate, strconv.FormatBool(success)).Inc()
+}
+
+// RecordCertificateSelection increments the counter of handshakes the certificate was selected for.
+func RecordCertificateSelection(certificate string) {
+	certificateSelections.WithLabelValues(certificate).Inc()
+}
+
+// SetShardMembers sets the number of replicas sharing the projects of the garden.
+func SetShardMembers(garden string, members int) {
+	shardMembers.WithLabelValues(garden).Set(float64(members))
+}
+
+// SetGardenUp sets whether the manager of the garden is running.
+func SetGardenUp(garden string, up bool) {
+	value := 0.0
+	if up {
+		value = 1
+	}
+	gardenUp.WithLabelValues(garden).Set(value)
+}
+
+// RecordForwardedRequest increments the counter of requests for projects of other shards.
+// The result is either "forwarded", "fallback" if the request was served locally because the owner could not be reached,
+// or "unavailable" if the local replica did not hold the requested document either.
+func RecordForwardedRequest(result string) {
+	forwardedRequests.WithLabelValues(result).Inc()
+}
@@ -0,0 +1,189 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package metrics
+
+import (
+	"cmp"
+	"maps"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/gardener/gardener-discovery-server/internal/store"
+)
+
+var (
+	storeEntriesDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(subsystemName, "", "store_entries"),
+		"Number of entries in the store.",
+		[]string{"garden", "store"}, nil,
+	)
+	storeBytesDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(subsystemName, "", "store_bytes"),
+		"Total size of the published data of the store entries in bytes.",
+		[]string{"garden", "store"}, nil,
+	)
+	storeOldestEntryAgeDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(subsystemName, "", "store_oldest_entry_age_seconds"),
+		"Time since the least recently confirmed store entry was written. It is 0 if the store is empty. Not reported for static stores.",
+		[]string{"garden", "store"}, nil,
+	)
+	storeEntryAgeDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(subsystemName, "", "store_entry_age_seconds"),
+		"Histogram of the time since the store entries were last written. Not reported for static stores.",
+		[]string{"garden", "store"}, nil,
+	)
+
+	deletionBreakerOpenDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(subsystemName, "", "deletion_breaker_open"),
+		"Whether deletions from the store are paused because too many entries were deleted, 1 if open and 0 otherwise.",
+		[]string{"garden", "store"}, nil,
+	)
+	deletionBreakerPausedDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(subsystemName, "", "deletion_breaker_paused_deletions"),
+		"Number of entries whose deletion from the store is paused.",
+		[]string{"garden", "store"}, nil,
+	)
+
+	// storeEntryAgeBuckets range from a minute to a week.
+	storeEntryAgeBuckets = []float64{60, 300, 900, 1800, 3600, 3 * 3600, 6 * 3600, 12 * 3600, 24 * 3600, 48 * 3600, 7 * 24 * 3600}
+
+	storeCollector = NewStoreCollector()
+)
+
+// Breaker exposes the state of a deletion breaker of a store.
+type Breaker interface {
+	State() store.BreakerState
+}
+
+// storeID identifies a store of a garden.
+type storeID struct {
+	garden string
+	name   string
+}
+
+func compareStoreIDs(a, b storeID) int {
+	return cmp.Or(cmp.Compare(a.garden, b.garden), cmp.Compare(a.name, b.name))
+}
+
+// StoreCollector collects the size and freshness of stores and the state of their deletion breakers.
+type StoreCollector struct {
+	mutex    sync.RWMutex
+	stores   map[storeID]store.Inspector
+	static   map[storeID]bool
+	breakers map[storeID]Breaker
+}
+
+// NewStoreCollector returns a [StoreCollector] without stores.
+func NewStoreCollector() *StoreCollector {
+	return &StoreCollector{
+		stores:   map[storeID]store.Inspector{},
+		static:   map[storeID]bool{},
+		breakers: map[storeID]Breaker{},
+	}
+}
+
+// Add adds the store of the garden to the collector under the given name.
+func (c *StoreCollector) Add(garden, name string, s store.Inspector) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.stores[storeID{garden: garden, name: name}] = s
+}
+
+// AddStatic adds the store of the garden whose entries are written once to the collector under the given name.
+// As its entries are never confirmed again, only their number and size are collected.
+func (c *StoreCollector) AddStatic(garden, name string, s store.Inspector) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	id := storeID{garden: garden, name: name}
+	c.stores[id] = s
+	c.static[id] = true
+}
+
+// AddBreaker adds the deletion breaker of the store of the garden with the given name to the collector.
+func (c *StoreCollector) AddBreaker(garden, name string, b Breaker) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.breakers[storeID{garden: garden, name: name}] = b
+}
+
+// Describe implements [prometheus.Collector].
+func (c *StoreCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- storeEntriesDesc
+	ch <- storeBytesDesc
+	ch <- storeOldestEntryAgeDesc
+	ch <- storeEntryAgeDesc
+	ch <- deletionBreakerOpenDesc
+	ch <- deletionBreakerPausedDesc
+}
+
+// Collect implements [prometheus.Collector].
+func (c *StoreCollector) Collect(ch chan<- prometheus.Metric) {
+	c.mutex.RLock()
+	stores := maps.Clone(c.stores)
+	static := maps.Clone(c.static)
+	breakers := maps.Clone(c.breakers)
+	c.mutex.RUnlock()
+
+	now := time.Now()
+	for _, id := range slices.SortedFunc(maps.Keys(stores), compareStoreIDs) {
+		var (
+			entries = stores[id].List("")
+			size    int
+			oldest  float64
+			sum     float64
+			buckets = make(map[float64]uint64, len(storeEntryAgeBuckets))
+		)
+		for _, b := range storeEntryAgeBuckets {
+			buckets[b] = 0
+		}
+		for _, e := range entries {
+			size += e.Size
+			age := now.Sub(e.LastWrite).Seconds()
+			oldest = max(oldest, age)
+			sum += age
+			for _, b := range storeEntryAgeBuckets {
+				if age <= b {
+					buckets[b]++
+				}
+			}
+		}
+
+		ch <- prometheus.MustNewConstMetric(storeEntriesDesc, prometheus.GaugeValue, float64(len(entries)), id.garden, id.name)
+		ch <- prometheus.MustNewConstMetric(storeBytesDesc, prometheus.GaugeValue, float64(size), id.garden, id.name)
+		if static[id] {
+			continue
+		}
+		ch <- prometheus.MustNewConstMetric(storeOldestEntryAgeDesc, prometheus.GaugeValue, oldest, id.garden, id.name)
+		ch <- prometheus.MustNewConstHistogram(storeEntryAgeDesc, uint64(len(entries)), sum, buckets, id.garden, id.name)
+	}
+
+	for id, b := range breakers {
+		state := b.State()
+		open := 0.0
+		if state.Open {
+			open = 1
+		}
+		ch <- prometheus.MustNewConstMetric(deletionBreakerOpenDesc, prometheus.GaugeValue, open, id.garden, id.name)
+		ch <- prometheus.MustNewConstMetric(deletionBreakerPausedDesc, prometheus.GaugeValue, float64(len(state.PausedDeletions)), id.garden, id.name)
+	}
+}
+
+// RegisterStore adds the store of the garden to the collected stores under the given name.
+func RegisterStore(garden, name string, s store.Inspector) {
+	storeCollector.Add(garden, name, s)
+}
+
+// RegisterStaticStore adds the store of the garden whose entries are written once to the collected stores under the given name.
+func RegisterStaticStore(garden, name string, s store.Inspector) {
+	storeCollector.AddStatic(garden, name, s)
+}
+
+// RegisterDeletionBreaker adds the deletion breaker of the store of the garden with the given name to the collected breakers.
+func RegisterDeletionBreaker(garden, name string, b Breaker) {
+	storeCollector.AddBreaker(garden, name, b)
+}
@@ -0,0 +1,109 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package metrics_test
+
+import (
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/gardener/gardener-discovery-server/internal/metrics"
+	"github.com/gardener/gardener-discovery-server/internal/store"
+	"github.com/gardener/gardener-discovery-server/internal/store/certificate"
+)
+
+var _ = Describe("StoreCollector", func() {
+	var (
+		collector *metrics.StoreCollector
+		certStore *store.Store[certificate.Data]
+	)
+
+	BeforeEach(func() {
+		collector = metrics.NewStoreCollector()
+		certStore = store.MustNewStore(certificate.Copy)
+		collector.Add("default", "certificate", certStore)
+		collector.Add("default", "empty", store.MustNewStore(certificate.Copy))
+	})
+
+	It("should report the number of entries and their size per store", func() {
+		certStore.Write("foo--bar--1", certificate.Data{CABundle: []byte("foo")})
+		certStore.Write("foo--bar--2", certificate.Data{CABundle: []byte("foobar")})
+
+		Expect(testutil.CollectAndCompare(collector, strings.NewReader(`
+# HELP gardener_discovery_server_store_entries Number of entries in the store.
+# TYPE gardener_discovery_server_store_entries gauge
+gardener_discovery_server_store_entries{garden="default",store="certificate"} 2
+gardener_discovery_server_store_entries{garden="default",store="empty"} 0
+# HELP gardener_discovery_server_store_bytes Total size of the published data of the store entries in bytes.
+# TYPE gardener_discovery_server_store_bytes gauge
+gardener_discovery_server_store_bytes{garden="default",store="certificate"} 9
+gardener_discovery_server_store_bytes{garden="default",store="empty"} 0
+`), "gardener_discovery_server_store_entries", "gardener_discovery_server_store_bytes")).To(Succeed())
+	})
+
+	It("should report the age of the entries", func() {
+		certStore.Write("foo--bar--1", certificate.Data{CABundle: []byte("foo")})
+
+		registry := prometheus.NewPedanticRegistry()
+		Expect(registry.Register(collector)).To(Succeed())
+		families, err := registry.Gather()
+		Expect(err).ToNot(HaveOccurred())
+
+		values := map[string]map[string]float64{}
+		for _, f := range families {
+			for _, m := range f.GetMetric() {
+				if values[f.GetName()] == nil {
+					values[f.GetName()] = map[string]float64{}
+				}
+				storeName := m.GetLabel()[1].GetValue()
+				switch {
+				case m.GetHistogram() != nil:
+					values[f.GetName()][storeName] = float64(m.GetHistogram().GetSampleCount())
+					Expect(m.GetHistogram().GetBucket()[0].GetCumulativeCount()).To(Equal(m.GetHistogram().GetSampleCount()))
+				default:
+					values[f.GetName()][storeName] = m.GetGauge().GetValue()
+				}
+			}
+		}
+
+		Expect(values["gardener_discovery_server_store_oldest_entry_age_seconds"]).To(HaveKeyWithValue("certificate", And(BeNumerically(">", 0), BeNumerically("<", 60))))
+		Expect(values["gardener_discovery_server_store_oldest_entry_age_seconds"]).To(HaveKeyWithValue("empty", BeZero()))
+		Expect(values["gardener_discovery_server_store_entry_age_seconds"]).To(HaveKeyWithValue("certificate", Equal(1.0)))
+		Expect(values["gardener_discovery_server_store_entry_age_seconds"]).To(HaveKeyWithValue("empty", BeZero()))
+	})
+
+	It("should not report the age of the entries of static stores", func() {
+		staticStore := store.MustNewStore(certificate.Copy)
+		staticStore.Write("garden", certificate.Data{CABundle: []byte("foo")})
+		collector = metrics.NewStoreCollector()
+		collector.AddStatic("default", "static", staticStore)
+
+		Expect(testutil.CollectAndCount(collector, "gardener_discovery_server_store_entries")).To(Equal(1))
+		Expect(testutil.CollectAndCount(collector, "gardener_discovery_server_store_bytes")).To(Equal(1))
+		Expect(testutil.CollectAndCount(collector, "gardener_discovery_server_store_oldest_entry_age_seconds")).To(BeZero())
+		Expect(testutil.CollectAndCount(collector, "gardener_discovery_server_store_entry_age_seconds")).To(BeZero())
+	})
+
+	It("should report the state of the deletion breakers", func() {
+		certStore.Write("foo--bar--1", certificate.Data{CABundle: []byte("foo")})
+		breaker := store.NewDeletionBreaker(certStore, store.BreakerConfig{Window: time.Minute}, logr.Discard())
+		collector.AddBreaker("default", "certificate", breaker)
+		breaker.Delete("foo--bar--1")
+
+		Expect(testutil.CollectAndCompare(collector, strings.NewReader(`
+# HELP gardener_discovery_server_deletion_breaker_open Whether deletions from the store are paused because too many entries were deleted, 1 if open and 0 otherwise.
+# TYPE gardener_discovery_server_deletion_breaker_open gauge
+gardener_discovery_server_deletion_breaker_open{garden="default",store="certificate"} 1
+# HELP gardener_discovery_server_deletion_breaker_paused_deletions Number of entries whose deletion from the store is paused.
+# TYPE gardener_discovery_server_deletion_breaker_paused_deletions gauge
+gardener_discovery_server_deletion_breaker_paused_deletions{garden="default",store="certificate"} 1
+`), "gardener_discovery_server_deletion_breaker_open", "gardener_discovery_server_deletion_breaker_paused_deletions")).To(Succeed())
+	})
+})
@@ -0,0 +1,118 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package precompress
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/andybalholm/brotli"
+)
+
+// Content codings of the precompressed variants.
+const (
+	EncodingBrotli   = "br"
+	EncodingGzip     = "gzip"
+	EncodingIdentity = "identity"
+)
+
+// Variants are the precompressed variants of a body.
+// A variant is nil if compressing did not reduce the size of the body.
+// Variants are immutable once compressed, they are shared by reference and must not be modified.
+type Variants struct {
+	Brotli []byte
+	Gzip   []byte
+}
+
+// Compress returns the precompressed variants of the body with the best compression.
+func Compress(body []byte) Variants {
+	return Variants{
+		Brotli: compress(body, func(w io.Writer) io.WriteCloser { return brotli.NewWriterLevel(w, brotli.BestCompression) }),
+		Gzip: compress(body, func(w io.Writer) io.WriteCloser {
+			gw, _ := gzip.NewWriterLevel(w, gzip.BestCompression)
+			return gw
+		}),
+	}
+}
+
+func compress(body []byte, newWriter func(io.Writer) io.WriteCloser) []byte {
+	if len(body) == 0 {
+		return nil
+	}
+	buf := &bytes.Buffer{}
+	w := newWriter(buf)
+	if _, err := w.Write(body); err != nil {
+		return nil
+	}
+	if err := w.Close(); err != nil {
+		return nil
+	}
+	if buf.Len() >= len(body) {
+		return nil
+	}
+	return buf.Bytes()
+}
+
+// Select returns the content coding and the body of the variant preferred by the Accept-Encoding header.
+// It falls back to the uncompressed body if no precompressed variant is acceptable.
+func (v Variants) Select(acceptEncoding string, identity []byte) (string, []byte) {
+	accepted := parseAcceptEncoding(acceptEncoding)
+	var (
+		encoding = EncodingIdentity
+		body     = identity
+		quality  = 0.0
+	)
+	for _, candidate := range []struct {
+		encoding string
+		body     []byte
+	}{
+		// Brotli is preferred over gzip on equal quality as it compresses better.
+		{EncodingBrotli, v.Brotli},
+		{EncodingGzip, v.Gzip},
+	} {
+		if q := accepted.quality(candidate.encoding); len(candidate.body) > 0 && q > quality {
+			encoding, body, quality = candidate.encoding, candidate.body, q
+		}
+	}
+	return encoding, body
+}
+
+type acceptEncoding map[string]float64
+
+func (a acceptEncoding) quality(encoding string) float64 {
+	if q, ok := a[encoding]; ok {
+		return q
+	}
+	return a["*"]
+}
+
+// parseAcceptEncoding parses the codings and their quality values.
+// Malformed quality values are treated as 0, i.e. not acceptable.
+func parseAcceptEncoding(header string) acceptEncoding {
+	out := acceptEncoding{}
+	for part := range strings.SplitSeq(header, ",") {
+		coding, params, _ := strings.Cut(part, ";")
+		coding = strings.ToLower(strings.TrimSpace(coding))
+		if coding == "" {
+			continue
+		}
+		q := 1.0
+		for param := range strings.SplitSeq(params, ";") {
+			name, value, _ := strings.Cut(strings.TrimSpace(param), "=")
+			if strings.EqualFold(name, "q") {
+				parsed, err := strconv.ParseFloat(value, 64)
+				if err != nil || parsed < 0 || parsed > 1 {
+					parsed = 0
+				}
+				q = parsed
+			}
+		}
+		out[coding] = q
+	}
+	return out
+}
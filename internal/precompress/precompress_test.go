@@ -0,0 +1,72 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package precompress_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/rand"
+	"io"
+	"strings"
+
+	"github.com/andybalholm/brotli"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/gardener/gardener-discovery-server/internal/precompress"
+)
+
+var _ = Describe("Precompress", func() {
+	var (
+		body     = []byte(strings.Repeat(`{"kty":"RSA","use":"sig"}`, 20))
+		variants = precompress.Variants{Brotli: []byte("br"), Gzip: []byte("gzip")}
+	)
+
+	Describe("#Compress", func() {
+		It("should compress the body", func() {
+			compressed := precompress.Compress(body)
+
+			Expect(len(compressed.Brotli)).To(BeNumerically("<", len(body)))
+			decoded, err := io.ReadAll(brotli.NewReader(bytes.NewReader(compressed.Brotli)))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(decoded).To(Equal(body))
+
+			Expect(len(compressed.Gzip)).To(BeNumerically("<", len(body)))
+			reader, err := gzip.NewReader(bytes.NewReader(compressed.Gzip))
+			Expect(err).ToNot(HaveOccurred())
+			decoded, err = io.ReadAll(reader)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(decoded).To(Equal(body))
+		})
+
+		It("should omit variants that are not smaller than the body", func() {
+			random := make([]byte, 64)
+			_, err := rand.Read(random)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(precompress.Compress(random)).To(Equal(precompress.Variants{}))
+			Expect(precompress.Compress(nil)).To(Equal(precompress.Variants{}))
+		})
+	})
+
+	DescribeTable("#Select",
+		func(acceptEncoding string, variants precompress.Variants, expectedEncoding string) {
+			encoding, content := variants.Select(acceptEncoding, []byte("identity"))
+			Expect(encoding).To(Equal(expectedEncoding))
+			Expect(string(content)).To(Equal(expectedEncoding))
+		},
+		Entry("no header", "", variants, "identity"),
+		Entry("gzip only", "gzip", variants, "gzip"),
+		Entry("brotli preferred on equal quality", "gzip, deflate, br", variants, "br"),
+		Entry("higher quality wins", "br;q=0.5, gzip;q=0.8", variants, "gzip"),
+		Entry("case insensitive", "GZIP;Q=1", variants, "gzip"),
+		Entry("wildcard", "*", variants, "br"),
+		Entry("wildcard with excluded brotli", "br;q=0, *;q=0.5", variants, "gzip"),
+		Entry("not acceptable", "gzip;q=0, br;q=0", variants, "identity"),
+		Entry("malformed quality", "br;q=foo, gzip", variants, "gzip"),
+		Entry("unknown coding", "deflate, zstd", variants, "identity"),
+		Entry("missing variant", "br", precompress.Variants{Gzip: []byte("gzip")}, "identity"),
+	)
+})
@@ -0,0 +1,59 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package publisher
+
+import (
+	"context"
+
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/gardener/gardener-discovery-server/internal/utils"
+)
+
+// EnqueueOnAliasOptOut returns an event handler for projects that enqueues the relevant source objects of the project
+// whenever it opts in or out of the resolution of its shoots by name. This lets the aliases follow the
+// annotation of the project without waiting for the resync.
+func EnqueueOnAliasOptOut[O client.Object, T any](reader client.Reader, resource PublishedResource[O, T]) handler.EventHandler {
+	return handler.Funcs{
+		UpdateFunc: func(ctx context.Context, e event.UpdateEvent, queue workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+			if e.ObjectOld.GetAnnotations()[utils.AnnotationShootNameAlias] == e.ObjectNew.GetAnnotations()[utils.AnnotationShootNameAlias] {
+				return
+			}
+			// Projects without a namespace do not have any shoots.
+			project, ok := e.ObjectNew.(*gardencorev1beta1.Project)
+			if !ok || project.Spec.Namespace == nil {
+				return
+			}
+
+			list := resource.NewObjectList()
+			if err := reader.List(ctx, list, resource.ProjectListOptions(project)...); err != nil {
+				logf.FromContext(ctx).Error(err, "Failed to list objects after the shoot name alias opt-out changed", "project", e.ObjectNew.GetName())
+				return
+			}
+
+			if err := meta.EachListItem(list, func(obj runtime.Object) error {
+				o, ok := obj.(O)
+				if !ok || !resource.IsRelevant(o) {
+					return nil
+				}
+				owner, err := resource.Owner(ctx, reader, o)
+				if err == nil && owner.ProjectName == e.ObjectNew.GetName() {
+					queue.Add(reconcile.Request{NamespacedName: client.ObjectKeyFromObject(o)})
+				}
+				return nil
+			}); err != nil {
+				logf.FromContext(ctx).Error(err, "Failed to enqueue objects after the shoot name alias opt-out changed", "project", e.ObjectNew.GetName())
+			}
+		},
+	}
+}
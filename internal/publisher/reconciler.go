@@ -0,0 +1,306 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package publisher
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	"github.com/gardener/gardener/pkg/controllerutils"
+	"github.com/go-logr/logr"
+	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/time/rate"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	"github.com/gardener/gardener-discovery-server/internal/sharding"
+	"github.com/gardener/gardener-discovery-server/internal/signing"
+	"github.com/gardener/gardener-discovery-server/internal/store"
+	"github.com/gardener/gardener-discovery-server/internal/tracing"
+	"github.com/gardener/gardener-discovery-server/internal/utils"
+)
+
+// Reconciler reconciles the source objects of a [PublishedResource].
+// It verifies that the objects belong to an existing shoot of the project owning the namespace
+// before the rendered data is written to the store.
+type Reconciler[O client.Object, T any] struct {
+	once    sync.Once
+	mapping map[string]string
+	// published are the source objects of the entries published before the reconciler started.
+	published []reconcile.Request
+	mutex     sync.Mutex
+	// reader resolves the owners of the source objects in the predicate, it reads from the cache of the manager.
+	reader client.Reader
+
+	Client       client.Client
+	ResyncPeriod time.Duration
+	Store        store.Writer[T]
+	Resource     PublishedResource[O, T]
+	// Name is the name of the controller, the name of the resource is used if it is empty.
+	Name string
+	// Shard restricts the reconciled projects to the ones owned by the local replica, all projects are reconciled if it is nil.
+	Shard sharding.Shard
+	// Signer signs the published documents, they are not signed if it is nil.
+	Signer signing.Signer
+	// Published lists the entries already in the store, e.g. the ones published by the reconciler of a failed manager.
+	// Their source objects are reconciled once the controller starts, so that the entries of objects
+	// removed in the meantime are deleted through the store writer.
+	Published store.Inspector
+}
+
+// Reconcile publishes the data rendered from the source object, it is removed from the store if the object is rejected.
+func (r *Reconciler[O, T]) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	ctx, span := tracing.Tracer().Start(ctx, r.name()+" Reconcile", trace.WithAttributes(
+		tracing.AttributeObjectNamespace.String(req.Namespace),
+		tracing.AttributeObjectName.String(req.Name),
+	))
+	defer span.End()
+
+	log := logf.FromContext(ctx)
+
+	r.init()
+
+	result, err := r.reconcile(ctx, span, log, req)
+	if rejection := (*Rejection)(nil); errors.As(err, &rejection) {
+		r.reject(log, req, rejection)
+		return reconcile.Result{}, nil
+	}
+	return result, err
+}
+
+func (r *Reconciler[O, T]) reconcile(ctx context.Context, span trace.Span, log logr.Logger, req ctrl.Request) (ctrl.Result, error) {
+	obj := r.Resource.NewObject()
+	if err := r.Client.Get(ctx, req.NamespacedName, obj); err != nil {
+		if apierrors.IsNotFound(err) {
+			return reconcile.Result{}, Reject(strings.ToLower(r.Resource.Kind())+" not found", nil)
+		}
+		return reconcile.Result{}, err
+	}
+
+	if obj.GetDeletionTimestamp() != nil {
+		return reconcile.Result{}, Reject("deletion timestamp present", nil)
+	}
+
+	if err := r.Resource.Validate(obj); err != nil {
+		return reconcile.Result{}, err
+	}
+
+	owner, err := r.Resource.Owner(ctx, r.Client, obj)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	span.SetAttributes(
+		tracing.AttributeProjectName.String(owner.ProjectName),
+		tracing.AttributeShootUID.String(owner.ShootUID),
+	)
+
+	if r.Shard != nil && !r.Shard.IsLocal(owner.ProjectName) {
+		log.V(1).Info("Removing entry from store - project is owned by another shard", "project", owner.ProjectName)
+		r.delete(req, owner.Key(), store.Planned())
+		return reconcile.Result{}, nil
+	}
+
+	project := &gardencorev1beta1.Project{ObjectMeta: metav1.ObjectMeta{Name: owner.ProjectName}}
+	if err := r.Client.Get(ctx, client.ObjectKeyFromObject(project), project); err != nil {
+		if apierrors.IsNotFound(err) {
+			return reconcile.Result{}, Reject("project not found", nil, "project", owner.ProjectName)
+		}
+		return reconcile.Result{}, err
+	}
+
+	if project.Spec.Namespace == nil {
+		return reconcile.Result{}, Reject("project spec.namespace is nil", nil, "project", owner.ProjectName)
+	}
+
+	if owner.ShootNamespace != *project.Spec.Namespace {
+		return reconcile.Result{}, Reject("shoot namespace does not match project namespace", nil, "project", owner.ProjectName)
+	}
+
+	shoot := &gardencorev1beta1.Shoot{ObjectMeta: metav1.ObjectMeta{
+		Name:      owner.ShootName,
+		Namespace: owner.ShootNamespace,
+	}}
+	if err := r.Client.Get(ctx, client.ObjectKeyFromObject(shoot), shoot); err != nil {
+		if apierrors.IsNotFound(err) {
+			return reconcile.Result{}, Reject("shoot not found", nil, "shoot", client.ObjectKeyFromObject(shoot))
+		}
+		return reconcile.Result{}, err
+	}
+
+	if owner.ShootUID != string(shoot.UID) {
+		return reconcile.Result{}, Reject("shoot UID does not match", nil, "shoot", client.ObjectKeyFromObject(shoot))
+	}
+
+	data, err := r.Resource.Render(obj, shoot, r.Signer)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	opts := []store.Option{store.WithSource(r.sourceReference(req, obj.GetResourceVersion()))}
+	if alias, ok := utils.ShootNameAlias(project, owner.ShootName); ok {
+		opts = append(opts, store.WithAlias(alias))
+	}
+
+	log.Info("Adding entry to store", "shoot", client.ObjectKeyFromObject(shoot))
+	r.write(req, owner.Key(), data, opts...)
+
+	return ctrl.Result{RequeueAfter: r.ResyncPeriod}, nil
+}
+
+// reject logs the rejection and removes the data published from the object.
+// The reason is kept by the store for inspection.
+func (r *Reconciler[O, T]) reject(log logr.Logger, req ctrl.Request, rejection *Rejection) {
+	if rejection.Err != nil {
+		log.Error(rejection.Err, "Removing entry from store - "+rejection.Reason, rejection.KeysAndValues...)
+	} else {
+		log.Info("Removing entry from store - "+rejection.Reason, rejection.KeysAndValues...)
+	}
+	key, _ := r.Resource.Key(req)
+	r.delete(req, key, store.WithReason(rejection.Error()), store.WithSource(r.sourceReference(req, "")))
+}
+
+func (r *Reconciler[O, T]) write(req ctrl.Request, key string, data T, opts ...store.Option) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.mapping[req.String()] = key
+	r.Store.Write(key, data, opts...)
+}
+
+// delete removes the data published from the object, the fallback key is used if it has not been published before.
+func (r *Reconciler[O, T]) delete(req ctrl.Request, fallbackKey string, opts ...store.Option) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	key, ok := r.mapping[req.String()]
+	if !ok {
+		key = fallbackKey
+	}
+	if key == "" {
+		return
+	}
+	r.Store.Delete(key, opts...)
+	delete(r.mapping, req.String())
+}
+
+// init creates the mapping of the reconciled objects to the keys of their entries,
+// it starts with the entries already published from objects of the resource kind.
+func (r *Reconciler[O, T]) init() {
+	r.once.Do(func() {
+		r.mapping = make(map[string]string)
+		if r.Published == nil {
+			return
+		}
+		for _, meta := range r.Published.List("") {
+			if meta.Source == nil || meta.Source.Kind != r.Resource.Kind() {
+				continue
+			}
+			req := reconcile.Request{NamespacedName: types.NamespacedName{Namespace: meta.Source.Namespace, Name: meta.Source.Name}}
+			r.mapping[req.String()] = meta.Key
+			r.published = append(r.published, req)
+		}
+	})
+}
+
+// enqueuePublished enqueues the source objects of the entries published before the reconciler started.
+func (r *Reconciler[O, T]) enqueuePublished(_ context.Context, queue workqueue.TypedRateLimitingInterface[reconcile.Request]) error {
+	r.init()
+	for _, req := range r.published {
+		queue.Add(req)
+	}
+	return nil
+}
+
+func (r *Reconciler[O, T]) name() string {
+	if r.Name != "" {
+		return r.Name
+	}
+	return r.Resource.Name()
+}
+
+func (r *Reconciler[O, T]) sourceReference(req ctrl.Request, resourceVersion string) store.ObjectReference {
+	return store.ObjectReference{Kind: r.Resource.Kind(), Namespace: req.Namespace, Name: req.Name, ResourceVersion: resourceVersion}
+}
+
+// SetupWithManager specifies how the controller is built to watch the source objects of the resource.
+func (r *Reconciler[O, T]) SetupWithManager(mgr ctrl.Manager) error {
+	if r.Client == nil {
+		r.Client = tracing.WrapClient(mgr.GetClient())
+	}
+	r.reader = mgr.GetCache()
+
+	b := builder.ControllerManagedBy(mgr).
+		Named(r.name()).
+		For(r.Resource.NewObject(), builder.WithPredicates(r.predicate())).
+		WithOptions(controller.Options{
+			MaxConcurrentReconciles: 50,
+			RateLimiter: workqueue.NewTypedMaxOfRateLimiter(
+				workqueue.NewTypedItemExponentialFailureRateLimiter[reconcile.Request](5*time.Second, 2*time.Minute),
+				&workqueue.TypedBucketRateLimiter[reconcile.Request]{Limiter: rate.NewLimiter(rate.Limit(10), 100)},
+			),
+			ReconciliationTimeout: controllerutils.DefaultReconciliationTimeout,
+		})
+
+	b = b.Watches(&gardencorev1beta1.Project{}, EnqueueOnAliasOptOut(r.reader, r.Resource), builder.WithPredicates(
+		predicate.NewPredicateFuncs(func(project client.Object) bool { return r.Shard == nil || r.Shard.IsLocal(project.GetName()) }),
+	))
+
+	if r.Published != nil {
+		b = b.WatchesRawSource(source.Func(r.enqueuePublished))
+	}
+	if r.Shard != nil {
+		b = b.WatchesRawSource(sharding.EnqueueOnChange(r.Shard, mgr.GetCache(), r.Resource.NewObjectList, r.Resource.IsRelevant))
+	}
+	// The signatures of the stored documents are renewed as soon as the signer switches to another key.
+	if notifier, ok := r.Signer.(sharding.Notifier); ok {
+		b = b.WatchesRawSource(sharding.EnqueueOnChange(notifier, mgr.GetCache(), r.Resource.NewObjectList, r.Resource.IsRelevant))
+	}
+
+	return b.Complete(r)
+}
+
+// predicate accepts the relevant objects of the projects owned by the local replica.
+// The projects of other replicas are picked up by the source enqueuing all objects when the ownership changes.
+func (r *Reconciler[O, T]) predicate() predicate.Predicate {
+	return predicate.Funcs{
+		CreateFunc: func(e event.CreateEvent) bool { return r.Resource.IsRelevant(e.Object) && !r.isForeign(e.Object) },
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			return (r.Resource.IsRelevant(e.ObjectNew) || r.Resource.IsRelevant(e.ObjectOld)) &&
+				!(r.isForeign(e.ObjectNew) && r.isForeign(e.ObjectOld))
+		},
+		DeleteFunc:  func(e event.DeleteEvent) bool { return r.Resource.IsRelevant(e.Object) && !r.isForeign(e.Object) },
+		GenericFunc: func(_ event.GenericEvent) bool { return false },
+	}
+}
+
+// isForeign reports whether the object belongs to a project owned by another replica.
+// Objects whose owner cannot be resolved are left to the reconciler, so that their rejection is recorded.
+func (r *Reconciler[O, T]) isForeign(obj client.Object) bool {
+	if r.Shard == nil {
+		return false
+	}
+	o, ok := obj.(O)
+	if !ok {
+		return false
+	}
+	owner, err := r.Resource.Owner(context.Background(), r.reader, o)
+	return err == nil && !r.Shard.IsLocal(owner.ProjectName)
+}
@@ -0,0 +1,113 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package publisher
+
+import (
+	"net/http"
+
+	"github.com/go-logr/logr"
+
+	"github.com/gardener/gardener-discovery-server/internal/handler"
+	"github.com/gardener/gardener-discovery-server/internal/store"
+)
+
+// Base paths of the documents of a shoot.
+const (
+	// ShootPath addresses the shoot by its UID.
+	ShootPath = "/projects/{projectName}/shoots/{shootUID}"
+	// AliasPath addresses the shoot by its name.
+	AliasPath = "/projects/{projectName}/shoots/by-name/{shootName}"
+)
+
+// Store is a store the documents are served from.
+type Store[T any] interface {
+	store.Reader[T]
+	store.Resolver
+}
+
+// Registry registers the routes of the documents of published resources.
+type Registry struct {
+	mux      *http.ServeMux
+	policies handler.CachePolicies
+	cors     handler.CORSPolicy
+	wrap     func(path string, h http.Handler) http.Handler
+	prefix   string
+	log      logr.Logger
+
+	aliases  bool
+	redirect bool
+}
+
+// NewRegistry returns a [Registry] registering the routes at the mux.
+func NewRegistry(mux *http.ServeMux, policies handler.CachePolicies, log logr.Logger, opts ...RegistryOption) *Registry {
+	r := &Registry{
+		mux:      mux,
+		policies: policies,
+		wrap:     func(_ string, h http.Handler) http.Handler { return h },
+		log:      log,
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
+}
+
+// Handle registers the handler for the path below the prefix of the registry,
+// it is wrapped like the handlers of the documents.
+func (r *Registry) Handle(path string, h http.Handler) {
+	path = r.prefix + path
+	h = handler.CORS(h, r.log.WithName("cors"), r.cors, http.MethodGet, http.MethodHead)
+	r.mux.Handle(path, r.wrap(path, h))
+}
+
+// Register registers the routes of the documents served from the store.
+// Every document is served below the [ShootPath] and, if enabled, below the [AliasPath].
+func Register[T any](r *Registry, s Store[T], documents ...Document[T]) {
+	aliasLog := r.log.WithName("shoot-name-alias")
+	for _, d := range documents {
+		canonical := d.Handler(s, r.policies, r.log)
+		r.Handle(ShootPath+d.Path, canonical)
+
+		if r.aliases {
+			r.Handle(AliasPath+d.Path, handler.SetHSTS(handler.AllowMethods(
+				handler.AliasRequest(aliasLog, s, r.redirect, canonical),
+				aliasLog, http.MethodGet, http.MethodHead,
+			)))
+		}
+	}
+}
+
+// RegistryOption can be used to configure [Registry].
+type RegistryOption func(*Registry)
+
+// WithWrapper sets the function wrapping the registered handlers, e.g. with instrumentation.
+func WithWrapper(wrap func(path string, h http.Handler) http.Handler) RegistryOption {
+	return func(r *Registry) {
+		r.wrap = wrap
+	}
+}
+
+// WithPathPrefix registers the routes below the prefix, e.g. "/gardens/foo".
+func WithPathPrefix(prefix string) RegistryOption {
+	return func(r *Registry) {
+		r.prefix = prefix
+	}
+}
+
+// WithCORS sets the CORS policy of the registered handlers, cross-origin requests are not allowed by default.
+func WithCORS(policy handler.CORSPolicy) RegistryOption {
+	return func(r *Registry) {
+		r.cors = policy
+	}
+}
+
+// WithShootNameAliases registers the documents below the [AliasPath] as well.
+// If redirect is set, the clients are redirected to the canonical path.
+func WithShootNameAliases(redirect bool) RegistryOption {
+	return func(r *Registry) {
+		r.aliases = true
+		r.redirect = redirect
+	}
+}
@@ -0,0 +1,151 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package publisher_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	logzap "sigs.k8s.io/controller-runtime/pkg/log/zap"
+
+	"github.com/gardener/gardener-discovery-server/internal/handler"
+	"github.com/gardener/gardener-discovery-server/internal/publisher"
+	"github.com/gardener/gardener-discovery-server/internal/store"
+)
+
+var _ = Describe("Registry", func() {
+	const (
+		uid      = "a6475c90-d533-43c4-bbb0-d99200b491b1"
+		shootURI = "/projects/foo/shoots/" + uid + "/doc"
+		aliasURI = "/projects/foo/shoots/by-name/bar/doc"
+	)
+
+	var (
+		s        *store.Store[string]
+		mux      *http.ServeMux
+		wrapped  []string
+		document = publisher.Document[string]{
+			Name:    "doc",
+			Path:    "/doc",
+			Policy:  func(p handler.CachePolicies) handler.CachePolicy { return p.CABundle },
+			Content: func(data string) handler.Content { return handler.Content{Body: []byte(data)} },
+		}
+
+		serve = func(uri string) *httptest.ResponseRecorder {
+			recorder := httptest.NewRecorder()
+			mux.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, uri, nil))
+			return recorder
+		}
+	)
+
+	BeforeEach(func() {
+		s = store.MustNewStore(func(data string) string { return data })
+		s.Write("foo--"+uid, `{"foo":"bar"}`, store.WithAlias("foo--bar"))
+		mux = http.NewServeMux()
+		wrapped = nil
+	})
+
+	newRegistry := func(opts ...publisher.RegistryOption) *publisher.Registry {
+		opts = append(opts, publisher.WithWrapper(func(path string, h http.Handler) http.Handler {
+			wrapped = append(wrapped, path)
+			return h
+		}))
+		return publisher.NewRegistry(mux, handler.DefaultCachePolicies, logzap.New(logzap.WriteTo(GinkgoWriter)), opts...)
+	}
+
+	It("should serve the documents below the shoot path", func() {
+		publisher.Register(newRegistry(), s, document)
+
+		recorder := serve(shootURI)
+		Expect(recorder.Code).To(Equal(http.StatusOK))
+		Expect(recorder.Body.String()).To(Equal(`{"foo":"bar"}`))
+		Expect(recorder.Header().Get("Strict-Transport-Security")).ToNot(BeEmpty())
+		Expect(wrapped).To(ConsistOf(publisher.ShootPath + "/doc"))
+
+		Expect(serve(aliasURI).Code).To(Equal(http.StatusNotFound))
+	})
+
+	It("should serve the documents below the alias path", func() {
+		publisher.Register(newRegistry(publisher.WithShootNameAliases(false)), s, document)
+
+		recorder := serve(aliasURI)
+		Expect(recorder.Code).To(Equal(http.StatusOK))
+		Expect(recorder.Body.String()).To(Equal(`{"foo":"bar"}`))
+		Expect(wrapped).To(ConsistOf(publisher.ShootPath+"/doc", publisher.AliasPath+"/doc"))
+	})
+
+	It("should redirect the alias path to the shoot path", func() {
+		publisher.Register(newRegistry(publisher.WithShootNameAliases(true)), s, document)
+
+		recorder := serve(aliasURI)
+		Expect(recorder.Code).To(Equal(http.StatusTemporaryRedirect))
+		Expect(recorder.Header().Get("Location")).To(Equal(shootURI))
+	})
+
+	It("should register the routes below the prefix", func() {
+		publisher.Register(newRegistry(publisher.WithPathPrefix("/gardens/foo"), publisher.WithShootNameAliases(true)), s, document)
+
+		Expect(serve("/gardens/foo" + shootURI).Code).To(Equal(http.StatusOK))
+		Expect(serve(shootURI).Code).To(Equal(http.StatusNotFound))
+
+		recorder := serve("/gardens/foo" + aliasURI)
+		Expect(recorder.Code).To(Equal(http.StatusTemporaryRedirect))
+		Expect(recorder.Header().Get("Location")).To(Equal("/gardens/foo" + shootURI))
+		Expect(wrapped).To(ConsistOf("/gardens/foo"+publisher.ShootPath+"/doc", "/gardens/foo"+publisher.AliasPath+"/doc"))
+	})
+
+	It("should set the CORS headers", func() {
+		publisher.Register(newRegistry(publisher.WithCORS(handler.CORSPolicy{AllowedOrigins: []string{"*"}})), s, document)
+
+		req := httptest.NewRequest(http.MethodGet, shootURI, nil)
+		req.Header.Set("Origin", "https://portal.example.com")
+		recorder := httptest.NewRecorder()
+		mux.ServeHTTP(recorder, req)
+		Expect(recorder.Code).To(Equal(http.StatusOK))
+		Expect(recorder.Header().Get("Access-Control-Allow-Origin")).To(Equal("*"))
+
+		req = httptest.NewRequest(http.MethodOptions, shootURI, nil)
+		req.Header.Set("Origin", "https://portal.example.com")
+		req.Header.Set("Access-Control-Request-Method", http.MethodGet)
+		recorder = httptest.NewRecorder()
+		mux.ServeHTTP(recorder, req)
+		Expect(recorder.Code).To(Equal(http.StatusNoContent))
+		Expect(recorder.Header().Get("Access-Control-Allow-Methods")).To(Equal("GET, HEAD"))
+	})
+
+	It("should not set the CORS headers by default", func() {
+		publisher.Register(newRegistry(), s, document)
+
+		req := httptest.NewRequest(http.MethodGet, shootURI, nil)
+		req.Header.Set("Origin", "https://portal.example.com")
+		recorder := httptest.NewRecorder()
+		mux.ServeHTTP(recorder, req)
+		Expect(recorder.Code).To(Equal(http.StatusOK))
+		Expect(recorder.Header()).ToNot(HaveKey("Access-Control-Allow-Origin"))
+	})
+
+	It("should wrap additional handlers", func() {
+		newRegistry().Handle("/foo", http.NotFoundHandler())
+
+		Expect(wrapped).To(ConsistOf("/foo"))
+		Expect(serve("/foo").Code).To(Equal(http.StatusNotFound))
+	})
+})
+
+var _ = Describe("Rejection", func() {
+	It("should format the reason and unwrap the cause", func() {
+		cause := errors.New("boom")
+		err := publisher.Reject("invalid object", cause, "key", "value")
+
+		var rejection *publisher.Rejection
+		Expect(errors.As(err, &rejection)).To(BeTrue())
+		Expect(rejection.Reason).To(Equal("invalid object"))
+		Expect(err).To(MatchError("invalid object, key=value: boom"))
+		Expect(errors.Is(err, cause)).To(BeTrue())
+	})
+})
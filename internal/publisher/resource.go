@@ -0,0 +1,112 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package publisher
+
+import (
+	"context"
+	"net/http"
+
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	"github.com/go-logr/logr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/gardener/gardener-discovery-server/internal/handler"
+	"github.com/gardener/gardener-discovery-server/internal/signing"
+	"github.com/gardener/gardener-discovery-server/internal/store"
+	"github.com/gardener/gardener-discovery-server/internal/utils"
+)
+
+// PublishedResource describes a document type which is published from objects of type O in the garden cluster.
+// The rendered data of type T is written to a store under the key projectName--shootUID and its documents are served from there.
+//
+// The framework covers the reconciliation of the source objects and the routes of the documents registered with [Register].
+// A resource is still published by the garden setup of the discovery server, which also owns the options enabling it.
+// Documents composed of the data of several resources, e.g. the cluster info, are written to a store of their own
+// by a composer and registered with [Register] as well.
+type PublishedResource[O client.Object, T any] interface {
+	// Name is the name of the resource, it is used as controller name.
+	Name() string
+	// Kind is the kind of the source objects.
+	Kind() string
+	// NewObject returns an empty source object.
+	NewObject() O
+	// NewObjectList returns an empty list of source objects.
+	NewObjectList() client.ObjectList
+	// ProjectListOptions returns the options restricting a list of source objects to the ones of the project.
+	// It is only called for projects with a namespace.
+	ProjectListOptions(project *gardencorev1beta1.Project) []client.ListOption
+	// IsRelevant reports whether changes of the object should be reconciled.
+	IsRelevant(obj client.Object) bool
+	// Key returns the store key of the object if it can be derived from the request alone.
+	// It is used to record rejections of objects which have never been published.
+	Key(req ctrl.Request) (string, bool)
+	// Validate checks the source object before its owner is resolved.
+	Validate(obj O) error
+	// Owner returns the shoot the object belongs to.
+	Owner(ctx context.Context, c client.Reader, obj O) (Owner, error)
+	// Render validates the content of the object and renders the data written to the store.
+	// The signer may be nil, then the data is not signed.
+	Render(obj O, shoot *gardencorev1beta1.Shoot, signer signing.Signer) (T, error)
+	// Documents returns the documents served from the rendered data.
+	Documents() []Document[T]
+}
+
+// Owner identifies the shoot a source object belongs to.
+type Owner struct {
+	ProjectName    string
+	ShootName      string
+	ShootNamespace string
+	ShootUID       string
+}
+
+// Key returns the store key of the owner.
+func (o Owner) Key() string {
+	return o.ProjectName + "--" + o.ShootUID
+}
+
+// Rejection is returned by a [PublishedResource] if an object must not be published.
+// The data published from the object is removed from the store and the reconciliation is not retried.
+type Rejection struct {
+	Reason        string
+	Err           error
+	KeysAndValues []any
+}
+
+// Reject returns a [Rejection] with the reason, the cause and the key value pairs that should be logged.
+func Reject(reason string, err error, keysAndValues ...any) error {
+	return &Rejection{Reason: reason, Err: err, KeysAndValues: keysAndValues}
+}
+
+func (r *Rejection) Error() string {
+	return utils.RejectionReason(r.Reason, r.Err, r.KeysAndValues...)
+}
+
+func (r *Rejection) Unwrap() error {
+	return r.Err
+}
+
+// Document is a document served from the data of a store.
+type Document[T any] struct {
+	// Name is used as name of the logger.
+	Name string
+	// Path is the path of the document relative to the shoot path, e.g. "/issuer/jwks".
+	Path string
+	// Policy selects the cache policy of the document.
+	Policy func(handler.CachePolicies) handler.CachePolicy
+	// Content extracts the document from the data.
+	Content func(T) handler.Content
+}
+
+// Handler returns the handler serving the document from the store.
+// It requires "projectName" and "shootUID" as path parameters.
+func (d Document[T]) Handler(reader store.Reader[T], policies handler.CachePolicies, log logr.Logger) http.Handler {
+	log = log.WithName(d.Name)
+	return handler.SetHSTS(
+		handler.AllowMethods(handler.StoreRequest(log, reader, d.Policy(policies), d.Content),
+			log, http.MethodGet, http.MethodHead,
+		),
+	)
+}
@@ -13,12 +13,13 @@ import (
 	logzap "sigs.k8s.io/controller-runtime/pkg/log/zap"
 
 	"github.com/gardener/gardener-discovery-server/internal/handler"
-	certhandler "github.com/gardener/gardener-discovery-server/internal/handler/certificate"
+	"github.com/gardener/gardener-discovery-server/internal/publisher"
+	certreconciler "github.com/gardener/gardener-discovery-server/internal/reconciler/certificate"
 	"github.com/gardener/gardener-discovery-server/internal/store"
 	certstore "github.com/gardener/gardener-discovery-server/internal/store/certificate"
 )
 
-var _ = Describe("#HttpHandlerCertificate", func() {
+var _ = Describe("#Documents", func() {
 	var (
 		s *store.Store[certstore.Data]
 
@@ -27,8 +28,7 @@ var _ = Describe("#HttpHandlerCertificate", func() {
 		uid1 = "a6475c90-d533-43c4-bbb0-d99200b491b1"
 		uid2 = "1e4914ca-c837-451d-a1cf-c559d131cb57"
 
-		certHandler *certhandler.Handler
-		mux         *http.ServeMux
+		mux *http.ServeMux
 	)
 
 	BeforeEach(func() {
@@ -41,9 +41,8 @@ var _ = Describe("#HttpHandlerCertificate", func() {
 		})
 
 		log := logzap.New(logzap.WriteTo(GinkgoWriter))
-		certHandler = certhandler.New(s, log)
 		mux = http.NewServeMux()
-		mux.Handle("/projects/{projectName}/shoots/{shootUID}/cluster-ca", certHandler.HandleCABundle())
+		publisher.Register(publisher.NewRegistry(mux, handler.DefaultCachePolicies, log), s, certreconciler.Resource{}.Documents()...)
 		mux.Handle("/", handler.NotFound(log))
 	})
 
@@ -68,6 +67,8 @@ var _ = Describe("#HttpHandlerCertificate", func() {
 			200,
 			[]byte("bundle1"),
 			map[string]string{
+				"Vary":                      "Accept-Encoding",
+				"Content-Length":            "7",
 				"Strict-Transport-Security": "max-age=31536000; includeSubDomains",
 				"Content-Type":              "application/json",
 				"Cache-Control":             "public, max-age=3600",
@@ -80,6 +81,8 @@ var _ = Describe("#HttpHandlerCertificate", func() {
 			200,
 			[]byte("bundle2"),
 			map[string]string{
+				"Vary":                      "Accept-Encoding",
+				"Content-Length":            "7",
 				"Strict-Transport-Security": "max-age=31536000; includeSubDomains",
 				"Content-Type":              "application/json",
 				"Cache-Control":             "public, max-age=3600",
@@ -90,10 +93,11 @@ var _ = Describe("#HttpHandlerCertificate", func() {
 			http.MethodGet,
 			"https://abc.def/projects/not-existent/shoots/1e4914ca-c837-451d-a1cf-c559d131cb57/cluster-ca",
 			404,
-			[]byte(`{"code":404,"message":"not found"}`),
+			[]byte(`{"type":"about:blank","title":"Not Found","status":404,"detail":"not found","instance":"/projects/not-existent/shoots/1e4914ca-c837-451d-a1cf-c559d131cb57/cluster-ca","code":404,"message":"not found"}`+"\n"),
 			map[string]string{
+				"Cache-Control":             "no-store",
 				"Strict-Transport-Security": "max-age=31536000; includeSubDomains",
-				"Content-Type":              "application/json",
+				"Content-Type":              "application/problem+json",
 			},
 		),
 		Entry(
@@ -101,10 +105,11 @@ var _ = Describe("#HttpHandlerCertificate", func() {
 			http.MethodGet,
 			"https://abc.def/projects/not-existent/shoots/not-a-uuid/cluster-ca",
 			400,
-			[]byte(`{"code":400,"message":"invalid UID"}`),
+			[]byte(`{"type":"about:blank","title":"Bad Request","status":400,"detail":"invalid UID","instance":"/projects/not-existent/shoots/not-a-uuid/cluster-ca","code":400,"message":"invalid UID"}`+"\n"),
 			map[string]string{
+				"Cache-Control":             "no-store",
 				"Strict-Transport-Security": "max-age=31536000; includeSubDomains",
-				"Content-Type":              "application/json",
+				"Content-Type":              "application/problem+json",
 			},
 		),
 		Entry(
@@ -112,9 +117,10 @@ var _ = Describe("#HttpHandlerCertificate", func() {
 			http.MethodGet,
 			"https://abc.def/does-not-exist",
 			404,
-			[]byte(`{"code":404,"message":"not found"}`),
+			[]byte(`{"type":"about:blank","title":"Not Found","status":404,"detail":"not found","instance":"/does-not-exist","code":404,"message":"not found"}`+"\n"),
 			map[string]string{
-				"Content-Type": "application/json",
+				"Cache-Control": "no-store",
+				"Content-Type":  "application/problem+json",
 			},
 		),
 		Entry(
@@ -122,10 +128,11 @@ var _ = Describe("#HttpHandlerCertificate", func() {
 			http.MethodPost,
 			"https://abc.def/projects/foo/shoots/1e4914ca-c837-451d-a1cf-c559d131cb57/cluster-ca",
 			405,
-			[]byte(`{"code":405,"message":"method not allowed"}`),
+			[]byte(`{"type":"about:blank","title":"Method Not Allowed","status":405,"detail":"method not allowed","instance":"/projects/foo/shoots/1e4914ca-c837-451d-a1cf-c559d131cb57/cluster-ca","code":405,"message":"method not allowed"}`+"\n"),
 			map[string]string{
+				"Cache-Control":             "no-store",
 				"Strict-Transport-Security": "max-age=31536000; includeSubDomains",
-				"Content-Type":              "application/json",
+				"Content-Type":              "application/problem+json",
 			},
 		),
 	)
@@ -0,0 +1,72 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package certificate_test
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+
+	certificatereconciler "github.com/gardener/gardener-discovery-server/internal/reconciler/certificate"
+	"github.com/gardener/gardener-discovery-server/internal/utils"
+)
+
+func FuzzRender(f *testing.F) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		f.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "ca"},
+		NotBefore:             time.Now(),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		f.Fatal(err)
+	}
+	ca := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+
+	f.Add(ca)
+	f.Add(ca + ca)
+	f.Add("\n" + ca + "\n\n" + ca + "\n")
+	f.Add(ca + "garbage")
+	f.Add("garbage\n" + ca)
+	f.Add("-----BEGIN CERTIFICATE-----\n!!!\n" + ca)
+	f.Add("-----BEGIN CERTIFICATE-----\n-----END CERTIFICATE-----\n")
+	f.Add("")
+
+	f.Fuzz(func(t *testing.T, bundle string) {
+		configmap := &corev1.ConfigMap{Data: map[string]string{"ca.crt": bundle}}
+
+		data, err := certificatereconciler.Resource{}.Render(configmap, nil, nil)
+		if err != nil {
+			return
+		}
+		if len(bundle) > utils.MaxDocumentSize {
+			t.Fatalf("accepted bundle of %d bytes", len(bundle))
+		}
+		trimmed := bytes.TrimSpace([]byte(bundle))
+		if !bytes.HasPrefix(trimmed, []byte("-----BEGIN CERTIFICATE-----")) || !bytes.HasSuffix(trimmed, []byte("-----END CERTIFICATE-----")) {
+			t.Fatalf("accepted data outside of the PEM blocks: %q", bundle)
+		}
+		if len(data.CABundle) == 0 {
+			t.Fatal("rendered empty CA bundle")
+		}
+	})
+}
@@ -5,12 +5,12 @@
 package certificate
 
 import (
+	"bytes"
 	"context"
 	"crypto/x509"
 	"encoding/json"
 	"encoding/pem"
-	"sync"
-	"time"
+	"fmt"
 
 	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
 	v1beta1constants "github.com/gardener/gardener/pkg/apis/core/v1beta1/constants"
@@ -20,226 +20,197 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
-	logf "sigs.k8s.io/controller-runtime/pkg/log"
-	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
-	"github.com/gardener/gardener-discovery-server/internal/store"
+	"github.com/gardener/gardener-discovery-server/internal/handler"
+	"github.com/gardener/gardener-discovery-server/internal/precompress"
+	"github.com/gardener/gardener-discovery-server/internal/publisher"
+	"github.com/gardener/gardener-discovery-server/internal/signing"
 	"github.com/gardener/gardener-discovery-server/internal/store/certificate"
+	"github.com/gardener/gardener-discovery-server/internal/utils"
 )
 
-// Reconciler reconciles configmap objects that contain shoot CA.
-type Reconciler struct {
-	once         sync.Once
-	storeMapping map[string]string
-	mutex        sync.Mutex
+// ControllerName is the name of the shoot CA controller.
+const ControllerName = "shoot-ca"
 
-	Client       client.Client
-	ResyncPeriod time.Duration
-	Store        store.Writer[certificate.Data]
-}
+// Resource publishes the CA bundle from configmaps that contain shoot CA.
+type Resource struct{}
 
-// Reconcile retrieves the CA bundle info from a configmap and stores into cache.
-func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
-	var (
-		log        = logf.FromContext(ctx)
-		mappingKey = req.String()
-	)
+var _ publisher.PublishedResource[*corev1.ConfigMap, certificate.Data] = Resource{}
 
-	r.init()
+// Name implements [publisher.PublishedResource].
+func (Resource) Name() string { return ControllerName }
 
-	configmap := &corev1.ConfigMap{}
-	if err := r.Client.Get(ctx, req.NamespacedName, configmap); err != nil {
-		if apierrors.IsNotFound(err) {
-			log.Info("Removing certificates from store - configmap not found")
-			r.deleteMapping(mappingKey)
+// Kind implements [publisher.PublishedResource].
+func (Resource) Kind() string { return "ConfigMap" }
 
-			return reconcile.Result{}, nil
-		}
-		return reconcile.Result{}, err
-	}
+// NewObject implements [publisher.PublishedResource].
+func (Resource) NewObject() *corev1.ConfigMap { return &corev1.ConfigMap{} }
 
-	if configmap.DeletionTimestamp != nil {
-		log.Info("Removing certificates from store - deletion timestamp present")
-		r.deleteMapping(mappingKey)
+// NewObjectList implements [publisher.PublishedResource].
+func (Resource) NewObjectList() client.ObjectList { return &corev1.ConfigMapList{} }
 
-		return reconcile.Result{}, nil
-	}
+// ProjectListOptions implements [publisher.PublishedResource].
+// The configmaps are kept in the namespace of the project.
+func (Resource) ProjectListOptions(project *gardencorev1beta1.Project) []client.ListOption {
+	return []client.ListOption{client.InNamespace(*project.Spec.Namespace)}
+}
 
-	var (
-		data string
-		ok   bool
-	)
+// IsRelevant implements [publisher.PublishedResource].
+func (Resource) IsRelevant(obj client.Object) bool {
+	configmap, ok := obj.(*corev1.ConfigMap)
+	if !ok {
+		return false
+	}
+	// we only allow update-restricted resources
+	// it is not safe to read data from resources that might be modified by users
+	return configmap.Labels != nil &&
+		configmap.Labels[v1beta1constants.LabelDiscoveryPublic] == v1beta1constants.DiscoveryShootCA &&
+		configmap.Labels[v1beta1constants.LabelUpdateRestriction] == "true"
+}
 
-	if data, ok = configmap.Data[secretsutils.DataKeyCertificateCA]; !ok || len(data) == 0 {
-		log.Info("Removing certificates from store - configmap is missing data key", "key", secretsutils.DataKeyCertificateCA)
-		r.deleteMapping(mappingKey)
+// Key implements [publisher.PublishedResource].
+// The shoot is referenced by labels, so the key cannot be derived from the request.
+func (Resource) Key(ctrl.Request) (string, bool) {
+	return "", false
+}
 
-		return reconcile.Result{}, nil
+// Validate implements [publisher.PublishedResource].
+func (Resource) Validate(configmap *corev1.ConfigMap) error {
+	if data, ok := configmap.Data[secretsutils.DataKeyCertificateCA]; !ok || len(data) == 0 {
+		return publisher.Reject("configmap is missing data key", nil, "key", secretsutils.DataKeyCertificateCA)
 	}
 
-	if configmap.Labels["discovery.gardener.cloud/public"] != "shoot-ca" ||
-		configmap.Labels["gardener.cloud/update-restriction"] != "true" {
-		log.Info("Removing certificates from store - configmap does not have expected labels or their value is incorrect")
-		r.deleteMapping(mappingKey)
-
-		return reconcile.Result{}, nil
+	if configmap.Labels[v1beta1constants.LabelDiscoveryPublic] != v1beta1constants.DiscoveryShootCA ||
+		configmap.Labels[v1beta1constants.LabelUpdateRestriction] != "true" {
+		return publisher.Reject("configmap does not have expected labels or their value is incorrect", nil)
 	}
+	return nil
+}
 
-	namespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: req.Namespace}}
-	if err := r.Client.Get(ctx, client.ObjectKeyFromObject(namespace), namespace); err != nil {
+// Owner implements [publisher.PublishedResource].
+// The project is referenced by the label of the namespace and the shoot by the labels of the configmap.
+func (Resource) Owner(ctx context.Context, c client.Reader, configmap *corev1.ConfigMap) (publisher.Owner, error) {
+	namespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: configmap.Namespace}}
+	if err := c.Get(ctx, client.ObjectKeyFromObject(namespace), namespace); err != nil {
 		if apierrors.IsNotFound(err) {
-			log.Info("Removing certificates from store - namespace not found")
-			r.deleteMapping(mappingKey)
-
-			return reconcile.Result{}, nil
+			return publisher.Owner{}, publisher.Reject("namespace not found", nil)
 		}
-		return reconcile.Result{}, err
+		return publisher.Owner{}, err
 	}
 
-	var (
-		shootName   string
-		shootUID    string
-		projectName string
-	)
-
-	projectName, ok = namespace.Labels[v1beta1constants.ProjectName]
+	projectName, ok := namespace.Labels[v1beta1constants.ProjectName]
 	if !ok {
-		log.Info("Removing certificates from store - namespace does not have expected label", "label", v1beta1constants.ProjectName)
-		r.deleteMapping(mappingKey)
-		return reconcile.Result{}, nil
+		return publisher.Owner{}, publisher.Reject("namespace does not have expected label", nil, "label", v1beta1constants.ProjectName)
 	}
 
-	project := &gardencorev1beta1.Project{ObjectMeta: metav1.ObjectMeta{Name: projectName}}
-	if err := r.Client.Get(ctx, client.ObjectKeyFromObject(project), project); err != nil {
-		if apierrors.IsNotFound(err) {
-			log.Info("Removing certificates from store - project not found", "project", projectName)
-			r.deleteMapping(mappingKey)
+	shootName, ok := configmap.Labels[v1beta1constants.LabelShootName]
+	if !ok {
+		return publisher.Owner{}, publisher.Reject("configmap does not have expected label", nil, "label", v1beta1constants.LabelShootName)
+	}
 
-			return reconcile.Result{}, nil
-		}
-		return reconcile.Result{}, err
+	shootUID, ok := configmap.Labels[v1beta1constants.ShootUID]
+	if !ok {
+		return publisher.Owner{}, publisher.Reject("configmap does not have expected label", nil, "label", v1beta1constants.ShootUID)
 	}
 
-	if project.Spec.Namespace == nil {
-		log.Info("Removing certificates from store - project spec.namespace is nil", "project", projectName)
-		r.deleteMapping(mappingKey)
+	return publisher.Owner{
+		ProjectName:    projectName,
+		ShootName:      shootName,
+		ShootNamespace: namespace.Name,
+		ShootUID:       shootUID,
+	}, nil
+}
 
-		return reconcile.Result{}, nil
+// Render implements [publisher.PublishedResource].
+func (Resource) Render(configmap *corev1.ConfigMap, _ *gardencorev1beta1.Shoot, signer signing.Signer) (certificate.Data, error) {
+	data := configmap.Data[secretsutils.DataKeyCertificateCA]
+	parsed, err := parseCABundle([]byte(data))
+	if err != nil {
+		return certificate.Data{}, err
 	}
 
-	if *project.Spec.Namespace != namespace.Name {
-		log.Info("Removing certificates from store - namespace name does not match project namespace", "project", projectName)
-		r.deleteMapping(mappingKey)
+	bundle := struct {
+		Certs string `json:"certs"`
+	}{Certs: data}
 
-		return reconcile.Result{}, nil
+	payload, err := json.Marshal(bundle)
+	if err != nil {
+		return certificate.Data{}, err
 	}
 
-	shootName, ok = configmap.Labels[v1beta1constants.LabelShootName]
-	if !ok {
-		log.Info("Removing certificates from store - configmap does not have expected label", "label", v1beta1constants.LabelShootName)
-		r.deleteMapping(mappingKey)
-		return reconcile.Result{}, nil
+	signature, err := signing.Sign(signer, payload)
+	if err != nil {
+		return certificate.Data{}, fmt.Errorf("failed to sign CA bundle: %w", err)
 	}
+	return certificate.Data{
+		CABundle:           payload,
+		CompressedCABundle: precompress.Compress(payload),
+		Expires:            utils.CertificatesExpiration(parsed),
+		CABundleSignature:  signature,
+	}, nil
+}
 
-	shootUID, ok = configmap.Labels[v1beta1constants.ShootUID]
-	if !ok {
-		log.Info("Removing certificates from store - configmap does not have expected label", "label", v1beta1constants.ShootUID)
-		r.deleteMapping(mappingKey)
-		return reconcile.Result{}, nil
+// parseCABundle parses the PEM encoded CA certificates of the bundle.
+// Anything but whitespace between the certificates is rejected, so is a bundle without certificates.
+func parseCABundle(data []byte) ([]*x509.Certificate, error) {
+	if len(data) > utils.MaxDocumentSize {
+		return nil, publisher.Reject("CA bundle is too large", utils.ErrDocumentTooLarge)
 	}
 
-	shoot := &gardencorev1beta1.Shoot{ObjectMeta: metav1.ObjectMeta{
-		Name:      shootName,
-		Namespace: req.Namespace,
-	}}
-	if err := r.Client.Get(ctx, client.ObjectKeyFromObject(shoot), shoot); err != nil {
-		if apierrors.IsNotFound(err) {
-			log.Info("Removing certificates from store - shoot not found", "shoot", shoot)
-			r.deleteMapping(mappingKey)
-
-			return reconcile.Result{}, nil
+	var (
+		parsed []*x509.Certificate
+		rest   = bytes.TrimSpace(data)
+	)
+	for len(rest) > 0 {
+		if !bytes.HasPrefix(rest, pemBegin) {
+			return nil, publisher.Reject("unexpected data outside of PEM blocks", nil)
 		}
-		return reconcile.Result{}, err
-	}
 
-	if shootUID != string(shoot.UID) {
-		log.Info("Removing certificates from store - shoot UID is different in spec and configmap label", "shoot", shoot)
-		r.deleteMapping(mappingKey)
-		return reconcile.Result{}, nil
-	}
-
-	certs := []byte(data)
-	for {
-		block, rest := pem.Decode(certs)
-		if block == nil {
-			break
+		block, next := pem.Decode(rest)
+		// pem.Decode skips malformed blocks, so the decoded block has to be the first one.
+		if block == nil || bytes.Count(rest[:len(rest)-len(next)], pemBegin) != 1 {
+			return nil, publisher.Reject("failed to decode PEM block", nil)
 		}
 
 		if block.Type != "CERTIFICATE" {
-			log.Info("Removing certificates from store - block type is not CERTIFICATE")
-			r.deleteMapping(mappingKey)
-			return reconcile.Result{}, nil
+			return nil, publisher.Reject("block type is not CERTIFICATE", nil)
 		}
 
 		if len(block.Headers) > 0 {
-			log.Info("Removing certificates from store - block headers are not expected")
-			r.deleteMapping(mappingKey)
-			return reconcile.Result{}, nil
+			return nil, publisher.Reject("block headers are not expected", nil)
 		}
 
 		cert, err := x509.ParseCertificate(block.Bytes)
 		if err != nil {
-			log.Error(err, "Removing certificates from store - failed to parse certificate")
-			r.deleteMapping(mappingKey)
-			return reconcile.Result{}, nil
+			return nil, publisher.Reject("failed to parse certificate", err)
 		}
 
 		if !cert.IsCA {
-			log.Info("Removing certificates from store - certificate is not a CA")
-			r.deleteMapping(mappingKey)
-			return reconcile.Result{}, nil
+			return nil, publisher.Reject("certificate is not a CA", nil)
 		}
 
-		certs = rest
+		parsed = append(parsed, cert)
+		rest = bytes.TrimSpace(next)
 	}
 
-	// Finally write the certificates to store
-	log.Info("Adding certificates to store", "shoot", shoot)
-	bundle := struct {
-		Certs string `json:"certs"`
-	}{Certs: data}
-
-	payload, err := json.Marshal(bundle)
-	if err != nil {
-		r.deleteMapping(mappingKey)
-		return ctrl.Result{}, err
+	if len(parsed) == 0 {
+		return nil, publisher.Reject("CA bundle does not contain any certificate", nil)
 	}
-
-	r.createMapping(mappingKey, projectName+"--"+shootUID, payload)
-
-	return ctrl.Result{RequeueAfter: r.ResyncPeriod}, nil
+	return parsed, nil
 }
 
-func (r *Reconciler) init() {
-	r.once.Do(func() {
-		r.storeMapping = make(map[string]string)
-	})
-}
-
-func (r *Reconciler) createMapping(mapKey, dataKey string, data []byte) {
-	r.mutex.Lock()
-	defer r.mutex.Unlock()
-
-	r.storeMapping[mapKey] = dataKey
-	r.Store.Write(dataKey, certificate.Data{
-		CABundle: data,
-	})
-}
-
-func (r *Reconciler) deleteMapping(key string) {
-	r.mutex.Lock()
-	defer r.mutex.Unlock()
-
-	r.Store.Delete(r.storeMapping[key])
-	delete(r.storeMapping, key)
+var pemBegin = []byte("-----BEGIN ")
+
+// Documents implements [publisher.PublishedResource].
+func (Resource) Documents() []publisher.Document[certificate.Data] {
+	return []publisher.Document[certificate.Data]{
+		{
+			Name:   "cluster-ca",
+			Path:   "/cluster-ca",
+			Policy: func(p handler.CachePolicies) handler.CachePolicy { return p.CABundle },
+			Content: func(data certificate.Data) handler.Content {
+				return handler.Content{Body: data.CABundle, Variants: data.CompressedCABundle, Signature: data.CABundleSignature}
+			},
+		},
+	}
 }
@@ -33,7 +33,10 @@ import (
 	logzap "sigs.k8s.io/controller-runtime/pkg/log/zap"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
+	"github.com/gardener/gardener-discovery-server/internal/precompress"
+	"github.com/gardener/gardener-discovery-server/internal/publisher"
 	certreconciler "github.com/gardener/gardener-discovery-server/internal/reconciler/certificate"
+	"github.com/gardener/gardener-discovery-server/internal/sharding"
 	"github.com/gardener/gardener-discovery-server/internal/store"
 	certstore "github.com/gardener/gardener-discovery-server/internal/store/certificate"
 )
@@ -47,7 +50,7 @@ var _ = Describe("#ReconcileCertificate", func() {
 	)
 
 	var (
-		reconciler *certreconciler.Reconciler
+		reconciler *publisher.Reconciler[*corev1.ConfigMap, certstore.Data]
 
 		c client.Client
 		s *store.Store[certstore.Data]
@@ -67,7 +70,9 @@ var _ = Describe("#ReconcileCertificate", func() {
 		expectStoreEntry = func(store *store.Store[certstore.Data], key string, want certstore.Data) {
 			got, ok := store.Read(key)
 			Expect(ok).To(BeTrue())
-			Expect(got).To(Equal(want))
+			Expect(got.CABundle).To(Equal(want.CABundle))
+			Expect(got.CompressedCABundle).To(Equal(precompress.Compress(want.CABundle)))
+			Expect(got.Expires).To(BeTemporally("~", time.Now().AddDate(0, 0, 3), time.Minute))
 		}
 
 		generateCA = func() ([]byte, error) {
@@ -174,10 +179,11 @@ var _ = Describe("#ReconcileCertificate", func() {
 
 		s = store.MustNewStore(certstore.Copy)
 
-		reconciler = &certreconciler.Reconciler{
+		reconciler = &publisher.Reconciler[*corev1.ConfigMap, certstore.Data]{
 			ResyncPeriod: resyncPeriod,
 			Client:       c,
 			Store:        s,
+			Resource:     certreconciler.Resource{},
 		}
 
 	})
@@ -198,6 +204,52 @@ var _ = Describe("#ReconcileCertificate", func() {
 		})
 	})
 
+	It("should remove entries published before the reconciler started once their source object is gone", func() {
+		s.Write(storeKey, certstore.Data{CABundle: expectedBundleBytes}, store.WithSource(store.ObjectReference{
+			Kind:      "ConfigMap",
+			Namespace: configmap.Namespace,
+			Name:      configmap.Name,
+		}))
+		reconciler.Published = s
+
+		_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: configmapNamespacedName})
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(s.Len()).To(Equal(0))
+		rejection, ok := s.Rejection(storeKey)
+		Expect(ok).To(BeTrue())
+		Expect(rejection.Reason).To(Equal("configmap not found"))
+	})
+
+	It("should sign the CA bundle", func() {
+		reconciler.Signer = &prefixSigner{}
+		Expect(c.Create(ctx, namespace)).To(Succeed())
+		Expect(c.Create(ctx, project)).To(Succeed())
+		Expect(c.Create(ctx, shoot)).To(Succeed())
+		Expect(c.Create(ctx, configmap)).To(Succeed())
+
+		_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: configmapNamespacedName})
+		Expect(err).ToNot(HaveOccurred())
+
+		data, ok := s.Read(storeKey)
+		Expect(ok).To(BeTrue())
+		Expect(data.CABundleSignature).To(Equal("signed:" + string(expectedBundleBytes)))
+	})
+
+	It("should resolve the entry by shoot name", func() {
+		Expect(c.Create(ctx, namespace)).To(Succeed())
+		Expect(c.Create(ctx, project)).To(Succeed())
+		Expect(c.Create(ctx, shoot)).To(Succeed())
+		Expect(c.Create(ctx, configmap)).To(Succeed())
+
+		_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: configmapNamespacedName})
+		Expect(err).ToNot(HaveOccurred())
+
+		key, ok := s.Resolve(project.Name + "--" + shoot.Name)
+		Expect(ok).To(BeTrue())
+		Expect(key).To(Equal(storeKey))
+	})
+
 	It("should write double certificate entry to store", func() {
 		Expect(c.Create(ctx, namespace)).To(Succeed())
 		Expect(c.Create(ctx, project)).To(Succeed())
@@ -231,6 +283,28 @@ var _ = Describe("#ReconcileCertificate", func() {
 		})
 	})
 
+	It("should remove entry from store without rejection when the project is owned by another shard", func() {
+		Expect(c.Create(ctx, namespace)).To(Succeed())
+		Expect(c.Create(ctx, project)).To(Succeed())
+		Expect(c.Create(ctx, shoot)).To(Succeed())
+		Expect(c.Create(ctx, configmap)).To(Succeed())
+		res, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: configmapNamespacedName})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(res).To(Equal(ctrl.Result{RequeueAfter: resyncPeriod}))
+		expectStoreEntry(s, storeKey, certstore.Data{
+			CABundle: expectedBundleBytes,
+		})
+
+		reconciler.Shard = &foreignShard{}
+		res, err = reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: configmapNamespacedName})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(res).To(Equal(ctrl.Result{}))
+
+		Expect(s.Len()).To(Equal(0))
+		_, ok := s.Rejection(storeKey)
+		Expect(ok).To(BeFalse())
+	})
+
 	DescribeTable(
 		"should remove entry from store because of failed validation",
 		func(prepFunc func()) {
@@ -255,6 +329,9 @@ var _ = Describe("#ReconcileCertificate", func() {
 			Expect(res).To(Equal(ctrl.Result{}))
 
 			Expect(s.Len()).To(Equal(0))
+			rejection, ok := s.Rejection(storeKey)
+			Expect(ok).To(BeTrue())
+			Expect(rejection.Reason).ToNot(BeEmpty())
 		},
 		Entry("configmap is missing", func() {
 			Expect(c.Delete(ctx, configmap)).To(Succeed())
@@ -316,5 +393,36 @@ var _ = Describe("#ReconcileCertificate", func() {
 			configmap.Data["ca.crt"] = pemEncoded.String()
 			Expect(c.Update(ctx, configmap)).To(Succeed())
 		}),
+		Entry("bundle has trailing data after the last pem block", func() {
+			configmap.Data["ca.crt"] += "garbage"
+			Expect(c.Update(ctx, configmap)).To(Succeed())
+		}),
+		Entry("bundle has data before the first pem block", func() {
+			configmap.Data["ca.crt"] = "garbage\n" + configmap.Data["ca.crt"]
+			Expect(c.Update(ctx, configmap)).To(Succeed())
+		}),
+		Entry("bundle has a malformed pem block", func() {
+			configmap.Data["ca.crt"] = "-----BEGIN CERTIFICATE-----\n!!!\n" + configmap.Data["ca.crt"]
+			Expect(c.Update(ctx, configmap)).To(Succeed())
+		}),
+		Entry("bundle does not contain any pem block", func() {
+			configmap.Data["ca.crt"] = "garbage"
+			Expect(c.Update(ctx, configmap)).To(Succeed())
+		}),
 	)
 })
+
+type prefixSigner struct{}
+
+func (prefixSigner) Sign(payload []byte) (string, error) { return "signed:" + string(payload), nil }
+
+func (prefixSigner) KeyID() string { return "prefix" }
+
+// foreignShard is a shard in which all projects are owned by another replica.
+type foreignShard struct{}
+
+func (*foreignShard) IsLocal(string) bool { return false }
+
+func (*foreignShard) Owner(string) sharding.Member { return sharding.Member{Identity: "other"} }
+
+func (*foreignShard) OnChange(func()) func() { return func() {} }
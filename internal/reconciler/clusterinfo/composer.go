@@ -0,0 +1,196 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package clusterinfo
+
+import (
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/go-logr/logr"
+	clientcmdv1 "k8s.io/client-go/tools/clientcmd/api/v1"
+	"sigs.k8s.io/yaml"
+
+	"github.com/gardener/gardener-discovery-server/internal/handler"
+	"github.com/gardener/gardener-discovery-server/internal/precompress"
+	"github.com/gardener/gardener-discovery-server/internal/publisher"
+	"github.com/gardener/gardener-discovery-server/internal/signing"
+	"github.com/gardener/gardener-discovery-server/internal/store"
+	"github.com/gardener/gardener-discovery-server/internal/store/certificate"
+	"github.com/gardener/gardener-discovery-server/internal/store/clusterinfo"
+)
+
+// MimeAppYAML is the media type of the kubeconfig variant of the cluster info.
+const MimeAppYAML = "application/yaml"
+
+// Source is a store the cluster info is composed of.
+type Source[T any] interface {
+	store.Reader[T]
+	Metadata(key string) (store.Metadata, bool)
+	OnUpdate(fn func(key string))
+}
+
+// Composer composes the cluster info of shoots of their API server addresses and their CA bundle.
+// The cluster info is composed whenever one of the entries it is composed of is written or removed,
+// so that requests are served from the stored kubeconfig without encoding it again.
+type Composer struct {
+	infoStore   Source[clusterinfo.Data]
+	certStore   Source[certificate.Data]
+	kubeconfigs *store.Store[clusterinfo.Kubeconfig]
+	signer      signing.Signer
+	log         logr.Logger
+
+	// mutex serializes the composition, so that a kubeconfig composed of outdated entries never overwrites a later one.
+	mutex sync.Mutex
+}
+
+// NewComposer returns a [Composer] writing the kubeconfigs composed of the entries of the stores to the kubeconfig store.
+// The signer may be nil, then the kubeconfigs are not signed.
+func NewComposer(
+	infoStore Source[clusterinfo.Data],
+	certStore Source[certificate.Data],
+	kubeconfigs *store.Store[clusterinfo.Kubeconfig],
+	signer signing.Signer,
+	log logr.Logger,
+) *Composer {
+	c := &Composer{
+		infoStore:   infoStore,
+		certStore:   certStore,
+		kubeconfigs: kubeconfigs,
+		signer:      signer,
+		log:         log,
+	}
+	infoStore.OnUpdate(c.Compose)
+	certStore.OnUpdate(c.Compose)
+	return c
+}
+
+// Compose composes the kubeconfig of the shoot, it exists if both stores have an entry for the key.
+// It is resolved by the alias of the entry of the API server addresses and it expires with the CA bundle.
+func (c *Composer) Compose(key string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	data, ok := c.infoStore.Read(key)
+	if !ok {
+		c.kubeconfigs.Delete(key)
+		return
+	}
+	caBundle, ok := c.certStore.Read(key)
+	if !ok {
+		c.kubeconfigs.Delete(key)
+		return
+	}
+
+	config, err := Kubeconfig(data, caBundle)
+	if err != nil {
+		c.log.Error(err, "Failed composing kubeconfig", "key", key)
+		c.kubeconfigs.Delete(key, store.WithReason("invalid cluster info: "+err.Error()))
+		return
+	}
+	rawJSON, err := json.Marshal(config)
+	if err != nil {
+		c.log.Error(err, "Failed encoding kubeconfig as JSON", "key", key)
+		return
+	}
+	rawYAML, err := yaml.Marshal(config)
+	if err != nil {
+		c.log.Error(err, "Failed encoding kubeconfig as YAML", "key", key)
+		return
+	}
+	jsonSignature, err := signing.Sign(c.signer, rawJSON)
+	if err != nil {
+		c.log.Error(err, "Failed signing kubeconfig", "key", key)
+		return
+	}
+	yamlSignature, err := signing.Sign(c.signer, rawYAML)
+	if err != nil {
+		c.log.Error(err, "Failed signing kubeconfig", "key", key)
+		return
+	}
+
+	var opts []store.Option
+	if meta, ok := c.infoStore.Metadata(key); ok {
+		if meta.Source != nil {
+			opts = append(opts, store.WithSource(*meta.Source))
+		}
+		if meta.Alias != "" {
+			opts = append(opts, store.WithAlias(meta.Alias))
+		}
+	}
+	c.kubeconfigs.Write(key, clusterinfo.Kubeconfig{
+		JSON:           rawJSON,
+		CompressedJSON: precompress.Compress(rawJSON),
+		YAML:           rawYAML,
+		CompressedYAML: precompress.Compress(rawYAML),
+		Expires:        caBundle.Expiration(),
+		JSONSignature:  jsonSignature,
+		YAMLSignature:  yamlSignature,
+	}, opts...)
+}
+
+// Kubeconfig returns the kubeconfig of the shoot shaped like the cluster-info config map in the kube-public namespace.
+// It has a cluster and a context without user for every server, the context of the preferred server is the current one.
+func Kubeconfig(data clusterinfo.Data, caBundle certificate.Data) (clientcmdv1.Config, error) {
+	var bundle struct {
+		Certs string `json:"certs"`
+	}
+	if err := json.Unmarshal(caBundle.CABundle, &bundle); err != nil {
+		return clientcmdv1.Config{}, fmt.Errorf("failed to decode CA bundle: %w", err)
+	}
+	if block, _ := pem.Decode([]byte(bundle.Certs)); block == nil {
+		return clientcmdv1.Config{}, errors.New("CA bundle does not contain a certificate")
+	}
+	if len(data.Servers) == 0 {
+		return clientcmdv1.Config{}, errors.New("cluster info does not contain a server")
+	}
+
+	config := clientcmdv1.Config{
+		Kind:           "Config",
+		APIVersion:     "v1",
+		Clusters:       make([]clientcmdv1.NamedCluster, 0, len(data.Servers)),
+		AuthInfos:      []clientcmdv1.NamedAuthInfo{},
+		Contexts:       make([]clientcmdv1.NamedContext, 0, len(data.Servers)),
+		CurrentContext: data.Servers[0].Name,
+	}
+	for _, server := range data.Servers {
+		config.Clusters = append(config.Clusters, clientcmdv1.NamedCluster{
+			Name: server.Name,
+			Cluster: clientcmdv1.Cluster{
+				Server:                   server.URL,
+				CertificateAuthorityData: []byte(bundle.Certs),
+			},
+		})
+		config.Contexts = append(config.Contexts, clientcmdv1.NamedContext{
+			Name:    server.Name,
+			Context: clientcmdv1.Context{Cluster: server.Name},
+		})
+	}
+	return config, nil
+}
+
+// KubeconfigDocuments returns the documents served from the kubeconfigs composed by the [Composer].
+func KubeconfigDocuments() []publisher.Document[clusterinfo.Kubeconfig] {
+	return []publisher.Document[clusterinfo.Kubeconfig]{
+		{
+			Name:   "cluster-info",
+			Path:   "/cluster-info",
+			Policy: func(p handler.CachePolicies) handler.CachePolicy { return p.CABundle },
+			Content: func(data clusterinfo.Kubeconfig) handler.Content {
+				return handler.Content{Body: data.JSON, Variants: data.CompressedJSON, Signature: data.JSONSignature}
+			},
+		},
+		{
+			Name:   "cluster-info-kubeconfig",
+			Path:   "/cluster-info/kubeconfig",
+			Policy: func(p handler.CachePolicies) handler.CachePolicy { return p.CABundle },
+			Content: func(data clusterinfo.Kubeconfig) handler.Content {
+				return handler.Content{Body: data.YAML, Variants: data.CompressedYAML, Signature: data.YAMLSignature, ContentType: MimeAppYAML}
+			},
+		},
+	}
+}
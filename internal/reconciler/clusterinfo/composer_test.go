@@ -0,0 +1,233 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package clusterinfo_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdv1 "k8s.io/client-go/tools/clientcmd/api/v1"
+	logzap "sigs.k8s.io/controller-runtime/pkg/log/zap"
+
+	"github.com/gardener/gardener-discovery-server/internal/handler"
+	"github.com/gardener/gardener-discovery-server/internal/publisher"
+	"github.com/gardener/gardener-discovery-server/internal/reconciler/clusterinfo"
+	"github.com/gardener/gardener-discovery-server/internal/store"
+	certstore "github.com/gardener/gardener-discovery-server/internal/store/certificate"
+	clusterinfostore "github.com/gardener/gardener-discovery-server/internal/store/clusterinfo"
+)
+
+var _ = Describe("Composer", func() {
+	const (
+		key = "foo--a6475c90-d533-43c4-bbb0-d99200b491b1"
+		uri = "/projects/foo/shoots/a6475c90-d533-43c4-bbb0-d99200b491b1/cluster-info"
+	)
+
+	var (
+		infoStore   *store.Store[clusterinfostore.Data]
+		certStore   *store.Store[certstore.Data]
+		kubeconfigs *store.Store[clusterinfostore.Kubeconfig]
+		mux         *http.ServeMux
+
+		caPEM   []byte
+		caBytes []byte
+		expires = time.Now().Add(24 * time.Hour).UTC().Truncate(time.Second)
+
+		servers = []clusterinfostore.Server{
+			{Name: "external", URL: "https://api.my-shoot.foo.example.com"},
+			{Name: "internal", URL: "https://api.my-shoot.foo.internal.example.com"},
+		}
+
+		get = func(path string) *httptest.ResponseRecorder {
+			recorder := httptest.NewRecorder()
+			mux.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, path, nil))
+			return recorder
+		}
+
+		expectKubeconfig = func(config clientcmdv1.Config) {
+			Expect(config.APIVersion).To(Equal("v1"))
+			Expect(config.Kind).To(Equal("Config"))
+			Expect(config.CurrentContext).To(Equal("external"))
+			Expect(config.Clusters).To(Equal([]clientcmdv1.NamedCluster{
+				{Name: "external", Cluster: clientcmdv1.Cluster{Server: servers[0].URL, CertificateAuthorityData: caPEM}},
+				{Name: "internal", Cluster: clientcmdv1.Cluster{Server: servers[1].URL, CertificateAuthorityData: caPEM}},
+			}))
+			Expect(config.Contexts).To(Equal([]clientcmdv1.NamedContext{
+				{Name: "external", Context: clientcmdv1.Context{Cluster: "external"}},
+				{Name: "internal", Context: clientcmdv1.Context{Cluster: "internal"}},
+			}))
+		}
+	)
+
+	BeforeEach(func() {
+		caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		Expect(err).ToNot(HaveOccurred())
+		template := &x509.Certificate{
+			SerialNumber:          big.NewInt(1),
+			Subject:               pkix.Name{CommonName: "ca"},
+			NotBefore:             time.Now().Add(-time.Hour),
+			NotAfter:              expires,
+			IsCA:                  true,
+			BasicConstraintsValid: true,
+			KeyUsage:              x509.KeyUsageCertSign,
+		}
+		der, err := x509.CreateCertificate(rand.Reader, template, template, caKey.Public(), caKey)
+		Expect(err).ToNot(HaveOccurred())
+		caPEM = pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+		caBytes, err = json.Marshal(struct {
+			Certs string `json:"certs"`
+		}{Certs: string(caPEM)})
+		Expect(err).ToNot(HaveOccurred())
+
+		infoStore = store.MustNewStore(clusterinfostore.Copy)
+		certStore = store.MustNewStore(certstore.Copy)
+		kubeconfigs = store.MustNewStore(clusterinfostore.CopyKubeconfig)
+
+		log := logzap.New(logzap.WriteTo(GinkgoWriter))
+		clusterinfo.NewComposer(infoStore, certStore, kubeconfigs, prefixSigner{}, log)
+
+		policies := handler.DefaultCachePolicies
+		policies.CABundle = handler.CachePolicy{MaxAge: 48 * time.Hour, Expires: true}
+		mux = http.NewServeMux()
+		publisher.Register(publisher.NewRegistry(mux, policies, log, publisher.WithShootNameAliases(false)), kubeconfigs, clusterinfo.KubeconfigDocuments()...)
+	})
+
+	Describe("#Compose", func() {
+		It("should compose the kubeconfig of the API server addresses and the CA bundle", func() {
+			infoStore.Write(key, clusterinfostore.Data{Servers: servers})
+			certStore.Write(key, certstore.Data{CABundle: caBytes, Expires: expires})
+
+			recorder := get(uri)
+			Expect(recorder.Code).To(Equal(http.StatusOK))
+			Expect(recorder.Header().Get("Content-Type")).To(Equal("application/json"))
+			Expect(recorder.Header().Get("Expires")).To(Equal(expires.Format(http.TimeFormat)))
+
+			var config clientcmdv1.Config
+			Expect(json.Unmarshal(recorder.Body.Bytes(), &config)).To(Succeed())
+			expectKubeconfig(config)
+		})
+
+		It("should sign and compress the kubeconfig when it is composed", func() {
+			infoStore.Write(key, clusterinfostore.Data{Servers: servers})
+			certStore.Write(key, certstore.Data{CABundle: caBytes, Expires: expires})
+
+			data, ok := kubeconfigs.Read(key)
+			Expect(ok).To(BeTrue())
+			Expect(data.JSONSignature).To(Equal("signed:" + string(data.JSON)))
+			Expect(data.YAMLSignature).To(Equal("signed:" + string(data.YAML)))
+			Expect(data.CompressedJSON.Gzip).ToNot(BeEmpty())
+			Expect(data.Expires).To(Equal(expires))
+
+			recorder := get(uri)
+			Expect(recorder.Header().Get("JWS-Signature")).To(Equal("signed:" + recorder.Body.String()))
+		})
+
+		It("should resolve the kubeconfig by the alias of the API server addresses", func() {
+			infoStore.Write(key, clusterinfostore.Data{Servers: servers}, store.WithAlias("foo--my-shoot"))
+			certStore.Write(key, certstore.Data{CABundle: caBytes})
+
+			resolved, ok := kubeconfigs.Resolve("foo--my-shoot")
+			Expect(ok).To(BeTrue())
+			Expect(resolved).To(Equal(key))
+			Expect(get("/projects/foo/shoots/by-name/my-shoot/cluster-info").Code).To(Equal(http.StatusOK))
+		})
+
+		It("should remove the kubeconfig once one of its entries is removed", func() {
+			infoStore.Write(key, clusterinfostore.Data{Servers: servers})
+			certStore.Write(key, certstore.Data{CABundle: caBytes})
+			Expect(kubeconfigs.Len()).To(Equal(1))
+
+			certStore.Delete(key)
+			Expect(kubeconfigs.Len()).To(Equal(0))
+			Expect(get(uri).Code).To(Equal(http.StatusNotFound))
+		})
+
+		It("should return not found if the shoot has no API server address", func() {
+			certStore.Write(key, certstore.Data{CABundle: caBytes})
+
+			Expect(get(uri).Code).To(Equal(http.StatusNotFound))
+		})
+
+		It("should return not found if the shoot has no CA bundle", func() {
+			infoStore.Write(key, clusterinfostore.Data{Servers: servers})
+
+			Expect(get(uri).Code).To(Equal(http.StatusNotFound))
+		})
+
+		It("should return not found if the CA bundle does not contain a certificate", func() {
+			infoStore.Write(key, clusterinfostore.Data{Servers: servers})
+			certStore.Write(key, certstore.Data{CABundle: []byte(`{"certs":""}`)})
+
+			Expect(get(uri).Code).To(Equal(http.StatusNotFound))
+		})
+
+		It("should reject other methods", func() {
+			recorder := httptest.NewRecorder()
+			mux.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, uri, nil))
+			Expect(recorder.Code).To(Equal(http.StatusMethodNotAllowed))
+		})
+	})
+
+	Describe("#KubeconfigDocuments", func() {
+		It("should serve a kubeconfig loadable by clientcmd", func() {
+			infoStore.Write(key, clusterinfostore.Data{Servers: servers})
+			certStore.Write(key, certstore.Data{CABundle: caBytes, Expires: expires})
+
+			recorder := get(uri + "/kubeconfig")
+			Expect(recorder.Code).To(Equal(http.StatusOK))
+			Expect(recorder.Header().Get("Content-Type")).To(Equal(clusterinfo.MimeAppYAML))
+
+			config, err := clientcmd.Load(recorder.Body.Bytes())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(config.CurrentContext).To(Equal("external"))
+			Expect(config.Clusters).To(HaveKey("external"))
+			Expect(config.Clusters["external"].Server).To(Equal(servers[0].URL))
+			Expect(config.Clusters["external"].CertificateAuthorityData).To(Equal(caPEM))
+			Expect(config.Contexts["internal"].Cluster).To(Equal("internal"))
+		})
+
+		It("should return not found if the shoot has no API server address", func() {
+			certStore.Write(key, certstore.Data{CABundle: caBytes})
+
+			Expect(get(uri + "/kubeconfig").Code).To(Equal(http.StatusNotFound))
+		})
+	})
+
+	Describe("#Kubeconfig", func() {
+		It("should shape the kubeconfig like the cluster-info config map", func() {
+			config, err := clusterinfo.Kubeconfig(clusterinfostore.Data{Servers: servers}, certstore.Data{CABundle: caBytes})
+			Expect(err).ToNot(HaveOccurred())
+			expectKubeconfig(config)
+		})
+
+		It("should fail if the CA bundle cannot be decoded", func() {
+			_, err := clusterinfo.Kubeconfig(clusterinfostore.Data{Servers: servers}, certstore.Data{CABundle: []byte("{")})
+			Expect(err).To(MatchError(ContainSubstring("failed to decode CA bundle")))
+		})
+
+		It("should fail without servers", func() {
+			_, err := clusterinfo.Kubeconfig(clusterinfostore.Data{}, certstore.Data{CABundle: caBytes})
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
+
+type prefixSigner struct{}
+
+func (prefixSigner) Sign(payload []byte) (string, error) { return "signed:" + string(payload), nil }
+
+func (prefixSigner) KeyID() string { return "prefix" }
@@ -0,0 +1,151 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package clusterinfo
+
+import (
+	"context"
+	"net/url"
+	"slices"
+
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	v1beta1constants "github.com/gardener/gardener/pkg/apis/core/v1beta1/constants"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/gardener/gardener-discovery-server/internal/publisher"
+	"github.com/gardener/gardener-discovery-server/internal/signing"
+	"github.com/gardener/gardener-discovery-server/internal/store/clusterinfo"
+)
+
+// ControllerName is the name of the shoot cluster info controller.
+const ControllerName = "shoot-cluster-info"
+
+// AnnotationClusterInfo is the annotation of shoots opting in to the publishing of their API server addresses.
+const AnnotationClusterInfo = "discovery.gardener.cloud/cluster-info"
+
+// AnnotationClusterInfoEnabled is the value of [AnnotationClusterInfo] enabling the publishing.
+const AnnotationClusterInfoEnabled = "enabled"
+
+// maxServers is the maximum number of published API server addresses of a shoot.
+const maxServers = 8
+
+// publishedAddresses are the names of the advertised addresses of the API server in the order of preference.
+// Other addresses, e.g. the one of the service account issuer, do not point to the API server.
+var publishedAddresses = []string{
+	v1beta1constants.AdvertisedAddressExternal,
+	v1beta1constants.AdvertisedAddressInternal,
+	v1beta1constants.AdvertisedAddressUnmanaged,
+}
+
+// Resource publishes the API server addresses of shoots from their status.
+type Resource struct{}
+
+var _ publisher.PublishedResource[*gardencorev1beta1.Shoot, clusterinfo.Data] = Resource{}
+
+// Name implements [publisher.PublishedResource].
+func (Resource) Name() string { return ControllerName }
+
+// Kind implements [publisher.PublishedResource].
+func (Resource) Kind() string { return "Shoot" }
+
+// NewObject implements [publisher.PublishedResource].
+func (Resource) NewObject() *gardencorev1beta1.Shoot { return &gardencorev1beta1.Shoot{} }
+
+// NewObjectList implements [publisher.PublishedResource].
+func (Resource) NewObjectList() client.ObjectList { return &gardencorev1beta1.ShootList{} }
+
+// ProjectListOptions implements [publisher.PublishedResource].
+// The shoots are kept in the namespace of the project.
+func (Resource) ProjectListOptions(project *gardencorev1beta1.Project) []client.ListOption {
+	return []client.ListOption{client.InNamespace(*project.Spec.Namespace)}
+}
+
+// IsRelevant implements [publisher.PublishedResource].
+func (Resource) IsRelevant(obj client.Object) bool {
+	_, ok := obj.(*gardencorev1beta1.Shoot)
+	return ok && obj.GetAnnotations()[AnnotationClusterInfo] == AnnotationClusterInfoEnabled
+}
+
+// Key implements [publisher.PublishedResource].
+// The shoot is requested by name, so the key cannot be derived from the request.
+func (Resource) Key(ctrl.Request) (string, bool) {
+	return "", false
+}
+
+// Validate implements [publisher.PublishedResource].
+func (Resource) Validate(shoot *gardencorev1beta1.Shoot) error {
+	if shoot.Annotations[AnnotationClusterInfo] != AnnotationClusterInfoEnabled {
+		return publisher.Reject("shoot does not opt in to publishing its cluster info", nil, "annotation", AnnotationClusterInfo)
+	}
+	return nil
+}
+
+// Owner implements [publisher.PublishedResource].
+// The project is referenced by the label of the namespace of the shoot.
+func (Resource) Owner(ctx context.Context, c client.Reader, shoot *gardencorev1beta1.Shoot) (publisher.Owner, error) {
+	namespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: shoot.Namespace}}
+	if err := c.Get(ctx, client.ObjectKeyFromObject(namespace), namespace); err != nil {
+		if apierrors.IsNotFound(err) {
+			return publisher.Owner{}, publisher.Reject("namespace not found", nil)
+		}
+		return publisher.Owner{}, err
+	}
+
+	projectName, ok := namespace.Labels[v1beta1constants.ProjectName]
+	if !ok {
+		return publisher.Owner{}, publisher.Reject("namespace does not have expected label", nil, "label", v1beta1constants.ProjectName)
+	}
+
+	return publisher.Owner{
+		ProjectName:    projectName,
+		ShootName:      shoot.Name,
+		ShootNamespace: shoot.Namespace,
+		ShootUID:       string(shoot.UID),
+	}, nil
+}
+
+// Render implements [publisher.PublishedResource].
+// The advertised addresses of the API server are published in the order of preference, other addresses are skipped.
+func (Resource) Render(obj *gardencorev1beta1.Shoot, _ *gardencorev1beta1.Shoot, _ signing.Signer) (clusterinfo.Data, error) {
+	var servers []clusterinfo.Server
+	for _, name := range publishedAddresses {
+		for _, address := range obj.Status.AdvertisedAddresses {
+			if address.Name != name {
+				continue
+			}
+			if !isServerURL(address.URL) {
+				return clusterinfo.Data{}, publisher.Reject("advertised address is not an https URL", nil, "address", address.Name)
+			}
+			servers = append(servers, clusterinfo.Server{Name: address.Name, URL: address.URL})
+		}
+	}
+
+	if len(servers) == 0 {
+		return clusterinfo.Data{}, publisher.Reject("shoot does not advertise an API server address", nil)
+	}
+	if len(servers) > maxServers {
+		return clusterinfo.Data{}, publisher.Reject("shoot advertises too many API server addresses", nil)
+	}
+	return clusterinfo.Data{Servers: slices.Clip(servers)}, nil
+}
+
+// isServerURL reports whether the URL is an https URL of a host without user info, path, query and fragment.
+func isServerURL(raw string) bool {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return false
+	}
+	return u.Scheme == "https" && u.Host != "" && u.User == nil &&
+		(u.Path == "" || u.Path == "/") && u.RawQuery == "" && u.Fragment == "" && len(raw) <= 2048
+}
+
+// Documents implements [publisher.PublishedResource].
+// The cluster info is composed with the CA bundle of the shoot by the [Composer], see [KubeconfigDocuments] for its documents.
+func (Resource) Documents() []publisher.Document[clusterinfo.Data] {
+	return nil
+}
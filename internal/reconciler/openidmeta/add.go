@@ -1,70 +0,0 @@
-// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
-//
-// SPDX-License-Identifier: Apache-2.0
-
-package openidmeta
-
-import (
-	"time"
-
-	v1beta1constants "github.com/gardener/gardener/pkg/apis/core/v1beta1/constants"
-	"github.com/gardener/gardener/pkg/controllerutils"
-	"golang.org/x/time/rate"
-	corev1 "k8s.io/api/core/v1"
-	"k8s.io/client-go/util/workqueue"
-	ctrl "sigs.k8s.io/controller-runtime"
-	"sigs.k8s.io/controller-runtime/pkg/builder"
-	"sigs.k8s.io/controller-runtime/pkg/client"
-	"sigs.k8s.io/controller-runtime/pkg/controller"
-	"sigs.k8s.io/controller-runtime/pkg/event"
-	"sigs.k8s.io/controller-runtime/pkg/predicate"
-	"sigs.k8s.io/controller-runtime/pkg/reconcile"
-)
-
-// ControllerName is the name of the shoot metadata controller.
-const ControllerName = "shoot-openid-metadata"
-
-// SetupWithManager specifies how the controller is built to watch secrets
-// that contain shoot cluster public service account keys
-func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
-	if r.Client == nil {
-		r.Client = mgr.GetClient()
-	}
-
-	return builder.ControllerManagedBy(mgr).
-		Named(ControllerName).
-		For(&corev1.Secret{}, builder.WithPredicates(secretPredicate())). // TODO it is not yet clear what the predicate should be
-		WithOptions(controller.Options{
-			MaxConcurrentReconciles: 50,
-			RateLimiter: workqueue.NewTypedMaxOfRateLimiter(
-				workqueue.NewTypedItemExponentialFailureRateLimiter[reconcile.Request](5*time.Second, 2*time.Minute),
-				&workqueue.TypedBucketRateLimiter[reconcile.Request]{Limiter: rate.NewLimiter(rate.Limit(10), 100)},
-			),
-			ReconciliationTimeout: controllerutils.DefaultReconciliationTimeout,
-		}).
-		Complete(r)
-}
-
-func secretPredicate() predicate.Predicate {
-	return predicate.Funcs{
-		CreateFunc:  func(e event.CreateEvent) bool { return isRelevantSecret(e.Object) },
-		UpdateFunc:  func(e event.UpdateEvent) bool { return isRelevantSecretUpdate(e.ObjectOld, e.ObjectNew) },
-		DeleteFunc:  func(e event.DeleteEvent) bool { return isRelevantSecret(e.Object) },
-		GenericFunc: func(_ event.GenericEvent) bool { return false },
-	}
-}
-
-func isRelevantSecret(obj client.Object) bool {
-	secret, ok := obj.(*corev1.Secret)
-	if !ok {
-		return false
-	}
-	return secret.Labels != nil &&
-		(secret.Labels[v1beta1constants.LabelDiscoveryPublic] == v1beta1constants.LabelPublicKeysServiceAccount ||
-			// TODO(vpnachev): Remove v1beta1constants.LabelPublicKeys once support for gardener/gardener <= v1.142.0 is dropped.
-			secret.Labels[v1beta1constants.LabelPublicKeys] == v1beta1constants.LabelPublicKeysServiceAccount) //nolint:staticcheck
-}
-
-func isRelevantSecretUpdate(oldObj, newObj client.Object) bool {
-	return isRelevantSecret(newObj) || isRelevantSecret(oldObj)
-}
@@ -13,12 +13,13 @@ import (
 	logzap "sigs.k8s.io/controller-runtime/pkg/log/zap"
 
 	"github.com/gardener/gardener-discovery-server/internal/handler"
-	oidhandler "github.com/gardener/gardener-discovery-server/internal/handler/openidmeta"
+	"github.com/gardener/gardener-discovery-server/internal/publisher"
+	oidreconciler "github.com/gardener/gardener-discovery-server/internal/reconciler/openidmeta"
 	"github.com/gardener/gardener-discovery-server/internal/store"
 	oidstore "github.com/gardener/gardener-discovery-server/internal/store/openidmeta"
 )
 
-var _ = Describe("#HttpHandlerOpenIDMeta", func() {
+var _ = Describe("#Documents", func() {
 	var (
 		s *store.Store[oidstore.Data]
 
@@ -27,8 +28,7 @@ var _ = Describe("#HttpHandlerOpenIDMeta", func() {
 		uid1 = "a6475c90-d533-43c4-bbb0-d99200b491b1"
 		uid2 = "1e4914ca-c837-451d-a1cf-c559d131cb57"
 
-		oidHandler *oidhandler.Handler
-		mux        *http.ServeMux
+		mux *http.ServeMux
 	)
 
 	BeforeEach(func() {
@@ -43,10 +43,8 @@ var _ = Describe("#HttpHandlerOpenIDMeta", func() {
 		})
 
 		log := logzap.New(logzap.WriteTo(GinkgoWriter))
-		oidHandler = oidhandler.New(s, log)
 		mux = http.NewServeMux()
-		mux.Handle("/projects/{projectName}/shoots/{shootUID}/issuer/.well-known/openid-configuration", oidHandler.HandleOpenIDConfiguration())
-		mux.Handle("/projects/{projectName}/shoots/{shootUID}/issuer/jwks", oidHandler.HandleJWKS())
+		publisher.Register(publisher.NewRegistry(mux, handler.DefaultCachePolicies, log), s, oidreconciler.Resource{}.Documents()...)
 		mux.Handle("/", handler.NotFound(log))
 	})
 
@@ -71,6 +69,8 @@ var _ = Describe("#HttpHandlerOpenIDMeta", func() {
 			200,
 			[]byte("config1"),
 			map[string]string{
+				"Vary":                      "Accept-Encoding",
+				"Content-Length":            "7",
 				"Strict-Transport-Security": "max-age=31536000; includeSubDomains",
 				"Content-Type":              "application/json",
 				"Cache-Control":             "public, max-age=3600",
@@ -83,6 +83,8 @@ var _ = Describe("#HttpHandlerOpenIDMeta", func() {
 			200,
 			[]byte("jwks1"),
 			map[string]string{
+				"Vary":                      "Accept-Encoding",
+				"Content-Length":            "5",
 				"Strict-Transport-Security": "max-age=31536000; includeSubDomains",
 				"Content-Type":              "application/json",
 				"Cache-Control":             "public, max-age=3600",
@@ -95,6 +97,8 @@ var _ = Describe("#HttpHandlerOpenIDMeta", func() {
 			200,
 			[]byte("config2"),
 			map[string]string{
+				"Vary":                      "Accept-Encoding",
+				"Content-Length":            "7",
 				"Strict-Transport-Security": "max-age=31536000; includeSubDomains",
 				"Content-Type":              "application/json",
 				"Cache-Control":             "public, max-age=3600",
@@ -107,6 +111,8 @@ var _ = Describe("#HttpHandlerOpenIDMeta", func() {
 			200,
 			[]byte("jwks2"),
 			map[string]string{
+				"Vary":                      "Accept-Encoding",
+				"Content-Length":            "5",
 				"Strict-Transport-Security": "max-age=31536000; includeSubDomains",
 				"Content-Type":              "application/json",
 				"Cache-Control":             "public, max-age=3600",
@@ -117,10 +123,11 @@ var _ = Describe("#HttpHandlerOpenIDMeta", func() {
 			http.MethodGet,
 			"https://abc.def/projects/not-existent/shoots/1e4914ca-c837-451d-a1cf-c559d131cb57/issuer/.well-known/openid-configuration",
 			404,
-			[]byte(`{"code":404,"message":"not found"}`),
+			[]byte(`{"type":"about:blank","title":"Not Found","status":404,"detail":"not found","instance":"/projects/not-existent/shoots/1e4914ca-c837-451d-a1cf-c559d131cb57/issuer/.well-known/openid-configuration","code":404,"message":"not found"}`+"\n"),
 			map[string]string{
+				"Cache-Control":             "no-store",
 				"Strict-Transport-Security": "max-age=31536000; includeSubDomains",
-				"Content-Type":              "application/json",
+				"Content-Type":              "application/problem+json",
 			},
 		),
 		Entry(
@@ -128,10 +135,11 @@ var _ = Describe("#HttpHandlerOpenIDMeta", func() {
 			http.MethodGet,
 			"https://abc.def/projects/not-existent/shoots/1e4914ca-c837-451d-a1cf-c559d131cb57/issuer/jwks",
 			404,
-			[]byte(`{"code":404,"message":"not found"}`),
+			[]byte(`{"type":"about:blank","title":"Not Found","status":404,"detail":"not found","instance":"/projects/not-existent/shoots/1e4914ca-c837-451d-a1cf-c559d131cb57/issuer/jwks","code":404,"message":"not found"}`+"\n"),
 			map[string]string{
+				"Cache-Control":             "no-store",
 				"Strict-Transport-Security": "max-age=31536000; includeSubDomains",
-				"Content-Type":              "application/json",
+				"Content-Type":              "application/problem+json",
 			},
 		),
 		Entry(
@@ -139,10 +147,11 @@ var _ = Describe("#HttpHandlerOpenIDMeta", func() {
 			http.MethodGet,
 			"https://abc.def/projects/not-existent/shoots/not-a-uuid/issuer/.well-known/openid-configuration",
 			400,
-			[]byte(`{"code":400,"message":"invalid UID"}`),
+			[]byte(`{"type":"about:blank","title":"Bad Request","status":400,"detail":"invalid UID","instance":"/projects/not-existent/shoots/not-a-uuid/issuer/.well-known/openid-configuration","code":400,"message":"invalid UID"}`+"\n"),
 			map[string]string{
+				"Cache-Control":             "no-store",
 				"Strict-Transport-Security": "max-age=31536000; includeSubDomains",
-				"Content-Type":              "application/json",
+				"Content-Type":              "application/problem+json",
 			},
 		),
 		Entry(
@@ -150,10 +159,11 @@ var _ = Describe("#HttpHandlerOpenIDMeta", func() {
 			http.MethodGet,
 			"https://abc.def/projects/not-existent/shoots/not-a-uuid/issuer/jwks",
 			400,
-			[]byte(`{"code":400,"message":"invalid UID"}`),
+			[]byte(`{"type":"about:blank","title":"Bad Request","status":400,"detail":"invalid UID","instance":"/projects/not-existent/shoots/not-a-uuid/issuer/jwks","code":400,"message":"invalid UID"}`+"\n"),
 			map[string]string{
+				"Cache-Control":             "no-store",
 				"Strict-Transport-Security": "max-age=31536000; includeSubDomains",
-				"Content-Type":              "application/json",
+				"Content-Type":              "application/problem+json",
 			},
 		),
 		Entry(
@@ -161,9 +171,10 @@ var _ = Describe("#HttpHandlerOpenIDMeta", func() {
 			http.MethodGet,
 			"https://abc.def/does-not-exist",
 			404,
-			[]byte(`{"code":404,"message":"not found"}`),
+			[]byte(`{"type":"about:blank","title":"Not Found","status":404,"detail":"not found","instance":"/does-not-exist","code":404,"message":"not found"}`+"\n"),
 			map[string]string{
-				"Content-Type": "application/json",
+				"Cache-Control": "no-store",
+				"Content-Type":  "application/problem+json",
 			},
 		),
 		Entry(
@@ -171,10 +182,11 @@ var _ = Describe("#HttpHandlerOpenIDMeta", func() {
 			http.MethodPost,
 			"https://abc.def/projects/foo/shoots/1e4914ca-c837-451d-a1cf-c559d131cb57/issuer/.well-known/openid-configuration",
 			405,
-			[]byte(`{"code":405,"message":"method not allowed"}`),
+			[]byte(`{"type":"about:blank","title":"Method Not Allowed","status":405,"detail":"method not allowed","instance":"/projects/foo/shoots/1e4914ca-c837-451d-a1cf-c559d131cb57/issuer/.well-known/openid-configuration","code":405,"message":"method not allowed"}`+"\n"),
 			map[string]string{
+				"Cache-Control":             "no-store",
 				"Strict-Transport-Security": "max-age=31536000; includeSubDomains",
-				"Content-Type":              "application/json",
+				"Content-Type":              "application/problem+json",
 			},
 		),
 		Entry(
@@ -182,10 +194,11 @@ var _ = Describe("#HttpHandlerOpenIDMeta", func() {
 			http.MethodPost,
 			"https://abc.def/projects/foo/shoots/1e4914ca-c837-451d-a1cf-c559d131cb57/issuer/jwks",
 			405,
-			[]byte(`{"code":405,"message":"method not allowed"}`),
+			[]byte(`{"type":"about:blank","title":"Method Not Allowed","status":405,"detail":"method not allowed","instance":"/projects/foo/shoots/1e4914ca-c837-451d-a1cf-c559d131cb57/issuer/jwks","code":405,"message":"method not allowed"}`+"\n"),
 			map[string]string{
+				"Cache-Control":             "no-store",
 				"Strict-Transport-Security": "max-age=31536000; includeSubDomains",
-				"Content-Type":              "application/json",
+				"Content-Type":              "application/problem+json",
 			},
 		),
 	)
@@ -6,233 +6,192 @@ package openidmeta
 
 import (
 	"context"
+	"fmt"
 	"strings"
-	"time"
 
 	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
 	v1beta1constants "github.com/gardener/gardener/pkg/apis/core/v1beta1/constants"
 	corev1 "k8s.io/api/core/v1"
-	apierrors "k8s.io/apimachinery/pkg/api/errors"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
-	logf "sigs.k8s.io/controller-runtime/pkg/log"
-	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
-	"github.com/gardener/gardener-discovery-server/internal/store"
+	discoverycache "github.com/gardener/gardener-discovery-server/internal/cache"
+	"github.com/gardener/gardener-discovery-server/internal/handler"
+	"github.com/gardener/gardener-discovery-server/internal/precompress"
+	"github.com/gardener/gardener-discovery-server/internal/publisher"
+	"github.com/gardener/gardener-discovery-server/internal/signing"
 	"github.com/gardener/gardener-discovery-server/internal/store/openidmeta"
 	"github.com/gardener/gardener-discovery-server/internal/utils"
 )
 
-// Reconciler reconciles secret objects that contain
-// shoot openid configuration and JWKS.
-type Reconciler struct {
-	Client       client.Client
-	ResyncPeriod time.Duration
-	Store        store.Writer[openidmeta.Data]
-}
-
-// Reconcile retrieves the public OIDC metadata info from a secret and stores into cache.
-func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
-	log := logf.FromContext(ctx)
-
-	secret := &corev1.Secret{}
-	if err := r.Client.Get(ctx, req.NamespacedName, secret); err != nil {
-		if apierrors.IsNotFound(err) {
-			log.Info("Removing metadata from store - secret not found")
-			r.Store.Delete(req.Name)
-
-			return reconcile.Result{}, nil
-		}
-		return reconcile.Result{}, err
-	}
-
-	if secret.DeletionTimestamp != nil {
-		log.Info("Removing metadata from store - deletion timestamp present")
-		r.Store.Delete(req.Name)
+// ControllerName is the name of the shoot metadata controller.
+const ControllerName = "shoot-openid-metadata"
 
-		return reconcile.Result{}, nil
-	}
+const (
+	openidConfigKey = "openid-config"
+	jwksKey         = "jwks"
+)
 
-	const (
-		openidConfigKey = "openid-config"
-		jwksKey         = "jwks"
-	)
-	if v, ok := secret.Data[openidConfigKey]; !ok || len(v) == 0 {
-		log.Info("Removing metadata from store - secret is missing data key", "key", openidConfigKey)
-		r.Store.Delete(req.Name)
+// Resource publishes the shoot openid configuration and JWKS
+// from secrets that contain shoot cluster public service account keys.
+type Resource struct{}
 
-		return reconcile.Result{}, nil
-	}
+var _ publisher.PublishedResource[*corev1.Secret, openidmeta.Data] = Resource{}
 
-	if v, ok := secret.Data[jwksKey]; !ok || len(v) == 0 {
-		log.Info("Removing metadata from store - secret is missing data key", "key", jwksKey)
-		r.Store.Delete(req.Name)
+// Name implements [publisher.PublishedResource].
+func (Resource) Name() string { return ControllerName }
 
-		return reconcile.Result{}, nil
-	}
+// Kind implements [publisher.PublishedResource].
+func (Resource) Kind() string { return "Secret" }
 
-	labels := secret.GetLabels()
-	shouldRemoveMetadata := false
-	if v, ok := labels[v1beta1constants.LabelDiscoveryPublic]; !ok || v != v1beta1constants.LabelPublicKeysServiceAccount {
-		log.Info("Considering metadata for removal from the store - secret does not have expected label or its value is incorrect")
-		shouldRemoveMetadata = true
-	}
+// NewObject implements [publisher.PublishedResource].
+func (Resource) NewObject() *corev1.Secret { return &corev1.Secret{} }
 
-	// TODO(vpnachev): Remove the fallback to v1beta1constants.LabelPublicKeys once support for gardener/gardener <= v1.142.0 is dropped.
-	if v, ok := labels[v1beta1constants.LabelPublicKeys]; shouldRemoveMetadata && ok && v == v1beta1constants.LabelPublicKeysServiceAccount { //nolint:staticcheck
-		log.Info("Metadata will not be removed from the store - found the deprecated label with expected value, this is for backward compatibility with gardener/gardener <= v1.142.0")
-		shouldRemoveMetadata = false
-	}
+// NewObjectList implements [publisher.PublishedResource].
+func (Resource) NewObjectList() client.ObjectList { return &corev1.SecretList{} }
 
-	if shouldRemoveMetadata {
-		log.Info("Removing metadata from store - secret does not have any of the expected labels or their values are incorrect", "label", v1beta1constants.LabelDiscoveryPublic, "value", labels[v1beta1constants.LabelDiscoveryPublic],
-			"alternativeLabel", v1beta1constants.LabelPublicKeys, "alternativeValue", labels[v1beta1constants.LabelPublicKeys]) //nolint:staticcheck
-		r.Store.Delete(req.Name)
-		return reconcile.Result{}, nil
+// ProjectListOptions implements [publisher.PublishedResource].
+// The secrets of all projects are kept in the shoot issuer namespace and are labeled with their project.
+func (Resource) ProjectListOptions(project *gardencorev1beta1.Project) []client.ListOption {
+	return []client.ListOption{
+		client.InNamespace(discoverycache.ShootIssuerNamespace),
+		client.MatchingLabels{v1beta1constants.ProjectName: project.Name},
 	}
+}
 
-	var (
-		shootName      string
-		shootNamespace string
-		projectName    string
-		ok             bool
-	)
-
-	projectName, ok = labels[v1beta1constants.ProjectName]
+// IsRelevant implements [publisher.PublishedResource].
+func (Resource) IsRelevant(obj client.Object) bool {
+	secret, ok := obj.(*corev1.Secret)
 	if !ok {
-		log.Info("Removing metadata from store - secret does not have expected label", "label", v1beta1constants.ProjectName)
-		r.Store.Delete(req.Name)
-		return reconcile.Result{}, nil
+		return false
 	}
+	return secret.Labels != nil &&
+		(secret.Labels[v1beta1constants.LabelDiscoveryPublic] == v1beta1constants.LabelPublicKeysServiceAccount ||
+			// TODO(vpnachev): Remove v1beta1constants.LabelPublicKeys once support for gardener/gardener <= v1.142.0 is dropped.
+			secret.Labels[v1beta1constants.LabelPublicKeys] == v1beta1constants.LabelPublicKeysServiceAccount) //nolint:staticcheck
+}
 
-	shootName, ok = labels[v1beta1constants.LabelShootName]
-	if !ok {
-		log.Info("Removing metadata from store - secret does not have expected label", "label", v1beta1constants.LabelShootName)
-		r.Store.Delete(req.Name)
-		return reconcile.Result{}, nil
-	}
+// Key implements [publisher.PublishedResource]. The secrets are named after the store key.
+func (Resource) Key(req ctrl.Request) (string, bool) {
+	return req.Name, true
+}
 
-	shootNamespace, ok = labels[v1beta1constants.LabelShootNamespace]
-	if !ok {
-		log.Info("Removing metadata from store - secret does not have expected label", "label", v1beta1constants.LabelShootNamespace)
-		r.Store.Delete(req.Name)
-		return reconcile.Result{}, nil
+// Validate implements [publisher.PublishedResource].
+func (Resource) Validate(secret *corev1.Secret) error {
+	if v, ok := secret.Data[openidConfigKey]; !ok || len(v) == 0 {
+		return publisher.Reject("secret is missing data key", nil, "key", openidConfigKey)
 	}
 
-	projName, shootUID, err := utils.SplitProjectNameAndShootUID(req.Name)
-	if err != nil {
-		log.Error(err, "Removing metadata from store - secret name is not in the correct format")
-		r.Store.Delete(req.Name)
-		return reconcile.Result{}, nil
+	if v, ok := secret.Data[jwksKey]; !ok || len(v) == 0 {
+		return publisher.Reject("secret is missing data key", nil, "key", jwksKey)
 	}
 
-	if projectName != projName {
-		log.Info("Removing metadata from store - project name does not match between secret name and the project label")
-		r.Store.Delete(req.Name)
-		return reconcile.Result{}, nil
+	labels := secret.GetLabels()
+	// TODO(vpnachev): Remove the fallback to v1beta1constants.LabelPublicKeys once support for gardener/gardener <= v1.142.0 is dropped.
+	if labels[v1beta1constants.LabelDiscoveryPublic] != v1beta1constants.LabelPublicKeysServiceAccount &&
+		labels[v1beta1constants.LabelPublicKeys] != v1beta1constants.LabelPublicKeysServiceAccount { //nolint:staticcheck
+		return publisher.Reject("secret does not have any of the expected labels or their values are incorrect", nil, "label", v1beta1constants.LabelDiscoveryPublic, "value", labels[v1beta1constants.LabelDiscoveryPublic],
+			"alternativeLabel", v1beta1constants.LabelPublicKeys, "alternativeValue", labels[v1beta1constants.LabelPublicKeys]) //nolint:staticcheck
 	}
+	return nil
+}
 
-	project := &gardencorev1beta1.Project{ObjectMeta: metav1.ObjectMeta{Name: projectName}}
-	if err := r.Client.Get(ctx, client.ObjectKeyFromObject(project), project); err != nil {
-		if apierrors.IsNotFound(err) {
-			log.Info("Removing metadata from store - project not found", "project", projectName)
-			r.Store.Delete(req.Name)
-
-			return reconcile.Result{}, nil
+// Owner implements [publisher.PublishedResource]. The shoot is referenced by the labels and the name of the secret.
+func (Resource) Owner(_ context.Context, _ client.Reader, secret *corev1.Secret) (publisher.Owner, error) {
+	labels := secret.GetLabels()
+	for _, label := range []string{v1beta1constants.ProjectName, v1beta1constants.LabelShootName, v1beta1constants.LabelShootNamespace} {
+		if _, ok := labels[label]; !ok {
+			return publisher.Owner{}, publisher.Reject("secret does not have expected label", nil, "label", label)
 		}
-		return reconcile.Result{}, err
-	}
-
-	if project.Spec.Namespace == nil {
-		log.Info("Removing metadata from store - project spec.namespace is nil", "project", projectName)
-		r.Store.Delete(req.Name)
-
-		return reconcile.Result{}, nil
 	}
 
-	if shootNamespace != *project.Spec.Namespace {
-		log.Info("Removing metadata from store - secret shoot namespace label does not match project namespace", "project", projectName)
-		r.Store.Delete(req.Name)
-
-		return reconcile.Result{}, nil
+	projectName, shootUID, err := utils.SplitProjectNameAndShootUID(secret.Name)
+	if err != nil {
+		return publisher.Owner{}, publisher.Reject("secret name is not in the correct format", err)
 	}
 
-	shoot := &gardencorev1beta1.Shoot{ObjectMeta: metav1.ObjectMeta{
-		Name:      shootName,
-		Namespace: shootNamespace,
-	}}
-	if err := r.Client.Get(ctx, client.ObjectKeyFromObject(shoot), shoot); err != nil {
-		if apierrors.IsNotFound(err) {
-			log.Info("Removing metadata from store - shoot not found", "shoot", client.ObjectKeyFromObject(shoot))
-			r.Store.Delete(req.Name)
-
-			return reconcile.Result{}, nil
-		}
-		return reconcile.Result{}, err
+	if labels[v1beta1constants.ProjectName] != projectName {
+		return publisher.Owner{}, publisher.Reject("project name does not match between secret name and the project label", nil)
 	}
 
-	if shootUID != string(shoot.UID) {
-		log.Info("Removing metadata from store - shoot UID is different in spec and in secret name", "shoot", client.ObjectKeyFromObject(shoot))
-		r.Store.Delete(req.Name)
-
-		return reconcile.Result{}, nil
-	}
+	return publisher.Owner{
+		ProjectName:    projectName,
+		ShootName:      labels[v1beta1constants.LabelShootName],
+		ShootNamespace: labels[v1beta1constants.LabelShootNamespace],
+		ShootUID:       shootUID,
+	}, nil
+}
 
+// Render implements [publisher.PublishedResource].
+func (Resource) Render(secret *corev1.Secret, shoot *gardencorev1beta1.Shoot, signer signing.Signer) (openidmeta.Data, error) {
 	if v, ok := shoot.Annotations[v1beta1constants.AnnotationAuthenticationIssuer]; !ok || v != v1beta1constants.AnnotationAuthenticationIssuerManaged {
-		log.Info("Removing metadata from store - shoot managed issuer annotation is missing or it has an invalid value", "shoot", client.ObjectKeyFromObject(shoot), "value", v)
-		r.Store.Delete(req.Name)
-
-		return reconcile.Result{}, nil
+		return openidmeta.Data{}, publisher.Reject("shoot managed issuer annotation is missing or it has an invalid value", nil, "shoot", client.ObjectKeyFromObject(shoot), "value", v)
 	}
 
 	// a best effort check to ensure that URIs use https
 	cfg, err := utils.LoadOpenIDConfig(secret.Data[openidConfigKey])
 	if err != nil {
-		log.Error(err, "Removing metadata from store - cannot unmarshal openid-config")
-		r.Store.Delete(req.Name)
-
-		return reconcile.Result{}, nil
+		return openidmeta.Data{}, publisher.Reject("cannot unmarshal openid-config", err)
 	}
 
 	if !strings.HasPrefix(cfg.Issuer, "https://") || !strings.HasPrefix(cfg.JWKSURI, "https://") {
-		log.Error(err, "Removing metadata from store - open ID config is invalid, either issuer or jwks_uri does not start with https://")
-		r.Store.Delete(req.Name)
-
-		return reconcile.Result{}, nil
+		return openidmeta.Data{}, publisher.Reject("open ID config is invalid, either issuer or jwks_uri does not start with https://", nil)
 	}
 
 	keySet, err := utils.LoadKeySet(secret.Data[jwksKey])
 	if err != nil {
-		log.Error(err, "Removing metadata from store - failed parsing JWKS")
-		r.Store.Delete(req.Name)
-
-		return reconcile.Result{}, nil
+		return openidmeta.Data{}, publisher.Reject("failed parsing JWKS", err)
 	}
 
 	// a check if for some reason there is a non public key in there
 	for _, k := range keySet.Keys {
 		if !k.IsPublic() {
-			log.Info("Removing metadata from store - found a non public key in JWKS")
-			r.Store.Delete(req.Name)
-
-			return reconcile.Result{}, nil
+			return openidmeta.Data{}, publisher.Reject("found a non public key in JWKS", nil)
 		}
 
 		if !k.Valid() {
-			log.Info("Removing metadata from store - found an invalid key in JWKS")
-			r.Store.Delete(req.Name)
-
-			return reconcile.Result{}, nil
+			return openidmeta.Data{}, publisher.Reject("found an invalid key in JWKS", nil)
 		}
 	}
 
-	// Finally write the metadata to store
-	log.Info("Adding metadata to store")
-	r.Store.Write(req.Name, openidmeta.Data{
-		Config: secret.Data[openidConfigKey],
-		JWKS:   secret.Data[jwksKey],
-	})
+	configSignature, err := signing.Sign(signer, secret.Data[openidConfigKey])
+	if err != nil {
+		return openidmeta.Data{}, fmt.Errorf("failed to sign openid configuration: %w", err)
+	}
+	jwksSignature, err := signing.Sign(signer, secret.Data[jwksKey])
+	if err != nil {
+		return openidmeta.Data{}, fmt.Errorf("failed to sign JWKS: %w", err)
+	}
+
+	return openidmeta.Data{
+		Config:           secret.Data[openidConfigKey],
+		JWKS:             secret.Data[jwksKey],
+		CompressedConfig: precompress.Compress(secret.Data[openidConfigKey]),
+		CompressedJWKS:   precompress.Compress(secret.Data[jwksKey]),
+		Expires:          utils.KeySetExpiration(keySet),
+		ConfigSignature:  configSignature,
+		JWKSSignature:    jwksSignature,
+	}, nil
+}
 
-	return ctrl.Result{RequeueAfter: r.ResyncPeriod}, nil
+// Documents implements [publisher.PublishedResource].
+func (Resource) Documents() []publisher.Document[openidmeta.Data] {
+	return []publisher.Document[openidmeta.Data]{
+		{
+			Name:   "openid-configuration",
+			Path:   "/issuer/.well-known/openid-configuration",
+			Policy: func(p handler.CachePolicies) handler.CachePolicy { return p.OpenIDConfiguration },
+			Content: func(data openidmeta.Data) handler.Content {
+				return handler.Content{Body: data.Config, Variants: data.CompressedConfig, Signature: data.ConfigSignature}
+			},
+		},
+		{
+			Name:   "jwks",
+			Path:   "/issuer/jwks",
+			Policy: func(p handler.CachePolicies) handler.CachePolicy { return p.JWKS },
+			Content: func(data openidmeta.Data) handler.Content {
+				return handler.Content{Body: data.JWKS, Variants: data.CompressedJWKS, Signature: data.JWKSSignature}
+			},
+		},
+	}
 }
@@ -24,15 +24,20 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
 	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	logzap "sigs.k8s.io/controller-runtime/pkg/log/zap"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
+	"github.com/gardener/gardener-discovery-server/internal/precompress"
+	"github.com/gardener/gardener-discovery-server/internal/publisher"
 	oidreconciler "github.com/gardener/gardener-discovery-server/internal/reconciler/openidmeta"
+	"github.com/gardener/gardener-discovery-server/internal/sharding"
 	"github.com/gardener/gardener-discovery-server/internal/store"
 	oidstore "github.com/gardener/gardener-discovery-server/internal/store/openidmeta"
 	"github.com/gardener/gardener-discovery-server/internal/utils"
@@ -46,7 +51,7 @@ var _ = Describe("#ReconcileOpenIDMeta", func() {
 	)
 
 	var (
-		reconciler *oidreconciler.Reconciler
+		reconciler *publisher.Reconciler[*corev1.Secret, oidstore.Data]
 
 		c client.Client
 		s *store.Store[oidstore.Data]
@@ -62,6 +67,8 @@ var _ = Describe("#ReconcileOpenIDMeta", func() {
 		expectStoreEntry = func(store *store.Store[oidstore.Data], key string, want oidstore.Data) {
 			got, ok := store.Read(key)
 			Expect(ok).To(BeTrue())
+			want.CompressedConfig = precompress.Compress(want.Config)
+			want.CompressedJWKS = precompress.Compress(want.JWKS)
 			Expect(got).To(Equal(want))
 		}
 
@@ -135,10 +142,11 @@ var _ = Describe("#ReconcileOpenIDMeta", func() {
 			},
 		}
 		s = store.MustNewStore(oidstore.Copy)
-		reconciler = &oidreconciler.Reconciler{
+		reconciler = &publisher.Reconciler[*corev1.Secret, oidstore.Data]{
 			Client:       c,
 			Store:        s,
 			ResyncPeriod: resyncPeriod,
+			Resource:     oidreconciler.Resource{},
 		}
 		secretNamespacedName = client.ObjectKeyFromObject(secret)
 	})
@@ -157,6 +165,85 @@ var _ = Describe("#ReconcileOpenIDMeta", func() {
 			Config: []byte(`{"issuer":"https://foo","jwks_uri":"https://foo/jwks"}`),
 			JWKS:   expectedJWKSBytes,
 		})
+
+		meta, ok := s.Metadata(secret.Name)
+		Expect(ok).To(BeTrue())
+		Expect(meta.Source).To(Equal(&store.ObjectReference{
+			Kind:            "Secret",
+			Namespace:       secret.Namespace,
+			Name:            secret.Name,
+			ResourceVersion: secret.ResourceVersion,
+		}))
+	})
+
+	It("should sign the documents", func() {
+		reconciler.Signer = &prefixSigner{}
+		Expect(c.Create(ctx, project)).To(Succeed())
+		Expect(c.Create(ctx, shoot)).To(Succeed())
+		Expect(c.Create(ctx, secret)).To(Succeed())
+
+		_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(secret)})
+		Expect(err).ToNot(HaveOccurred())
+
+		data, ok := s.Read(secret.Name)
+		Expect(ok).To(BeTrue())
+		Expect(data.ConfigSignature).To(Equal("signed:" + string(secret.Data["openid-config"])))
+		Expect(data.JWKSSignature).To(Equal("signed:" + string(expectedJWKSBytes)))
+	})
+
+	It("should resolve the entry by shoot name", func() {
+		Expect(c.Create(ctx, project)).To(Succeed())
+		Expect(c.Create(ctx, shoot)).To(Succeed())
+		Expect(c.Create(ctx, secret)).To(Succeed())
+
+		_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(secret)})
+		Expect(err).ToNot(HaveOccurred())
+
+		key, ok := s.Resolve(project.Name + "--" + shoot.Name)
+		Expect(ok).To(BeTrue())
+		Expect(key).To(Equal(secret.Name))
+	})
+
+	It("should not resolve the entry by shoot name if the project opted out", func() {
+		project.Annotations = map[string]string{"discovery.gardener.cloud/shoot-name-alias": "disabled"}
+		Expect(c.Create(ctx, project)).To(Succeed())
+		Expect(c.Create(ctx, shoot)).To(Succeed())
+		Expect(c.Create(ctx, secret)).To(Succeed())
+
+		_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(secret)})
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(s.Len()).To(Equal(1))
+		_, ok := s.Resolve(project.Name + "--" + shoot.Name)
+		Expect(ok).To(BeFalse())
+	})
+
+	It("should enqueue the secrets of the project when it opts out of the resolution by name", func() {
+		otherSecret := secret.DeepCopy()
+		otherSecret.Name = "other--" + string(shoot.UID)
+		otherProjectSecret := secret.DeepCopy()
+		otherProjectSecret.Name = "other--" + string(shoot.UID) + "-2"
+		otherProjectSecret.Labels["project.gardener.cloud/name"] = "other"
+		outsideSecret := secret.DeepCopy()
+		outsideSecret.Namespace = shootNamespace
+		Expect(c.Create(ctx, secret)).To(Succeed())
+		Expect(c.Create(ctx, otherSecret)).To(Succeed())
+		Expect(c.Create(ctx, otherProjectSecret)).To(Succeed())
+		Expect(c.Create(ctx, outsideSecret)).To(Succeed())
+
+		optedOut := project.DeepCopy()
+		optedOut.Annotations = map[string]string{"discovery.gardener.cloud/shoot-name-alias": "disabled"}
+		queue := workqueue.NewTypedRateLimitingQueue(workqueue.DefaultTypedControllerRateLimiter[reconcile.Request]())
+		defer queue.ShutDown()
+
+		h := publisher.EnqueueOnAliasOptOut(c, oidreconciler.Resource{})
+		h.Update(ctx, event.UpdateEvent{ObjectOld: project, ObjectNew: project.DeepCopy()}, queue)
+		Expect(queue.Len()).To(BeZero())
+
+		h.Update(ctx, event.UpdateEvent{ObjectOld: project, ObjectNew: optedOut}, queue)
+		Expect(queue.Len()).To(Equal(1))
+		item, _ := queue.Get()
+		Expect(item).To(Equal(reconcile.Request{NamespacedName: secretNamespacedName}))
 	})
 
 	// TODO(vpnachev): Remove this test once support for gardener/gardener <= v1.142.0 is dropped.
@@ -179,6 +266,28 @@ var _ = Describe("#ReconcileOpenIDMeta", func() {
 		})
 	})
 
+	It("should remove entry from store without rejection when the project is owned by another shard", func() {
+		Expect(c.Create(ctx, project)).To(Succeed())
+		Expect(c.Create(ctx, shoot)).To(Succeed())
+		Expect(c.Create(ctx, secret)).To(Succeed())
+		res, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: secretNamespacedName})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(res).To(Equal(ctrl.Result{RequeueAfter: resyncPeriod}))
+		expectStoreEntry(s, secret.Name, oidstore.Data{
+			Config: []byte(`{"issuer":"https://foo","jwks_uri":"https://foo/jwks"}`),
+			JWKS:   expectedJWKSBytes,
+		})
+
+		reconciler.Shard = &foreignShard{}
+		res, err = reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: secretNamespacedName})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(res).To(Equal(ctrl.Result{}))
+
+		Expect(s.Len()).To(Equal(0))
+		_, ok := s.Rejection(secret.Name)
+		Expect(ok).To(BeFalse())
+	})
+
 	DescribeTable(
 		"should remove entry from store because of failed validation",
 		func(prepFunc func()) {
@@ -203,6 +312,9 @@ var _ = Describe("#ReconcileOpenIDMeta", func() {
 			Expect(res).To(Equal(ctrl.Result{}))
 
 			Expect(s.Len()).To(Equal(0))
+			rejection, ok := s.Rejection(secret.Name)
+			Expect(ok).To(BeTrue())
+			Expect(rejection.Reason).ToNot(BeEmpty())
 		},
 		Entry("secret is missing", func() {
 			Expect(c.Delete(ctx, secret)).To(Succeed())
@@ -314,3 +426,18 @@ var _ = Describe("#ReconcileOpenIDMeta", func() {
 		}),
 	)
 })
+
+type prefixSigner struct{}
+
+func (prefixSigner) Sign(payload []byte) (string, error) { return "signed:" + string(payload), nil }
+
+func (prefixSigner) KeyID() string { return "prefix" }
+
+// foreignShard is a shard in which all projects are owned by another replica.
+type foreignShard struct{}
+
+func (*foreignShard) IsLocal(string) bool { return false }
+
+func (*foreignShard) Owner(string) sharding.Member { return sharding.Member{Identity: "other"} }
+
+func (*foreignShard) OnChange(func()) func() { return func() {} }
@@ -0,0 +1,144 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package sharding
+
+import (
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+
+	"github.com/go-logr/logr"
+
+	"github.com/gardener/gardener-discovery-server/internal/handler"
+	"github.com/gardener/gardener-discovery-server/internal/metrics"
+)
+
+// HeaderForwardedBy is set on requests forwarded to the owner of a project.
+// Requests carrying it are never forwarded again to prevent forwarding loops. It is only trusted on the
+// peer listener which authenticates the replicas, see [StripForwardedBy].
+const HeaderForwardedBy = "X-Gardener-Discovery-Forwarded-By"
+
+const (
+	resultForwarded   = "forwarded"
+	resultFallback    = "fallback"
+	resultUnavailable = "unavailable"
+
+	// retryAfterSeconds is the delay clients should wait before retrying, the lease of an unreachable owner
+	// usually expired by then and its projects are taken over by the remaining replicas.
+	retryAfterSeconds = "5"
+)
+
+// Forward returns a handler that serves requests for projects of the local replica with the
+// local handler and forwards all other requests to the owning replica. The project is read from
+// the "projectName" path value.
+// Requests are served locally as fallback if the owner cannot be reached or if a forwarded request
+// is not owned by the local replica while the members change. As the local replica does not hold the
+// documents of other projects, the fallback answers with service unavailable instead of not found.
+func Forward(shard Shard, identity string, transport http.RoundTripper, local http.Handler, log logr.Logger) http.Handler {
+	fallback := newFallback(local, log)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		projectName := r.PathValue("projectName")
+		if projectName == "" || shard.IsLocal(projectName) {
+			local.ServeHTTP(w, r)
+			return
+		}
+
+		if by := r.Header.Get(HeaderForwardedBy); by != "" {
+			handler.RequestLog(log, r).V(1).Info("Serving forwarded request for project of another replica locally", "forwardedBy", by)
+			fallback.ServeHTTP(w, r)
+			return
+		}
+
+		owner := shard.Owner(projectName)
+		target, err := url.Parse(owner.Address)
+		if err != nil || owner.Address == "" {
+			handler.RequestLog(log, r).Error(err, "Serving locally, owner has no valid address", "owner", owner.Identity)
+			fallback.ServeHTTP(w, r)
+			return
+		}
+
+		proxy := &httputil.ReverseProxy{
+			Rewrite: func(pr *httputil.ProxyRequest) {
+				pr.SetURL(target)
+				pr.SetXForwarded()
+				pr.Out.Header.Set(HeaderForwardedBy, identity)
+			},
+			Transport: transport,
+			ModifyResponse: func(resp *http.Response) error {
+				// The owner echoes the forwarded request ID which is already set on the response.
+				resp.Header.Del(handler.HeaderRequestID)
+				metrics.RecordForwardedRequest(resultForwarded)
+				return nil
+			},
+			ErrorHandler: func(w http.ResponseWriter, r *http.Request, err error) {
+				handler.RequestLog(log, r).Info("Serving locally, failed to forward request", "owner", owner.Identity, "error", err.Error())
+				fallback.ServeHTTP(w, r)
+			},
+		}
+		proxy.ServeHTTP(w, r)
+	})
+}
+
+// newFallback returns a handler serving requests for projects of other replicas with the local handler.
+// Documents the local replica does not hold are answered with service unavailable and a Retry-After header,
+// so that clients retry instead of caching that the document does not exist.
+func newFallback(local http.Handler, log logr.Logger) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fw := &fallbackWriter{ResponseWriter: w}
+		local.ServeHTTP(fw, r)
+		if !fw.notFound {
+			metrics.RecordForwardedRequest(resultFallback)
+			return
+		}
+
+		metrics.RecordForwardedRequest(resultUnavailable)
+		w.Header().Del("Content-Length")
+		w.Header().Set("Retry-After", retryAfterSeconds)
+		handler.WriteProblem(w, r, log, http.StatusServiceUnavailable, "owner of the project is unavailable")
+	})
+}
+
+// fallbackWriter passes the response of the local handler through unless it is not found.
+// Not found responses are discarded, so that they can be replaced.
+type fallbackWriter struct {
+	http.ResponseWriter
+	wroteHeader bool
+	notFound    bool
+}
+
+func (w *fallbackWriter) WriteHeader(status int) {
+	if w.wroteHeader {
+		return
+	}
+	w.wroteHeader = true
+	if status == http.StatusNotFound {
+		w.notFound = true
+		return
+	}
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *fallbackWriter) Write(b []byte) (int, error) {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+	if w.notFound {
+		return len(b), nil
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *fallbackWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// StripForwardedBy removes the [HeaderForwardedBy] header from requests received on the public listeners,
+// so that clients cannot pretend to be a replica forwarding a request.
+func StripForwardedBy(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.Header.Del(HeaderForwardedBy)
+		h.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,157 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package sharding_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/gardener/gardener-discovery-server/internal/handler"
+	"github.com/gardener/gardener-discovery-server/internal/sharding"
+)
+
+var _ = Describe("#Forward", func() {
+	var (
+		owner *httptest.Server
+		shard *staticShard
+		mux   *http.ServeMux
+
+		forwardedBy string
+		requestID   string
+
+		get = func(path string, header http.Header) (int, string) {
+			req := httptest.NewRequest(http.MethodGet, path, nil)
+			for k, v := range header {
+				req.Header[k] = v
+			}
+			rec := httptest.NewRecorder()
+			mux.ServeHTTP(rec, req)
+			body, err := io.ReadAll(rec.Result().Body)
+			Expect(err).ToNot(HaveOccurred())
+			return rec.Code, string(body)
+		}
+	)
+
+	BeforeEach(func() {
+		forwardedBy, requestID = "", ""
+		owner = httptest.NewServer(handler.RequestID(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			forwardedBy = r.Header.Get(sharding.HeaderForwardedBy)
+			requestID = handler.RequestIDFrom(r.Context())
+			_, _ = w.Write([]byte("owner " + r.URL.Path))
+		})))
+		DeferCleanup(owner.Close)
+
+		shard = &staticShard{local: map[string]bool{"local": true}, owner: sharding.Member{Identity: "b", Address: owner.URL}}
+		local := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.PathValue("projectName") == "missing" {
+				handler.WriteProblem(w, r, logr.Discard(), http.StatusNotFound, "not found")
+				return
+			}
+			_, _ = w.Write([]byte("local"))
+		})
+
+		mux = http.NewServeMux()
+		mux.Handle("/projects/{projectName}/foo", sharding.Forward(shard, "a", http.DefaultTransport, local, logr.Discard()))
+	})
+
+	It("should serve projects of the local replica locally", func() {
+		code, body := get("/projects/local/foo", nil)
+		Expect(code).To(Equal(http.StatusOK))
+		Expect(body).To(Equal("local"))
+	})
+
+	It("should forward projects of other replicas to the owner", func() {
+		code, body := get("/projects/remote/foo", nil)
+		Expect(code).To(Equal(http.StatusOK))
+		Expect(body).To(Equal("owner /projects/remote/foo"))
+		Expect(forwardedBy).To(Equal("a"))
+	})
+
+	It("should forward the request ID to the owner", func() {
+		mux = http.NewServeMux()
+		mux.Handle("/projects/{projectName}/foo", handler.RequestID(sharding.Forward(shard, "a", http.DefaultTransport, http.NotFoundHandler(), logr.Discard())))
+
+		req := httptest.NewRequest(http.MethodGet, "/projects/remote/foo", nil)
+		req.Header.Set(handler.HeaderRequestID, "foo")
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, req)
+		Expect(rec.Code).To(Equal(http.StatusOK))
+		Expect(requestID).To(Equal("foo"))
+		Expect(rec.Header().Values(handler.HeaderRequestID)).To(Equal([]string{"foo"}))
+	})
+
+	It("should serve forwarded requests locally", func() {
+		code, body := get("/projects/remote/foo", http.Header{sharding.HeaderForwardedBy: {"c"}})
+		Expect(code).To(Equal(http.StatusOK))
+		Expect(body).To(Equal("local"))
+		Expect(forwardedBy).To(BeEmpty())
+	})
+
+	It("should serve locally if the owner is unreachable", func() {
+		owner.Close()
+
+		code, body := get("/projects/remote/foo", nil)
+		Expect(code).To(Equal(http.StatusOK))
+		Expect(body).To(Equal("local"))
+	})
+
+	It("should serve locally if the owner has no address", func() {
+		shard.owner.Address = ""
+
+		code, body := get("/projects/remote/foo", nil)
+		Expect(code).To(Equal(http.StatusOK))
+		Expect(body).To(Equal("local"))
+	})
+
+	It("should reply with service unavailable if the fallback does not hold the document", func() {
+		owner.Close()
+
+		req := httptest.NewRequest(http.MethodGet, "/projects/missing/foo", nil)
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, req)
+		Expect(rec.Code).To(Equal(http.StatusServiceUnavailable))
+		Expect(rec.Header().Get("Retry-After")).To(Equal("5"))
+		Expect(rec.Header().Get("Cache-Control")).To(Equal("no-store"))
+		Expect(rec.Body.String()).To(ContainSubstring("owner of the project is unavailable"))
+		Expect(rec.Body.String()).ToNot(ContainSubstring("not found"))
+	})
+
+	It("should reply with service unavailable to forwarded requests for documents the replica does not hold", func() {
+		code, _ := get("/projects/missing/foo", http.Header{sharding.HeaderForwardedBy: {"c"}})
+		Expect(code).To(Equal(http.StatusServiceUnavailable))
+		Expect(forwardedBy).To(BeEmpty())
+	})
+})
+
+var _ = Describe("#StripForwardedBy", func() {
+	It("should remove the forwarded header set by clients", func() {
+		var forwardedBy string
+		h := sharding.StripForwardedBy(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+			forwardedBy = r.Header.Get(sharding.HeaderForwardedBy)
+		}))
+
+		req := httptest.NewRequest(http.MethodGet, "/projects/remote/foo", nil)
+		req.Header.Set(sharding.HeaderForwardedBy, "c")
+		h.ServeHTTP(httptest.NewRecorder(), req)
+		Expect(forwardedBy).To(BeEmpty())
+	})
+})
+
+// staticShard owns the projects in local, all other projects are owned by owner.
+type staticShard struct {
+	local map[string]bool
+	owner sharding.Member
+}
+
+func (s *staticShard) IsLocal(projectName string) bool { return s.local[projectName] }
+
+func (s *staticShard) Owner(string) sharding.Member { return s.owner }
+
+func (*staticShard) OnChange(func()) func() { return func() {} }
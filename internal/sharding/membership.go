@@ -0,0 +1,275 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package sharding
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/go-logr/logr"
+	coordinationv1 "k8s.io/api/coordination/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/gardener/gardener-discovery-server/internal/metrics"
+)
+
+const (
+	// LabelShardMember is the label identifying the leases of the replicas sharing the projects.
+	LabelShardMember = "discovery.gardener.cloud/shard-member"
+	// AnnotationShardAddress is the annotation holding the URL other replicas forward requests to.
+	AnnotationShardAddress = "discovery.gardener.cloud/shard-address"
+
+	leaseNamePrefix = "gardener-discovery-server-"
+)
+
+// Member is a replica sharing the projects.
+type Member struct {
+	// Identity is the unique name of the replica.
+	Identity string
+	// Address is the URL other replicas forward requests to.
+	Address string
+}
+
+// Shard decides which projects are reconciled and served by the local replica.
+// It only splits the reconciliation and serving work, the informers of every replica still
+// watch the objects of all projects, as the owned projects change with the members.
+type Shard interface {
+	// IsLocal reports whether the project is owned by the local replica.
+	IsLocal(projectName string) bool
+	// Owner returns the replica owning the project.
+	Owner(projectName string) Member
+	// OnChange registers a function that is called whenever the ownership of projects may have changed.
+	// The returned function removes the registration.
+	OnChange(fn func()) (remove func())
+}
+
+// Config is the configuration of the [Membership].
+type Config struct {
+	// Namespace is the namespace of the leases.
+	Namespace string
+	// Identity is the unique name of the local replica.
+	Identity string
+	// Address is the URL other replicas forward requests to.
+	Address string
+	// LeaseDuration is the duration after which a replica that did not renew its lease is considered gone.
+	LeaseDuration time.Duration
+	// Garden is the name of the garden the leases are maintained in, it is used to label the metrics.
+	Garden string
+}
+
+// Membership maintains the lease of the local replica and tracks the leases of all replicas.
+// Projects are assigned to the replicas by rendezvous hashing of the project name,
+// so that only the projects of a joining or leaving replica move.
+type Membership struct {
+	reader client.Reader
+	writer client.Writer
+	conf   Config
+	log    logr.Logger
+
+	mutex     sync.RWMutex
+	members   []Member
+	listeners []*listener
+	synced    atomic.Bool
+}
+
+var _ Shard = (*Membership)(nil)
+
+// NewMembership returns a [Membership] in which the local replica owns all projects until
+// the leases of the other replicas are observed. It is not ready before, see [Membership.Ready].
+// Leases are read with the reader, so that they are not cached by the manager.
+func NewMembership(reader client.Reader, writer client.Writer, conf Config, log logr.Logger) *Membership {
+	return &Membership{
+		reader:  reader,
+		writer:  writer,
+		conf:    conf,
+		log:     log,
+		members: []Member{{Identity: conf.Identity, Address: conf.Address}},
+	}
+}
+
+// NeedLeaderElection implements the LeaderElectionRunnable interface, all replicas must take part.
+func (m *Membership) NeedLeaderElection() bool {
+	return false
+}
+
+// Start renews the lease of the local replica and refreshes the members until the context is canceled.
+// The lease is deleted on return, so that the projects are taken over by the remaining replicas immediately.
+func (m *Membership) Start(ctx context.Context) error {
+	ticker := time.NewTicker(m.conf.LeaseDuration / 3)
+	defer ticker.Stop()
+
+	for {
+		m.Sync(ctx)
+
+		select {
+		case <-ctx.Done():
+			releaseCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			if err := m.release(releaseCtx); err != nil {
+				m.log.Error(err, "Failed to release lease")
+			}
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// Sync renews the lease of the local replica and refreshes the members.
+func (m *Membership) Sync(ctx context.Context) {
+	if err := m.renew(ctx); err != nil {
+		m.log.Error(err, "Failed to renew lease")
+	}
+
+	members, err := m.list(ctx)
+	if err != nil {
+		m.log.Error(err, "Failed to list leases, keeping current members")
+		return
+	}
+
+	m.mutex.Lock()
+	changed := !slices.Equal(m.members, members)
+	m.members = members
+	listeners := slices.Clone(m.listeners)
+	m.mutex.Unlock()
+
+	m.synced.Store(true)
+	metrics.SetShardMembers(m.conf.Garden, len(members))
+	if !changed {
+		return
+	}
+
+	m.log.Info("Shard members changed", "members", len(members))
+	for _, l := range listeners {
+		l.fn()
+	}
+}
+
+// Ready reports whether the leases of the replicas were listed, the ownership of the projects is not known before.
+func (m *Membership) Ready(_ *http.Request) error {
+	if !m.synced.Load() {
+		return errors.New("shard members are not yet known")
+	}
+	return nil
+}
+
+// Members returns the replicas sharing the projects.
+func (m *Membership) Members() []Member {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+	return slices.Clone(m.members)
+}
+
+// IsLocal reports whether the project is owned by the local replica.
+func (m *Membership) IsLocal(projectName string) bool {
+	return m.Owner(projectName).Identity == m.conf.Identity
+}
+
+// Owner returns the replica with the highest score for the project.
+func (m *Membership) Owner(projectName string) Member {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	var (
+		owner     Member
+		bestScore uint64
+	)
+	for i, member := range m.members {
+		if s := score(member.Identity, projectName); i == 0 || s > bestScore {
+			owner, bestScore = member, s
+		}
+	}
+	return owner
+}
+
+// OnChange registers a function that is called whenever the members change.
+// The returned function removes the registration, e.g. once the manager of the registering controller stopped.
+func (m *Membership) OnChange(fn func()) func() {
+	l := &listener{fn: fn}
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.listeners = append(m.listeners, l)
+	return func() {
+		m.mutex.Lock()
+		defer m.mutex.Unlock()
+		m.listeners = slices.DeleteFunc(m.listeners, func(other *listener) bool { return other == l })
+	}
+}
+
+// listener is a registered function, it is identified by its address when it is removed.
+type listener struct {
+	fn func()
+}
+
+func score(identity, projectName string) uint64 {
+	sum := sha256.Sum256([]byte(identity + "/" + projectName))
+	return binary.BigEndian.Uint64(sum[:8])
+}
+
+func (m *Membership) leaseName() string {
+	return leaseNamePrefix + m.conf.Identity
+}
+
+func (m *Membership) renew(ctx context.Context) error {
+	lease := &coordinationv1.Lease{}
+	err := m.reader.Get(ctx, client.ObjectKey{Namespace: m.conf.Namespace, Name: m.leaseName()}, lease)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+
+	exists := err == nil
+	lease.Name = m.leaseName()
+	lease.Namespace = m.conf.Namespace
+	metav1.SetMetaDataLabel(&lease.ObjectMeta, LabelShardMember, "true")
+	metav1.SetMetaDataAnnotation(&lease.ObjectMeta, AnnotationShardAddress, m.conf.Address)
+	lease.Spec.HolderIdentity = ptr.To(m.conf.Identity)
+	lease.Spec.LeaseDurationSeconds = ptr.To(int32(m.conf.LeaseDuration / time.Second))
+	lease.Spec.RenewTime = &metav1.MicroTime{Time: time.Now()}
+
+	if exists {
+		return m.writer.Update(ctx, lease)
+	}
+	return m.writer.Create(ctx, lease)
+}
+
+func (m *Membership) release(ctx context.Context) error {
+	lease := &coordinationv1.Lease{ObjectMeta: metav1.ObjectMeta{Name: m.leaseName(), Namespace: m.conf.Namespace}}
+	return client.IgnoreNotFound(m.writer.Delete(ctx, lease))
+}
+
+func (m *Membership) list(ctx context.Context) ([]Member, error) {
+	leases := &coordinationv1.LeaseList{}
+	if err := m.reader.List(ctx, leases, client.InNamespace(m.conf.Namespace), client.MatchingLabels{LabelShardMember: "true"}); err != nil {
+		return nil, fmt.Errorf("failed to list leases: %w", err)
+	}
+
+	// The local replica is always a member, even if its lease could not be renewed.
+	members := []Member{{Identity: m.conf.Identity, Address: m.conf.Address}}
+	now := time.Now()
+	for _, lease := range leases.Items {
+		spec := lease.Spec
+		if spec.HolderIdentity == nil || *spec.HolderIdentity == m.conf.Identity || spec.RenewTime == nil || spec.LeaseDurationSeconds == nil {
+			continue
+		}
+		if spec.RenewTime.Add(time.Duration(*spec.LeaseDurationSeconds) * time.Second).Before(now) {
+			continue
+		}
+		members = append(members, Member{Identity: *spec.HolderIdentity, Address: lease.Annotations[AnnotationShardAddress]})
+	}
+
+	slices.SortFunc(members, func(a, b Member) int { return strings.Compare(a.Identity, b.Identity) })
+	return members, nil
+}
@@ -0,0 +1,187 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package sharding_test
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gardener/gardener/pkg/client/kubernetes"
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	coordinationv1 "k8s.io/api/coordination/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/gardener/gardener-discovery-server/internal/sharding"
+)
+
+var _ = Describe("Membership", func() {
+	const namespace = "garden"
+
+	var (
+		ctx        context.Context
+		c          client.Client
+		membership *sharding.Membership
+
+		newMembership = func(identity string) *sharding.Membership {
+			return sharding.NewMembership(c, c, sharding.Config{
+				Namespace:     namespace,
+				Identity:      identity,
+				Address:       "https://" + identity,
+				LeaseDuration: 15 * time.Second,
+			}, logr.Discard())
+		}
+
+		newLease = func(identity string, renewTime time.Time) *coordinationv1.Lease {
+			return &coordinationv1.Lease{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "gardener-discovery-server-" + identity,
+					Namespace:   namespace,
+					Labels:      map[string]string{sharding.LabelShardMember: "true"},
+					Annotations: map[string]string{sharding.AnnotationShardAddress: "https://" + identity},
+				},
+				Spec: coordinationv1.LeaseSpec{
+					HolderIdentity:       ptr.To(identity),
+					LeaseDurationSeconds: ptr.To[int32](15),
+					RenewTime:            &metav1.MicroTime{Time: renewTime},
+				},
+			}
+		}
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		c = fake.NewClientBuilder().WithScheme(kubernetes.GardenScheme).Build()
+		membership = newMembership("a")
+	})
+
+	It("should own all projects before the first sync", func() {
+		Expect(membership.Members()).To(ConsistOf(sharding.Member{Identity: "a", Address: "https://a"}))
+		Expect(membership.IsLocal("foo")).To(BeTrue())
+	})
+
+	It("should only be ready once the leases were listed", func() {
+		Expect(membership.Ready(nil)).To(MatchError(ContainSubstring("not yet known")))
+
+		membership.Sync(ctx)
+		Expect(membership.Ready(nil)).To(Succeed())
+	})
+
+	It("should create and renew its lease", func() {
+		membership.Sync(ctx)
+
+		lease := &coordinationv1.Lease{}
+		Expect(c.Get(ctx, client.ObjectKey{Namespace: namespace, Name: "gardener-discovery-server-a"}, lease)).To(Succeed())
+		Expect(lease.Labels).To(HaveKeyWithValue(sharding.LabelShardMember, "true"))
+		Expect(lease.Annotations).To(HaveKeyWithValue(sharding.AnnotationShardAddress, "https://a"))
+		Expect(*lease.Spec.HolderIdentity).To(Equal("a"))
+		Expect(*lease.Spec.LeaseDurationSeconds).To(BeEquivalentTo(15))
+		firstRenewal := lease.Spec.RenewTime.Time
+
+		membership.Sync(ctx)
+		Expect(c.Get(ctx, client.ObjectKeyFromObject(lease), lease)).To(Succeed())
+		Expect(lease.Spec.RenewTime.Time).To(BeTemporally(">=", firstRenewal))
+	})
+
+	It("should only consider replicas with valid leases", func() {
+		Expect(c.Create(ctx, newLease("b", time.Now()))).To(Succeed())
+		Expect(c.Create(ctx, newLease("c", time.Now().Add(-time.Minute)))).To(Succeed())
+		unlabeled := newLease("d", time.Now())
+		unlabeled.Labels = nil
+		Expect(c.Create(ctx, unlabeled)).To(Succeed())
+
+		membership.Sync(ctx)
+		Expect(membership.Members()).To(Equal([]sharding.Member{
+			{Identity: "a", Address: "https://a"},
+			{Identity: "b", Address: "https://b"},
+		}))
+	})
+
+	It("should assign each project to exactly one replica", func() {
+		other := newMembership("b")
+		membership.Sync(ctx)
+		other.Sync(ctx)
+		membership.Sync(ctx)
+
+		local := 0
+		for i := range 1000 {
+			project := fmt.Sprintf("project-%d", i)
+			Expect(membership.Owner(project)).To(Equal(other.Owner(project)))
+			Expect(membership.IsLocal(project)).ToNot(Equal(other.IsLocal(project)))
+			if membership.IsLocal(project) {
+				local++
+			}
+		}
+		Expect(local).To(BeNumerically("~", 500, 100))
+	})
+
+	It("should only move the projects of a leaving replica", func() {
+		for _, identity := range []string{"b", "c"} {
+			Expect(c.Create(ctx, newLease(identity, time.Now()))).To(Succeed())
+		}
+		membership.Sync(ctx)
+
+		owners := map[string]string{}
+		for i := range 1000 {
+			project := fmt.Sprintf("project-%d", i)
+			owners[project] = membership.Owner(project).Identity
+		}
+
+		Expect(c.Delete(ctx, newLease("c", time.Now()))).To(Succeed())
+		membership.Sync(ctx)
+
+		for project, owner := range owners {
+			if owner != "c" {
+				Expect(membership.Owner(project).Identity).To(Equal(owner))
+			}
+		}
+	})
+
+	It("should notify listeners when the members change", func() {
+		notified := 0
+		membership.OnChange(func() { notified++ })
+
+		membership.Sync(ctx)
+		Expect(notified).To(Equal(0))
+
+		Expect(c.Create(ctx, newLease("b", time.Now()))).To(Succeed())
+		membership.Sync(ctx)
+		Expect(notified).To(Equal(1))
+
+		membership.Sync(ctx)
+		Expect(notified).To(Equal(1))
+	})
+
+	It("should not notify removed listeners", func() {
+		notified := 0
+		remove := membership.OnChange(func() { notified++ })
+		remove()
+
+		Expect(c.Create(ctx, newLease("b", time.Now()))).To(Succeed())
+		membership.Sync(ctx)
+		Expect(notified).To(Equal(0))
+	})
+
+	It("should delete its lease when stopped", func() {
+		ctx, cancel := context.WithCancel(ctx)
+		done := make(chan error)
+		go func() { done <- membership.Start(ctx) }()
+
+		lease := &coordinationv1.Lease{}
+		key := client.ObjectKey{Namespace: namespace, Name: "gardener-discovery-server-a"}
+		Eventually(func() error { return c.Get(ctx, key, lease) }).Should(Succeed())
+
+		cancel()
+		Eventually(done).Should(Receive(BeNil()))
+		err := c.Get(context.Background(), key, lease)
+		Expect(apierrors.IsNotFound(err)).To(BeTrue())
+	})
+})
@@ -0,0 +1,58 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package sharding
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+// Notifier calls the registered functions whenever its state changes, e.g. a [Shard] when the ownership of projects changes.
+// The function returned on registration removes it.
+type Notifier interface {
+	OnChange(fn func()) (remove func())
+}
+
+// EnqueueOnChange returns a source that enqueues all listed objects accepted by the filter
+// whenever the notifier reports a change. For a [Shard] this lets the reconcilers pick up the projects
+// the local replica took over and drop the ones it handed over.
+// The registration is removed once the context of the source is canceled, as the notifier may outlive the manager.
+func EnqueueOnChange(notifier Notifier, reader client.Reader, newList func() client.ObjectList, filter func(client.Object) bool, opts ...client.ListOption) source.Source {
+	return source.Func(func(ctx context.Context, queue workqueue.TypedRateLimitingInterface[reconcile.Request]) error {
+		remove := notifier.OnChange(func() {
+			if ctx.Err() != nil {
+				return
+			}
+
+			list := newList()
+			if err := reader.List(ctx, list, opts...); err != nil {
+				logf.FromContext(ctx).Error(err, "Failed to list objects after change")
+				return
+			}
+
+			if err := meta.EachListItem(list, func(obj runtime.Object) error {
+				o, ok := obj.(client.Object)
+				if ok && filter(o) {
+					queue.Add(reconcile.Request{NamespacedName: client.ObjectKeyFromObject(o)})
+				}
+				return nil
+			}); err != nil {
+				logf.FromContext(ctx).Error(err, "Failed to enqueue objects after change")
+			}
+		})
+		go func() {
+			<-ctx.Done()
+			remove()
+		}()
+		return nil
+	})
+}
@@ -0,0 +1,108 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package sharding_test
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"sync"
+
+	"github.com/gardener/gardener/pkg/client/kubernetes"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/gardener/gardener-discovery-server/internal/sharding"
+)
+
+var _ = Describe("#EnqueueOnChange", func() {
+	It("should enqueue the accepted objects when the shard changes", func() {
+		ctx := context.Background()
+		c := fake.NewClientBuilder().WithScheme(kubernetes.GardenScheme).WithObjects(
+			&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "ns"}},
+			&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "bar", Namespace: "ns"}},
+			&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "baz", Namespace: "other"}},
+		).Build()
+
+		shard := &notifyingShard{}
+		src := sharding.EnqueueOnChange(shard, c,
+			func() client.ObjectList { return &corev1.SecretList{} },
+			func(obj client.Object) bool { return obj.GetName() != "bar" },
+			client.InNamespace("ns"),
+		)
+
+		queue := workqueue.NewTypedRateLimitingQueue(workqueue.DefaultTypedControllerRateLimiter[reconcile.Request]())
+		DeferCleanup(queue.ShutDown)
+		Expect(src.Start(ctx, queue)).To(Succeed())
+		Expect(queue.Len()).To(Equal(0))
+
+		shard.change()
+		Expect(queue.Len()).To(Equal(1))
+		item, _ := queue.Get()
+		Expect(item).To(Equal(reconcile.Request{NamespacedName: client.ObjectKey{Namespace: "ns", Name: "foo"}}))
+	})
+
+	It("should remove the registration once the context is canceled", func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		shard := &notifyingShard{}
+		src := sharding.EnqueueOnChange(shard, fake.NewClientBuilder().WithScheme(kubernetes.GardenScheme).Build(),
+			func() client.ObjectList { return &corev1.SecretList{} },
+			func(client.Object) bool { return true },
+		)
+
+		queue := workqueue.NewTypedRateLimitingQueue(workqueue.DefaultTypedControllerRateLimiter[reconcile.Request]())
+		DeferCleanup(queue.ShutDown)
+		Expect(src.Start(ctx, queue)).To(Succeed())
+		Expect(shard.registered()).To(Equal(1))
+
+		cancel()
+		Eventually(shard.registered).Should(BeZero())
+	})
+})
+
+// notifyingShard calls the registered functions on change.
+type notifyingShard struct {
+	staticShard
+	mutex     sync.Mutex
+	listeners map[int]func()
+	next      int
+}
+
+func (s *notifyingShard) OnChange(fn func()) func() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.listeners == nil {
+		s.listeners = map[int]func(){}
+	}
+	id := s.next
+	s.listeners[id] = fn
+	s.next++
+	return func() {
+		s.mutex.Lock()
+		defer s.mutex.Unlock()
+		delete(s.listeners, id)
+	}
+}
+
+func (s *notifyingShard) registered() int {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return len(s.listeners)
+}
+
+func (s *notifyingShard) change() {
+	s.mutex.Lock()
+	listeners := slices.Collect(maps.Values(s.listeners))
+	s.mutex.Unlock()
+	for _, fn := range listeners {
+		fn()
+	}
+}
@@ -0,0 +1,307 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package signing
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/go-jose/go-jose/v4"
+	"github.com/go-logr/logr"
+)
+
+// Signer creates detached JSON Web Signatures.
+type Signer interface {
+	// Sign returns the detached compact serialization of the JWS over the payload,
+	// i.e. the serialization with an empty payload as described in RFC 7515 appendix F.
+	Sign(payload []byte) (string, error)
+	// KeyID returns the ID of the key new signatures are created with.
+	KeyID() string
+}
+
+// Sign returns the signature of the payload, it is empty if the signer is nil, i.e. signing is disabled.
+func Sign(signer Signer, payload []byte) (string, error) {
+	if signer == nil {
+		return "", nil
+	}
+	return signer.Sign(payload)
+}
+
+// DynamicKey is a [Signer] whose private key is read from a PEM encoded file and reloaded if it changes.
+// A reloaded key is published for the publish delay before documents are signed with it, so that verifiers
+// holding a cached key set already know it. The previous key stays published after the switch,
+// so that signatures created before are still verifiable.
+type DynamicKey struct {
+	keyFile      string
+	interval     time.Duration
+	publishDelay time.Duration
+	log          logr.Logger
+
+	lock      sync.RWMutex
+	current   *key
+	next      *key
+	nextSince time.Time
+	previous  *key
+	listeners []*listener
+}
+
+var _ Signer = (*DynamicKey)(nil)
+
+type key struct {
+	raw    []byte
+	signer jose.Signer
+	public jose.JSONWebKey
+}
+
+// New returns a new instance of [DynamicKey].
+func New(keyFile string, opts ...Option) (*DynamicKey, error) {
+	k, err := loadKey(keyFile)
+	if err != nil {
+		return nil, err
+	}
+
+	dynamicKey := &DynamicKey{
+		keyFile:  keyFile,
+		interval: time.Minute,
+		log:      logr.Discard(),
+		current:  k,
+	}
+
+	for _, opt := range opts {
+		opt(dynamicKey)
+	}
+
+	go func() {
+		ticker := time.NewTicker(dynamicKey.interval)
+		for range ticker.C {
+			if err := dynamicKey.reloadKey(); err != nil {
+				dynamicKey.log.Error(err, "Failed to reload signing key")
+			}
+		}
+	}()
+
+	return dynamicKey, nil
+}
+
+func (dk *DynamicKey) reloadKey() error {
+	k, err := loadKey(dk.keyFile)
+	if err != nil {
+		return err
+	}
+	dk.lock.Lock()
+	switch {
+	case slices.Equal(k.raw, dk.current.raw):
+		// do not renew the key if the current equals the new
+		dk.next = nil
+	case dk.next == nil || !slices.Equal(k.raw, dk.next.raw):
+		dk.next, dk.nextSince = k, time.Now()
+		dk.log.Info("Signing key was reloaded, publishing it before signing with it", "keyID", k.public.KeyID, "publishDelay", dk.publishDelay)
+	}
+	switched := dk.next != nil && time.Since(dk.nextSince) >= dk.publishDelay
+	if switched {
+		dk.previous, dk.current, dk.next = dk.current, dk.next, nil
+		dk.log.Info("Switched to the reloaded signing key", "keyID", dk.current.public.KeyID)
+	}
+	listeners := slices.Clone(dk.listeners)
+	dk.lock.Unlock()
+	if switched {
+		for _, l := range listeners {
+			l.fn()
+		}
+	}
+	return nil
+}
+
+// OnChange registers a listener which is called after documents are signed with another key,
+// e.g. to renew the signatures of stored documents. The returned function removes the listener.
+func (dk *DynamicKey) OnChange(fn func()) func() {
+	l := &listener{fn: fn}
+	dk.lock.Lock()
+	defer dk.lock.Unlock()
+	dk.listeners = append(dk.listeners, l)
+	return func() {
+		dk.lock.Lock()
+		defer dk.lock.Unlock()
+		dk.listeners = slices.DeleteFunc(dk.listeners, func(other *listener) bool { return other == l })
+	}
+}
+
+// listener is a registered function, it is identified by its address when it is removed.
+type listener struct {
+	fn func()
+}
+
+// Sign implements [Signer].
+func (dk *DynamicKey) Sign(payload []byte) (string, error) {
+	dk.lock.RLock()
+	defer dk.lock.RUnlock()
+	jws, err := dk.current.signer.Sign(payload)
+	if err != nil {
+		return "", err
+	}
+	return jws.DetachedCompactSerialize()
+}
+
+// KeyID implements [Signer].
+func (dk *DynamicKey) KeyID() string {
+	dk.lock.RLock()
+	defer dk.lock.RUnlock()
+	return dk.current.public.KeyID
+}
+
+// JWKS returns the JSON encoded key set of the public keys, starting with the current one
+// followed by the key documents are signed with next and the previous one.
+func (dk *DynamicKey) JWKS() ([]byte, error) {
+	dk.lock.RLock()
+	defer dk.lock.RUnlock()
+	keySet := jose.JSONWebKeySet{Keys: []jose.JSONWebKey{dk.current.public}}
+	if dk.next != nil {
+		keySet.Keys = append(keySet.Keys, dk.next.public)
+	}
+	if dk.previous != nil {
+		keySet.Keys = append(keySet.Keys, dk.previous.public)
+	}
+	return json.Marshal(keySet)
+}
+
+// loadKey reads a PKCS #8, PKCS #1 or SEC 1 encoded private key and creates a signer for it.
+// The key ID is the base64url encoded SHA-256 thumbprint of the public key.
+func loadKey(keyFile string) (*key, error) {
+	raw, err := os.ReadFile(keyFile) // #nosec G304 -- the path is configured by the operator.
+	if err != nil {
+		return nil, fmt.Errorf("failed to read signing key: %w", err)
+	}
+	block, _ := pem.Decode(raw)
+	if block == nil {
+		return nil, errors.New("signing key is not PEM encoded")
+	}
+
+	var private crypto.Signer
+	if k, err := x509.ParsePKCS8PrivateKey(block.Bytes); err == nil {
+		signer, ok := k.(crypto.Signer)
+		if !ok {
+			return nil, fmt.Errorf("unsupported signing key type %T", k)
+		}
+		private = signer
+	} else if k, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		private = k
+	} else if k, err := x509.ParseECPrivateKey(block.Bytes); err == nil {
+		private = k
+	} else {
+		return nil, errors.New("failed to parse signing key")
+	}
+
+	alg, err := algorithm(private)
+	if err != nil {
+		return nil, err
+	}
+	public := jose.JSONWebKey{Key: private.Public(), Algorithm: string(alg), Use: "sig"}
+	thumbprint, err := public.Thumbprint(crypto.SHA256)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compute key thumbprint: %w", err)
+	}
+	public.KeyID = base64.RawURLEncoding.EncodeToString(thumbprint)
+
+	signer, err := jose.NewSigner(
+		jose.SigningKey{Algorithm: alg, Key: jose.JSONWebKey{Key: private, KeyID: public.KeyID}},
+		nil,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create signer: %w", err)
+	}
+	return &key{raw: raw, signer: signer, public: public}, nil
+}
+
+func algorithm(private crypto.Signer) (jose.SignatureAlgorithm, error) {
+	switch k := private.(type) {
+	case *rsa.PrivateKey:
+		if k.N.BitLen() < 2048 {
+			return "", errors.New("RSA signing keys must have at least 2048 bits")
+		}
+		return jose.RS256, nil
+	case *ecdsa.PrivateKey:
+		switch k.Curve {
+		case elliptic.P256():
+			return jose.ES256, nil
+		case elliptic.P384():
+			return jose.ES384, nil
+		case elliptic.P521():
+			return jose.ES512, nil
+		}
+		return "", errors.New("unsupported elliptic curve of signing key")
+	case ed25519.PrivateKey:
+		return jose.EdDSA, nil
+	}
+	return "", fmt.Errorf("unsupported signing key type %T", private)
+}
+
+// Signature caches the signature of a static payload.
+// It is only renewed when the signer switches to another key.
+type Signature struct {
+	signer  Signer
+	payload []byte
+
+	lock      sync.Mutex
+	keyID     string
+	signature string
+}
+
+// NewSignature returns a [Signature] of the payload.
+func NewSignature(signer Signer, payload []byte) *Signature {
+	return &Signature{signer: signer, payload: payload}
+}
+
+// Get returns the signature of the payload.
+func (s *Signature) Get() (string, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if keyID := s.signer.KeyID(); s.signature == "" || keyID != s.keyID {
+		signature, err := s.signer.Sign(s.payload)
+		if err != nil {
+			return "", err
+		}
+		s.keyID, s.signature = keyID, signature
+	}
+	return s.signature, nil
+}
+
+// Option can be used to configure [DynamicKey].
+type Option func(*DynamicKey)
+
+// WithRefreshInterval sets the interval that will be used
+// to periodically check if the signing key should be reloaded.
+func WithRefreshInterval(interval time.Duration) Option {
+	return func(dk *DynamicKey) {
+		dk.interval = interval
+	}
+}
+
+// WithPublishDelay sets the duration a reloaded key is published before documents are signed with it.
+// It should cover the duration verifiers cache the published key set.
+func WithPublishDelay(delay time.Duration) Option {
+	return func(dk *DynamicKey) {
+		dk.publishDelay = delay
+	}
+}
+
+// WithLogger sets the logger for [DynamicKey].
+func WithLogger(log logr.Logger) Option {
+	return func(dk *DynamicKey) {
+		dk.log = log
+	}
+}
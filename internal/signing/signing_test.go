@@ -0,0 +1,179 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package signing_test
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/go-jose/go-jose/v4"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/gardener/gardener-discovery-server/internal/signing"
+)
+
+var _ = Describe("Signing", func() {
+	var (
+		keyFile string
+
+		writeKey = func(key crypto.Signer) {
+			der, err := x509.MarshalPKCS8PrivateKey(key)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), 0o600)).To(Succeed())
+		}
+		newECKey = func() crypto.Signer {
+			key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+			Expect(err).ToNot(HaveOccurred())
+			return key
+		}
+		keySet = func(dk *signing.DynamicKey) jose.JSONWebKeySet {
+			raw, err := dk.JWKS()
+			Expect(err).ToNot(HaveOccurred())
+			var out jose.JSONWebKeySet
+			Expect(json.Unmarshal(raw, &out)).To(Succeed())
+			return out
+		}
+		verify = func(signature string, payload []byte, keys jose.JSONWebKeySet) error {
+			jws, err := jose.ParseDetached(signature, payload, []jose.SignatureAlgorithm{jose.ES256, jose.RS256})
+			if err != nil {
+				return err
+			}
+			matching := keys.Key(jws.Signatures[0].Header.KeyID)
+			Expect(matching).To(HaveLen(1))
+			_, err = jws.Verify(matching[0])
+			return err
+		}
+	)
+
+	BeforeEach(func() {
+		keyFile = filepath.Join(GinkgoT().TempDir(), "key.pem")
+	})
+
+	Describe("#DynamicKey", func() {
+		It("should create detached signatures verifiable with the published keys", func() {
+			writeKey(newECKey())
+			dk, err := signing.New(keyFile)
+			Expect(err).ToNot(HaveOccurred())
+
+			payload := []byte(`{"keys":[]}`)
+			signature, err := dk.Sign(payload)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(strings.Split(signature, ".")).To(HaveLen(3))
+			Expect(strings.Split(signature, ".")[1]).To(BeEmpty())
+
+			keys := keySet(dk)
+			Expect(keys.Keys).To(HaveLen(1))
+			Expect(keys.Keys[0].KeyID).To(Equal(dk.KeyID()))
+			Expect(keys.Keys[0].IsPublic()).To(BeTrue())
+			Expect(verify(signature, payload, keys)).To(Succeed())
+			Expect(verify(signature, []byte(`{"keys":[{}]}`), keys)).ToNot(Succeed())
+		})
+
+		It("should reload the key and keep publishing the previous one", func() {
+			writeKey(newECKey())
+			dk, err := signing.New(keyFile, signing.WithRefreshInterval(50*time.Millisecond))
+			Expect(err).ToNot(HaveOccurred())
+			previousKeyID := dk.KeyID()
+			payload := []byte(`{}`)
+			previousSignature, err := dk.Sign(payload)
+			Expect(err).ToNot(HaveOccurred())
+
+			writeKey(newECKey())
+			Eventually(dk.KeyID).ShouldNot(Equal(previousKeyID))
+
+			keys := keySet(dk)
+			Expect(keys.Keys).To(HaveLen(2))
+			Expect(keys.Keys[0].KeyID).To(Equal(dk.KeyID()))
+			Expect(keys.Keys[1].KeyID).To(Equal(previousKeyID))
+			Expect(verify(previousSignature, payload, keys)).To(Succeed())
+		})
+
+		It("should publish the reloaded key before signing with it", func() {
+			writeKey(newECKey())
+			dk, err := signing.New(keyFile, signing.WithRefreshInterval(50*time.Millisecond), signing.WithPublishDelay(500*time.Millisecond))
+			Expect(err).ToNot(HaveOccurred())
+			previousKeyID := dk.KeyID()
+			var changes atomic.Int32
+			dk.OnChange(func() { changes.Add(1) })
+
+			writeKey(newECKey())
+			Eventually(func() []jose.JSONWebKey { return keySet(dk).Keys }).Should(HaveLen(2))
+			Expect(dk.KeyID()).To(Equal(previousKeyID))
+			Expect(keySet(dk).Keys[0].KeyID).To(Equal(previousKeyID))
+			nextKeyID := keySet(dk).Keys[1].KeyID
+			Expect(changes.Load()).To(BeZero())
+
+			Eventually(dk.KeyID).Should(Equal(nextKeyID))
+			keys := keySet(dk)
+			Expect(keys.Keys).To(HaveLen(2))
+			Expect(keys.Keys[0].KeyID).To(Equal(nextKeyID))
+			Expect(keys.Keys[1].KeyID).To(Equal(previousKeyID))
+			Eventually(changes.Load).Should(Equal(int32(1)))
+			Consistently(changes.Load, 200*time.Millisecond).Should(Equal(int32(1)))
+		})
+
+		It("should reject weak RSA keys", func() {
+			key, err := rsa.GenerateKey(rand.Reader, 1024) // #nosec G403 -- weak key on purpose
+			Expect(err).ToNot(HaveOccurred())
+			writeKey(key)
+
+			_, err = signing.New(keyFile)
+			Expect(err).To(MatchError(ContainSubstring("at least 2048 bits")))
+		})
+
+		It("should fail for files without PEM encoded key", func() {
+			Expect(os.WriteFile(keyFile, []byte("foo"), 0o600)).To(Succeed())
+
+			_, err := signing.New(keyFile)
+			Expect(err).To(MatchError("signing key is not PEM encoded"))
+		})
+	})
+
+	Describe("#Sign", func() {
+		It("should not sign without signer", func() {
+			Expect(signing.Sign(nil, []byte("foo"))).To(BeEmpty())
+		})
+	})
+
+	Describe("#Signature", func() {
+		It("should only renew the signature when the key changes", func() {
+			signer := &fakeSigner{keyID: "first"}
+			signature := signing.NewSignature(signer, []byte("foo"))
+
+			Expect(signature.Get()).To(Equal("first-1"))
+			Expect(signature.Get()).To(Equal("first-1"))
+
+			signer.keyID = "second"
+			Expect(signature.Get()).To(Equal("second-2"))
+		})
+	})
+})
+
+type fakeSigner struct {
+	keyID string
+	calls int
+}
+
+func (f *fakeSigner) Sign([]byte) (string, error) {
+	f.calls++
+	return f.keyID + "-" + strconv.Itoa(f.calls), nil
+}
+
+func (f *fakeSigner) KeyID() string {
+	return f.keyID
+}
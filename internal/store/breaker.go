@@ -0,0 +1,157 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package store
+
+import (
+	"maps"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	"k8s.io/utils/clock"
+)
+
+// BreakerConfig configures a [DeletionBreaker].
+type BreakerConfig struct {
+	// Threshold is the fraction of entries that may be deleted within Window before the breaker opens.
+	Threshold float64
+	// Window is the period in which deletions are counted.
+	Window time.Duration
+	// MinDeletions is the number of deletions within Window that never open the breaker.
+	// It keeps small stores from opening the breaker on regular deletions.
+	MinDeletions int
+	// Clock is used to measure the window. It defaults to the real clock.
+	Clock clock.PassiveClock
+}
+
+// BreakerState describes the state of a [DeletionBreaker].
+type BreakerState struct {
+	Open bool `json:"open"`
+	// Since is the time the breaker opened.
+	Since *time.Time `json:"since,omitempty"`
+	// PausedDeletions are the keys whose deletion is paused, sorted by key.
+	PausedDeletions []string `json:"pausedDeletions"`
+}
+
+// DeletionBreaker is a [Writer] that pauses deletions from the wrapped [Store]
+// once more entries are deleted within a window than the configured threshold allows,
+// e.g. because the garden cluster unexpectedly reports objects as missing.
+// It closes again once all paused entries are written again or it is released.
+type DeletionBreaker[T any] struct {
+	store *Store[T]
+	conf  BreakerConfig
+	log   logr.Logger
+
+	mutex     sync.Mutex
+	deletions []time.Time
+	openSince *time.Time
+	paused    map[string][]Option
+}
+
+var _ Writer[any] = (*DeletionBreaker[any])(nil)
+
+// NewDeletionBreaker returns a [DeletionBreaker] guarding deletions from the store.
+func NewDeletionBreaker[T any](store *Store[T], conf BreakerConfig, log logr.Logger) *DeletionBreaker[T] {
+	if conf.Clock == nil {
+		conf.Clock = clock.RealClock{}
+	}
+	return &DeletionBreaker[T]{
+		store:  store,
+		conf:   conf,
+		log:    log,
+		paused: map[string][]Option{},
+	}
+}
+
+// Write implements [Writer]. Writing an entry cancels its paused deletion.
+// The breaker closes when no paused deletions are left.
+func (b *DeletionBreaker[T]) Write(key string, data T, opts ...Option) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	b.store.Write(key, data, opts...)
+	if b.openSince == nil {
+		return
+	}
+	delete(b.paused, key)
+	if len(b.paused) == 0 {
+		b.log.Info("Closing deletion breaker, all paused entries were written again")
+		b.close()
+	}
+}
+
+// Delete implements [Writer]. Deletions of existing entries are paused while the breaker is open.
+// Planned deletions and deletions of missing entries are never paused.
+func (b *DeletionBreaker[T]) Delete(key string, opts ...Option) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if _, ok := b.store.Metadata(key); !ok || applyOptions(opts).planned {
+		b.store.Delete(key, opts...)
+		return
+	}
+
+	if b.openSince != nil {
+		b.paused[key] = opts
+		return
+	}
+
+	now := b.conf.Clock.Now()
+	b.deletions = slices.DeleteFunc(b.deletions, func(t time.Time) bool { return now.Sub(t) >= b.conf.Window })
+	var (
+		deletions = len(b.deletions) + 1
+		// entries approximates the number of entries at the beginning of the window.
+		entries = b.store.Len() + len(b.deletions)
+	)
+	if deletions > b.conf.MinDeletions && float64(deletions) > b.conf.Threshold*float64(entries) {
+		b.log.Error(nil, "Opening deletion breaker, too many entries are deleted", "deletions", deletions, "entries", entries, "window", b.conf.Window)
+		b.openSince = &now
+		b.paused[key] = opts
+		return
+	}
+
+	b.deletions = append(b.deletions, now)
+	b.store.Delete(key, opts...)
+}
+
+// State returns the current state of the breaker.
+func (b *DeletionBreaker[T]) State() BreakerState {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	state := BreakerState{
+		Open:            b.openSince != nil,
+		PausedDeletions: slices.Sorted(maps.Keys(b.paused)),
+	}
+	if b.openSince != nil {
+		since := *b.openSince
+		state.Since = &since
+	}
+	return state
+}
+
+// Release performs all paused deletions and closes the breaker.
+// It returns the number of deleted entries.
+func (b *DeletionBreaker[T]) Release() int {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	released := len(b.paused)
+	for key, opts := range b.paused {
+		b.store.Delete(key, opts...)
+	}
+	if b.openSince != nil {
+		b.log.Info("Released deletion breaker", "deletions", released)
+	}
+	b.close()
+	return released
+}
+
+func (b *DeletionBreaker[T]) close() {
+	b.openSince = nil
+	b.deletions = nil
+	clear(b.paused)
+}
@@ -0,0 +1,132 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package store_test
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	testclock "k8s.io/utils/clock/testing"
+
+	"github.com/gardener/gardener-discovery-server/internal/store"
+)
+
+var _ = Describe("DeletionBreaker", func() {
+	var (
+		s       *store.Store[exported]
+		breaker *store.DeletionBreaker[exported]
+		clock   *testclock.FakePassiveClock
+
+		key        = func(i int) string { return fmt.Sprintf("foo--%02d", i) }
+		isStored   = func(key string) bool { _, ok := s.Read(key); return ok }
+		deleteKeys = func(from, to int) {
+			for i := from; i < to; i++ {
+				breaker.Delete(key(i), store.WithReason("secret not found"))
+			}
+		}
+	)
+
+	BeforeEach(func() {
+		s = store.MustNewStore(func(e exported) exported { return e })
+		for i := range 50 {
+			s.Write(key(i), exported{Value: key(i)})
+		}
+		clock = testclock.NewFakePassiveClock(time.Now())
+		breaker = store.NewDeletionBreaker(s, store.BreakerConfig{
+			Threshold:    0.1,
+			Window:       5 * time.Minute,
+			MinDeletions: 2,
+			Clock:        clock,
+		}, logr.Discard())
+	})
+
+	It("should delete entries below the threshold", func() {
+		deleteKeys(0, 5)
+
+		Expect(s.Len()).To(Equal(45))
+		Expect(breaker.State().Open).To(BeFalse())
+		rejection, ok := s.Rejection(key(0))
+		Expect(ok).To(BeTrue())
+		Expect(rejection.Reason).To(Equal("secret not found"))
+	})
+
+	It("should pause deletions above the threshold", func() {
+		deleteKeys(0, 8)
+
+		Expect(s.Len()).To(Equal(45))
+		Expect(isStored(key(5))).To(BeTrue())
+		state := breaker.State()
+		Expect(state.Open).To(BeTrue())
+		Expect(state.Since).ToNot(BeNil())
+		Expect(state.PausedDeletions).To(Equal([]string{key(5), key(6), key(7)}))
+		_, ok := s.Rejection(key(5))
+		Expect(ok).To(BeFalse())
+	})
+
+	It("should only count deletions within the window", func() {
+		deleteKeys(0, 5)
+		clock.SetTime(clock.Now().Add(5 * time.Minute))
+		deleteKeys(5, 9)
+
+		Expect(s.Len()).To(Equal(41))
+		Expect(breaker.State().Open).To(BeFalse())
+	})
+
+	It("should not open for small stores below the minimum deletions", func() {
+		small := store.MustNewStore(func(e exported) exported { return e })
+		small.Write("foo", exported{})
+		smallBreaker := store.NewDeletionBreaker(small, store.BreakerConfig{Threshold: 0.1, Window: time.Minute, MinDeletions: 2}, logr.Discard())
+
+		smallBreaker.Delete("foo")
+		Expect(small.Len()).To(BeZero())
+		Expect(smallBreaker.State().Open).To(BeFalse())
+	})
+
+	It("should not pause planned deletions and deletions of missing entries", func() {
+		deleteKeys(0, 6)
+		Expect(breaker.State().Open).To(BeTrue())
+
+		breaker.Delete(key(10), store.Planned())
+		breaker.Delete("bar--1", store.WithReason("secret not found"))
+
+		Expect(isStored(key(10))).To(BeFalse())
+		_, ok := s.Rejection("bar--1")
+		Expect(ok).To(BeTrue())
+		Expect(breaker.State().PausedDeletions).To(Equal([]string{key(5)}))
+	})
+
+	It("should close once all paused entries are written again", func() {
+		deleteKeys(0, 7)
+		Expect(breaker.State().PausedDeletions).To(HaveLen(2))
+
+		breaker.Write(key(5), exported{Value: "foo"})
+		Expect(breaker.State().Open).To(BeTrue())
+		breaker.Write(key(6), exported{Value: "foo"})
+
+		state := breaker.State()
+		Expect(state.Open).To(BeFalse())
+		Expect(state.Since).To(BeNil())
+		Expect(state.PausedDeletions).To(BeEmpty())
+		Expect(s.Len()).To(Equal(45))
+	})
+
+	It("should perform the paused deletions when released", func() {
+		deleteKeys(0, 8)
+
+		Expect(breaker.Release()).To(Equal(3))
+		Expect(breaker.State().Open).To(BeFalse())
+		Expect(s.Len()).To(Equal(42))
+		rejection, ok := s.Rejection(key(7))
+		Expect(ok).To(BeTrue())
+		Expect(rejection.Reason).To(Equal("secret not found"))
+
+		deleteKeys(8, 10)
+		Expect(s.Len()).To(Equal(40))
+		Expect(breaker.State().Open).To(BeFalse())
+	})
+})
@@ -4,23 +4,52 @@
 
 package certificate
 
-import "github.com/gardener/gardener-discovery-server/internal/store"
+import (
+	"time"
+
+	"github.com/gardener/gardener-discovery-server/internal/precompress"
+	"github.com/gardener/gardener-discovery-server/internal/store"
+)
 
 var (
 	_ store.Reader[Data] = (*store.Store[Data])(nil)
 	_ store.Writer[Data] = (*store.Store[Data])(nil)
+	_ store.Inspector    = (*store.Store[Data])(nil)
+	_ store.Resolver     = (*store.Store[Data])(nil)
+	_ store.Sizer        = Data{}
 )
 
 // Data holds public certificates.
 type Data struct {
 	CABundle []byte
+	// CompressedCABundle are the precompressed variants of CABundle.
+	CompressedCABundle precompress.Variants
+	// Expires is the earliest expiration of the certificates, it is zero if unknown.
+	Expires time.Time
+	// CABundleSignature is the detached JWS over CABundle, it is empty if signing is disabled.
+	// It is excluded from the hash of the entry as signatures may differ for the same content.
+	CABundleSignature string `json:"-"`
 }
 
-// Copy returns a deep copy of [Data].
+// Copy returns a copy of [Data]. The precompressed variants are immutable and shared
+// with the copy, so that reading from the store does not copy them.
 func Copy(data Data) Data {
 	out := Data{
+		Expires:  data.Expires,
 		CABundle: make([]byte, len(data.CABundle)),
 	}
 	copy(out.CABundle, data.CABundle)
+	out.CompressedCABundle = data.CompressedCABundle
+	out.CABundleSignature = data.CABundleSignature
 	return out
 }
+
+// Size returns the number of published bytes.
+func (data Data) Size() int {
+	return len(data.CABundle)
+}
+
+// Expiration returns the expiration hint of the data.
+func (data Data) Expiration() time.Time {
+	return data.Expires
+}
@@ -0,0 +1,46 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package clusterinfo
+
+import (
+	"slices"
+
+	"github.com/gardener/gardener-discovery-server/internal/store"
+)
+
+var (
+	_ store.Reader[Data] = (*store.Store[Data])(nil)
+	_ store.Writer[Data] = (*store.Store[Data])(nil)
+	_ store.Inspector    = (*store.Store[Data])(nil)
+	_ store.Resolver     = (*store.Store[Data])(nil)
+	_ store.Sizer        = Data{}
+)
+
+// Server is an address the API server of a shoot is reachable at.
+type Server struct {
+	// Name is the name of the advertised address, e.g. external.
+	Name string
+	// URL is the https URL of the API server.
+	URL string
+}
+
+// Data holds the API server addresses of a shoot, the preferred one first.
+type Data struct {
+	Servers []Server
+}
+
+// Copy returns a deep copy of [Data].
+func Copy(data Data) Data {
+	return Data{Servers: slices.Clone(data.Servers)}
+}
+
+// Size returns the number of published bytes.
+func (data Data) Size() int {
+	var size int
+	for _, s := range data.Servers {
+		size += len(s.Name) + len(s.URL)
+	}
+	return size
+}
@@ -0,0 +1,64 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package clusterinfo
+
+import (
+	"slices"
+	"time"
+
+	"github.com/gardener/gardener-discovery-server/internal/precompress"
+	"github.com/gardener/gardener-discovery-server/internal/store"
+)
+
+var (
+	_ store.Reader[Kubeconfig] = (*store.Store[Kubeconfig])(nil)
+	_ store.Writer[Kubeconfig] = (*store.Store[Kubeconfig])(nil)
+	_ store.Inspector          = (*store.Store[Kubeconfig])(nil)
+	_ store.Resolver           = (*store.Store[Kubeconfig])(nil)
+	_ store.Sizer              = Kubeconfig{}
+)
+
+// Kubeconfig holds the cluster info of a shoot composed of its API server addresses and its CA bundle,
+// encoded as JSON and as YAML.
+type Kubeconfig struct {
+	JSON []byte
+	// CompressedJSON are the precompressed variants of JSON.
+	CompressedJSON precompress.Variants
+	YAML           []byte
+	// CompressedYAML are the precompressed variants of YAML.
+	CompressedYAML precompress.Variants
+	// Expires is the expiration of the CA bundle, it is zero if unknown.
+	Expires time.Time
+	// JSONSignature is the detached JWS over JSON, it is empty if signing is disabled.
+	// It is excluded from the hash of the entry as signatures may differ for the same content.
+	JSONSignature string `json:"-"`
+	// YAMLSignature is the detached JWS over YAML, it is empty if signing is disabled.
+	// It is excluded from the hash of the entry as signatures may differ for the same content.
+	YAMLSignature string `json:"-"`
+}
+
+// CopyKubeconfig returns a copy of [Kubeconfig]. The precompressed variants are immutable and shared
+// with the copy, so that reading from the store does not copy them.
+func CopyKubeconfig(data Kubeconfig) Kubeconfig {
+	return Kubeconfig{
+		JSON:           slices.Clone(data.JSON),
+		CompressedJSON: data.CompressedJSON,
+		YAML:           slices.Clone(data.YAML),
+		CompressedYAML: data.CompressedYAML,
+		Expires:        data.Expires,
+		JSONSignature:  data.JSONSignature,
+		YAMLSignature:  data.YAMLSignature,
+	}
+}
+
+// Size returns the number of published bytes.
+func (data Kubeconfig) Size() int {
+	return len(data.JSON) + len(data.YAML)
+}
+
+// Expiration returns the expiration hint of the data.
+func (data Kubeconfig) Expiration() time.Time {
+	return data.Expires
+}
@@ -4,20 +4,38 @@
 
 package openidmeta
 
-import "github.com/gardener/gardener-discovery-server/internal/store"
+import (
+	"time"
+
+	"github.com/gardener/gardener-discovery-server/internal/precompress"
+	"github.com/gardener/gardener-discovery-server/internal/store"
+)
 
 var (
 	_ store.Reader[Data] = (*store.Store[Data])(nil)
 	_ store.Writer[Data] = (*store.Store[Data])(nil)
+	_ store.Inspector    = (*store.Store[Data])(nil)
+	_ store.Resolver     = (*store.Store[Data])(nil)
+	_ store.Sizer        = Data{}
 )
 
 // Data holds openid discovery metadata.
 type Data struct {
 	Config []byte
 	JWKS   []byte
+	// CompressedConfig and CompressedJWKS are the precompressed variants of Config and JWKS.
+	CompressedConfig precompress.Variants
+	CompressedJWKS   precompress.Variants
+	// Expires is the earliest expiration of the certificates of the keys, it is zero if unknown.
+	Expires time.Time
+	// ConfigSignature and JWKSSignature are the detached JWS over Config and JWKS, they are empty if signing is disabled.
+	// They are excluded from the hash of the entry as signatures may differ for the same content.
+	ConfigSignature string `json:"-"`
+	JWKSSignature   string `json:"-"`
 }
 
-// Copy returns a deep copy of [Data].
+// Copy returns a copy of [Data]. The precompressed variants are immutable and shared
+// with the copy, so that reading from the store does not copy them.
 func Copy(data Data) Data {
 	out := Data{
 		Config: make([]byte, len(data.Config)),
@@ -25,5 +43,20 @@ func Copy(data Data) Data {
 	}
 	copy(out.Config, data.Config)
 	copy(out.JWKS, data.JWKS)
+	out.CompressedConfig = data.CompressedConfig
+	out.CompressedJWKS = data.CompressedJWKS
+	out.Expires = data.Expires
+	out.ConfigSignature = data.ConfigSignature
+	out.JWKSSignature = data.JWKSSignature
 	return out
 }
+
+// Size returns the number of published bytes.
+func (data Data) Size() int {
+	return len(data.Config) + len(data.JWKS)
+}
+
+// Expiration returns the expiration hint of the data.
+func (data Data) Expiration() time.Time {
+	return data.Expires
+}
@@ -0,0 +1,56 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package spiffe
+
+import (
+	"slices"
+	"time"
+
+	"github.com/gardener/gardener-discovery-server/internal/precompress"
+	"github.com/gardener/gardener-discovery-server/internal/store"
+)
+
+var (
+	_ store.Reader[Data] = (*store.Store[Data])(nil)
+	_ store.Writer[Data] = (*store.Store[Data])(nil)
+	_ store.Inspector    = (*store.Store[Data])(nil)
+	_ store.Sizer        = Data{}
+)
+
+// Data holds an encoded SPIFFE trust bundle.
+type Data struct {
+	Bundle []byte
+	// CompressedBundle are the precompressed variants of Bundle.
+	CompressedBundle precompress.Variants
+	// Sequence is the sequence number encoded in Bundle.
+	Sequence uint64
+	// Expires is the earliest expiration of the keys and certificates, it is zero if unknown.
+	Expires time.Time
+	// BundleSignature is the detached JWS over Bundle, it is empty if signing is disabled.
+	// It is excluded from the hash of the entry as signatures may differ for the same content.
+	BundleSignature string `json:"-"`
+}
+
+// Copy returns a copy of [Data]. The precompressed variants are immutable and shared
+// with the copy, so that reading from the store does not copy them.
+func Copy(data Data) Data {
+	return Data{
+		Bundle:           slices.Clone(data.Bundle),
+		CompressedBundle: data.CompressedBundle,
+		Sequence:         data.Sequence,
+		Expires:          data.Expires,
+		BundleSignature:  data.BundleSignature,
+	}
+}
+
+// Size returns the number of published bytes.
+func (data Data) Size() int {
+	return len(data.Bundle)
+}
+
+// Expiration returns the expiration hint of the data.
+func (data Data) Expiration() time.Time {
+	return data.Expires
+}
@@ -5,13 +5,27 @@
 package store
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"errors"
+	"slices"
+	"strings"
 	"sync"
+	"time"
 )
 
 // ErrNoCopyFunc is an error indicating that a copyFunc was not passed to [Store].
 var ErrNoCopyFunc = errors.New("store: copyFunc must not be nil")
 
+const (
+	// rejectionTTL is the duration a rejection is kept for if the entry is not written again,
+	// e.g. because the object it was published from got deleted.
+	rejectionTTL = 24 * time.Hour
+	// maxRejections is the number of rejections kept by a [Store], the oldest ones are dropped first.
+	maxRejections = 10000
+)
+
 // Reader lets the consumer read entries from [Store].
 type Reader[T any] interface {
 	Read(key string) (T, bool)
@@ -19,17 +33,120 @@ type Reader[T any] interface {
 
 // Writer lets the consumer write entries to [Store].
 type Writer[T any] interface {
-	Write(key string, data T)
-	Delete(key string)
+	Write(key string, data T, opts ...Option)
+	Delete(key string, opts ...Option)
+}
+
+// Resolver lets the consumer resolve aliases of entries in [Store].
+type Resolver interface {
+	Resolve(alias string) (string, bool)
+}
+
+// Inspector lets the consumer inspect the metadata of entries in [Store].
+type Inspector interface {
+	Metadata(key string) (Metadata, bool)
+	List(prefix string) []Metadata
+	Rejection(key string) (Rejection, bool)
+	Rejections(prefix string) []Rejection
+}
+
+// ObjectReference references the object an entry is published from.
+type ObjectReference struct {
+	Kind            string `json:"kind"`
+	Namespace       string `json:"namespace,omitempty"`
+	Name            string `json:"name"`
+	ResourceVersion string `json:"resourceVersion,omitempty"`
+}
+
+// Metadata describes an entry of the [Store].
+type Metadata struct {
+	Key string `json:"key"`
+	// Source is the object the entry was published from.
+	Source *ObjectReference `json:"source,omitempty"`
+	// Alias is an alternative key the entry is resolved by.
+	Alias string `json:"alias,omitempty"`
+	// LastWrite is the time the entry was last written, i.e. last confirmed by a reconciliation.
+	LastWrite time.Time `json:"lastWrite"`
+	// LastChange is the time the content of the entry last changed, i.e. its hash differed from the previous write.
+	LastChange time.Time `json:"lastChange"`
+	// Hash is the hex encoded SHA-256 hash of the JSON encoded entry.
+	Hash string `json:"hash"`
+	// Size is the size of the entry in bytes as reported by [Sizer]
+	// or the size of the JSON encoded entry.
+	Size int `json:"size"`
+}
+
+// Sizer can be implemented by entries to report the size of the data they publish.
+type Sizer interface {
+	Size() int
+}
+
+// Rejection describes why an entry was removed from the [Store].
+type Rejection struct {
+	Key string `json:"key"`
+	// Source is the object the entry would be published from.
+	Source *ObjectReference `json:"source,omitempty"`
+	Reason string           `json:"reason"`
+	Time   time.Time        `json:"time"`
+}
+
+// Option configures a write or a deletion.
+type Option func(*options)
+
+type options struct {
+	source  *ObjectReference
+	reason  string
+	planned bool
+	alias   string
+}
+
+// WithSource records the object the entry is published from.
+func WithSource(source ObjectReference) Option {
+	return func(o *options) {
+		o.source = &source
+	}
+}
+
+// WithReason records why the entry is deleted. The reason is kept until the entry is written again
+// or for a day at most. It is not recorded for [Planned] deletions.
+func WithReason(reason string) Option {
+	return func(o *options) {
+		o.reason = reason
+	}
+}
+
+// WithAlias records an alternative key the written entry can be resolved by.
+// The alias is removed together with the entry or if the entry is written without it.
+// If another entry is written with the same alias, the alias resolves to the latest one.
+func WithAlias(alias string) Option {
+	return func(o *options) {
+		o.alias = alias
+	}
+}
+
+// Planned marks a deletion as expected, e.g. on the handover of a project to another shard.
+// Planned deletions are not paused by a [DeletionBreaker].
+func Planned() Option {
+	return func(o *options) {
+		o.planned = true
+	}
+}
+
+type entry[T any] struct {
+	data     T
+	metadata Metadata
 }
 
 // Store is a thread safe in-memory store that can be used to
 // read and write data. Mind that the store
 // does not perform any validation on the inputs.
 type Store[T any] struct {
-	mutex    sync.RWMutex
-	store    map[string]T
-	copyFunc func(T) T
+	mutex      sync.RWMutex
+	store      map[string]entry[T]
+	rejections map[string]Rejection
+	aliases    map[string]string
+	copyFunc   func(T) T
+	listeners  []func(key string)
 }
 
 // NewStore returns a ready for use [Store].
@@ -38,8 +155,10 @@ func NewStore[T any](copyFunc func(T) T) (*Store[T], error) {
 		return nil, ErrNoCopyFunc
 	}
 	return &Store[T]{
-		store:    make(map[string]T),
-		copyFunc: copyFunc,
+		store:      make(map[string]entry[T]),
+		rejections: make(map[string]Rejection),
+		aliases:    make(map[string]string),
+		copyFunc:   copyFunc,
 	}, nil
 }
 
@@ -57,9 +176,9 @@ func MustNewStore[T any](copyFunc func(T) T) *Store[T] {
 func (s *Store[T]) Read(key string) (T, bool) {
 	s.mutex.RLock()
 	defer s.mutex.RUnlock()
-	data, ok := s.store[key]
+	e, ok := s.store[key]
 	if ok {
-		return s.copyFunc(data), ok
+		return s.copyFunc(e.data), ok
 	}
 	var t T
 	return t, false
@@ -67,18 +186,107 @@ func (s *Store[T]) Read(key string) (T, bool) {
 
 // Write sets and entry to the [Store].
 // If the entry exists it is overwritten.
-func (s *Store[T]) Write(key string, data T) {
-	d := s.copyFunc(data)
-	s.mutex.Lock()
-	defer s.mutex.Unlock()
-	s.store[key] = d
+func (s *Store[T]) Write(key string, data T, opts ...Option) {
+	o := applyOptions(opts)
+	hash, size := digest(data)
+	now := time.Now()
+	e := entry[T]{
+		data: s.copyFunc(data),
+		metadata: Metadata{
+			Key:        key,
+			Source:     o.source,
+			Alias:      o.alias,
+			LastWrite:  now,
+			LastChange: now,
+			Hash:       hash,
+			Size:       size,
+		},
+	}
+	func() {
+		s.mutex.Lock()
+		defer s.mutex.Unlock()
+		if previous, ok := s.store[key]; ok && previous.metadata.Hash == hash {
+			e.metadata.LastChange = previous.metadata.LastChange
+		}
+		s.removeAlias(key)
+		s.store[key] = e
+		if o.alias != "" {
+			s.aliases[o.alias] = key
+		}
+		delete(s.rejections, key)
+	}()
+	s.notify(key)
 }
 
 // Delete removes an entry from the [Store].
-func (s *Store[T]) Delete(key string) {
+func (s *Store[T]) Delete(key string, opts ...Option) {
+	o := applyOptions(opts)
+	func() {
+		s.mutex.Lock()
+		defer s.mutex.Unlock()
+		s.removeAlias(key)
+		delete(s.store, key)
+		if o.planned || o.reason == "" {
+			delete(s.rejections, key)
+			return
+		}
+		now := time.Now()
+		if _, ok := s.rejections[key]; !ok && len(s.rejections) >= maxRejections {
+			s.pruneRejections(now)
+		}
+		s.rejections[key] = Rejection{Key: key, Source: o.source, Reason: o.reason, Time: now}
+	}()
+	s.notify(key)
+}
+
+// OnUpdate registers a function that is called with the key of an entry after it was written or removed,
+// e.g. to derive other documents from the entry. It is called without holding the lock of the [Store].
+func (s *Store[T]) OnUpdate(fn func(key string)) {
 	s.mutex.Lock()
 	defer s.mutex.Unlock()
-	delete(s.store, key)
+	s.listeners = append(s.listeners, fn)
+}
+
+func (s *Store[T]) notify(key string) {
+	s.mutex.RLock()
+	listeners := slices.Clone(s.listeners)
+	s.mutex.RUnlock()
+	for _, fn := range listeners {
+		fn(key)
+	}
+}
+
+// pruneRejections drops the expired rejections and the oldest one if there is still no room for another.
+func (s *Store[T]) pruneRejections(now time.Time) {
+	var oldest *Rejection
+	for key, r := range s.rejections {
+		if now.Sub(r.Time) > rejectionTTL {
+			delete(s.rejections, key)
+			continue
+		}
+		if oldest == nil || r.Time.Before(oldest.Time) {
+			oldest = &r
+		}
+	}
+	if len(s.rejections) >= maxRejections && oldest != nil {
+		delete(s.rejections, oldest.Key)
+	}
+}
+
+// Resolve returns the key of the entry with the alias.
+func (s *Store[T]) Resolve(alias string) (string, bool) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	key, ok := s.aliases[alias]
+	return key, ok
+}
+
+// removeAlias removes the alias of the entry unless it resolves to another entry.
+func (s *Store[T]) removeAlias(key string) {
+	e, ok := s.store[key]
+	if ok && e.metadata.Alias != "" && s.aliases[e.metadata.Alias] == key {
+		delete(s.aliases, e.metadata.Alias)
+	}
 }
 
 // Len returns the number of entries in the [Store].
@@ -87,3 +295,74 @@ func (s *Store[T]) Len() int {
 	defer s.mutex.RUnlock()
 	return len(s.store)
 }
+
+// Metadata returns the metadata of an entry.
+func (s *Store[T]) Metadata(key string) (Metadata, bool) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	e, ok := s.store[key]
+	return e.metadata, ok
+}
+
+// List returns the metadata of all entries whose key has the prefix, sorted by key.
+func (s *Store[T]) List(prefix string) []Metadata {
+	s.mutex.RLock()
+	out := make([]Metadata, 0, len(s.store))
+	for key, e := range s.store {
+		if strings.HasPrefix(key, prefix) {
+			out = append(out, e.metadata)
+		}
+	}
+	s.mutex.RUnlock()
+
+	slices.SortFunc(out, func(a, b Metadata) int { return strings.Compare(a.Key, b.Key) })
+	return out
+}
+
+// Rejection returns the last reason an entry was removed for.
+func (s *Store[T]) Rejection(key string) (Rejection, bool) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	r, ok := s.rejections[key]
+	if !ok || time.Since(r.Time) > rejectionTTL {
+		return Rejection{}, false
+	}
+	return r, ok
+}
+
+// Rejections returns the rejections of all entries whose key has the prefix, sorted by key.
+func (s *Store[T]) Rejections(prefix string) []Rejection {
+	s.mutex.RLock()
+	now := time.Now()
+	out := make([]Rejection, 0, len(s.rejections))
+	for key, r := range s.rejections {
+		if strings.HasPrefix(key, prefix) && now.Sub(r.Time) <= rejectionTTL {
+			out = append(out, r)
+		}
+	}
+	s.mutex.RUnlock()
+
+	slices.SortFunc(out, func(a, b Rejection) int { return strings.Compare(a.Key, b.Key) })
+	return out
+}
+
+func applyOptions(opts []Option) options {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+func digest(data any) (string, int) {
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return "", 0
+	}
+	size := len(raw)
+	if s, ok := data.(Sizer); ok {
+		size = s.Size()
+	}
+	sum := sha256.Sum256(raw)
+	return hex.EncodeToString(sum[:]), size
+}
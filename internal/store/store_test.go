@@ -5,7 +5,9 @@
 package store_test
 
 import (
+	"fmt"
 	"sync"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -111,6 +113,20 @@ var _ = Describe("Store", func() {
 		Expect(s.Len()).To(Equal(0))
 	})
 
+	It("should notify the listeners after an entry was written or removed", func() {
+		var updated []string
+		s.OnUpdate(func(key string) {
+			// The store is not locked while the listeners are notified.
+			_, ok := s.Read(key)
+			updated = append(updated, fmt.Sprintf("%s:%t", key, ok))
+		})
+
+		s.Write(fooKey, d)
+		s.Delete(fooKey)
+		s.Write("bar", d)
+		Expect(updated).To(Equal([]string{fooKey + ":true", fooKey + ":false", "bar:true"}))
+	})
+
 	It("should be able to use the store in parallel", func() {
 		initialEntries := map[string]data{
 			"0": {bytes: []byte("0")},
@@ -209,4 +225,174 @@ var _ = Describe("Store", func() {
 		}
 		wg.Wait()
 	})
+
+	Describe("metadata", func() {
+		var (
+			hashed *store.Store[exported]
+			source = store.ObjectReference{Kind: "Secret", Namespace: "ns", Name: "foo", ResourceVersion: "1"}
+		)
+
+		BeforeEach(func() {
+			hashed = store.MustNewStore(func(e exported) exported { return e })
+		})
+
+		It("should record the source, write time and hash of an entry", func() {
+			before := time.Now()
+			hashed.Write(fooKey, exported{Value: "foo"}, store.WithSource(source))
+
+			meta, ok := hashed.Metadata(fooKey)
+			Expect(ok).To(BeTrue())
+			Expect(meta.Key).To(Equal(fooKey))
+			Expect(meta.Source).To(Equal(&source))
+			Expect(meta.LastWrite).To(BeTemporally(">=", before))
+			Expect(meta.Hash).To(HaveLen(64))
+			Expect(meta.Size).To(Equal(len(`{"Value":"foo"}`)))
+
+			hashed.Write(fooKey, exported{Value: "bar"})
+			updated, ok := hashed.Metadata(fooKey)
+			Expect(ok).To(BeTrue())
+			Expect(updated.Source).To(BeNil())
+			Expect(updated.Hash).ToNot(Equal(meta.Hash))
+		})
+
+		It("should only update the change time if the content changes", func() {
+			hashed.Write(fooKey, exported{Value: "foo"})
+			first, _ := hashed.Metadata(fooKey)
+
+			hashed.Write(fooKey, exported{Value: "foo"})
+			unchanged, _ := hashed.Metadata(fooKey)
+			Expect(unchanged.LastChange).To(Equal(first.LastChange))
+			Expect(unchanged.LastWrite).To(BeTemporally(">=", first.LastWrite))
+
+			hashed.Write(fooKey, exported{Value: "bar"})
+			changed, _ := hashed.Metadata(fooKey)
+			Expect(changed.LastChange).To(Equal(changed.LastWrite))
+		})
+
+		It("should record the size reported by the entry", func() {
+			sizedStore := store.MustNewStore(func(d sized) sized { return d })
+			sizedStore.Write(fooKey, sized{Value: "foobar"})
+
+			meta, ok := sizedStore.Metadata(fooKey)
+			Expect(ok).To(BeTrue())
+			Expect(meta.Size).To(Equal(6))
+		})
+
+		It("should not return metadata of missing entries", func() {
+			_, ok := hashed.Metadata(fooKey)
+			Expect(ok).To(BeFalse())
+		})
+
+		It("should list entries by prefix sorted by key", func() {
+			for _, key := range []string{"b--2", "a--1", "b--1", "c--1"} {
+				hashed.Write(key, exported{Value: key})
+			}
+
+			keys := func(metas []store.Metadata) []string {
+				out := []string{}
+				for _, m := range metas {
+					out = append(out, m.Key)
+				}
+				return out
+			}
+			Expect(keys(hashed.List(""))).To(Equal([]string{"a--1", "b--1", "b--2", "c--1"}))
+			Expect(keys(hashed.List("b--"))).To(Equal([]string{"b--1", "b--2"}))
+			Expect(hashed.List("d--")).To(BeEmpty())
+		})
+
+		It("should keep the rejection reason until the entry is written again", func() {
+			hashed.Write(fooKey, exported{Value: "foo"})
+			hashed.Delete(fooKey, store.WithReason("secret not found"), store.WithSource(source))
+
+			rejection, ok := hashed.Rejection(fooKey)
+			Expect(ok).To(BeTrue())
+			Expect(rejection.Key).To(Equal(fooKey))
+			Expect(rejection.Reason).To(Equal("secret not found"))
+			Expect(rejection.Source).To(Equal(&source))
+			Expect(hashed.Rejections("")).To(ConsistOf(rejection))
+			Expect(hashed.Rejections("bar")).To(BeEmpty())
+
+			hashed.Write(fooKey, exported{Value: "foo"})
+			_, ok = hashed.Rejection(fooKey)
+			Expect(ok).To(BeFalse())
+		})
+
+		It("should not record a rejection without reason", func() {
+			hashed.Write(fooKey, exported{Value: "foo"})
+			hashed.Delete(fooKey)
+
+			_, ok := hashed.Rejection(fooKey)
+			Expect(ok).To(BeFalse())
+		})
+
+		It("should drop the rejection on planned deletions", func() {
+			hashed.Delete(fooKey, store.WithReason("secret not found"))
+			hashed.Delete(fooKey, store.WithReason("project is owned by another shard"), store.Planned())
+
+			_, ok := hashed.Rejection(fooKey)
+			Expect(ok).To(BeFalse())
+		})
+
+		It("should drop the oldest rejections once the limit is reached", func() {
+			for i := range 10001 {
+				hashed.Delete(fmt.Sprintf("key-%d", i), store.WithReason("secret not found"))
+			}
+
+			Expect(hashed.Rejections("")).To(HaveLen(10000))
+			_, ok := hashed.Rejection("key-0")
+			Expect(ok).To(BeFalse())
+			_, ok = hashed.Rejection("key-10000")
+			Expect(ok).To(BeTrue())
+		})
+	})
+
+	Describe("aliases", func() {
+		var aliased *store.Store[exported]
+
+		BeforeEach(func() {
+			aliased = store.MustNewStore(func(e exported) exported { return e })
+		})
+
+		It("should resolve the alias of an entry", func() {
+			aliased.Write("foo--uid1", exported{}, store.WithAlias("foo--bar"))
+
+			key, ok := aliased.Resolve("foo--bar")
+			Expect(ok).To(BeTrue())
+			Expect(key).To(Equal("foo--uid1"))
+			meta, _ := aliased.Metadata("foo--uid1")
+			Expect(meta.Alias).To(Equal("foo--bar"))
+		})
+
+		It("should remove the alias with the entry or if it is written without alias", func() {
+			aliased.Write("foo--uid1", exported{}, store.WithAlias("foo--bar"))
+			aliased.Write("foo--uid1", exported{})
+			_, ok := aliased.Resolve("foo--bar")
+			Expect(ok).To(BeFalse())
+
+			aliased.Write("foo--uid1", exported{}, store.WithAlias("foo--bar"))
+			aliased.Delete("foo--uid1")
+			_, ok = aliased.Resolve("foo--bar")
+			Expect(ok).To(BeFalse())
+		})
+
+		It("should resolve to the latest entry written with the alias", func() {
+			aliased.Write("foo--uid1", exported{}, store.WithAlias("foo--bar"))
+			aliased.Write("foo--uid2", exported{}, store.WithAlias("foo--bar"))
+			aliased.Delete("foo--uid1")
+
+			key, ok := aliased.Resolve("foo--bar")
+			Expect(ok).To(BeTrue())
+			Expect(key).To(Equal("foo--uid2"))
+		})
+	})
 })
+
+type exported struct {
+	Value string
+}
+
+type sized exported
+
+func (s sized) Size() int {
+	return len(s.Value)
+}
@@ -0,0 +1,56 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package tokenverify
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+// WriteText writes the diagnosis in a human-readable form.
+func WriteText(w io.Writer, d Diagnosis) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 1, ' ', 0)
+	if d.Valid {
+		fmt.Fprintln(tw, "Token is valid.")
+	} else {
+		fmt.Fprintln(tw, "Token is NOT valid.")
+	}
+	fmt.Fprintln(tw)
+
+	for _, field := range []struct{ name, value string }{
+		{"Issuer:", d.Issuer},
+		{"Subject:", d.Subject},
+		{"Audience:", strings.Join(d.Audience, ", ")},
+		{"Key ID:", d.KeyID},
+		{"Algorithm:", d.Algorithm},
+		{"Issued at:", formatTime(d.IssuedAt)},
+		{"Not before:", formatTime(d.NotBefore)},
+		{"Expires at:", formatTime(d.Expiry)},
+	} {
+		if field.value != "" {
+			fmt.Fprintf(tw, "%s\t%s\n", field.name, field.value)
+		}
+	}
+	fmt.Fprintln(tw)
+
+	for _, c := range d.Checks {
+		result := "PASS"
+		if !c.Passed {
+			result = "FAIL"
+		}
+		fmt.Fprintf(tw, "[%s]\t%s:\t%s\n", result, c.Name, c.Message)
+	}
+	return tw.Flush()
+}
+
+func formatTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
@@ -0,0 +1,251 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package tokenverify
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/go-jose/go-jose/v4"
+	"github.com/go-jose/go-jose/v4/jwt"
+	"k8s.io/utils/clock"
+
+	"github.com/gardener/gardener-discovery-server/internal/utils"
+)
+
+// Names of the checks of a diagnosis.
+const (
+	// CheckFormat parses the token as signed JWT.
+	CheckFormat = "format"
+	// CheckIssuer resolves the iss claim to the published documents and compares it with the issuer of the discovery document.
+	CheckIssuer = "issuer"
+	// CheckKey looks up the key the token was signed with in the published JWKS.
+	CheckKey = "key"
+	// CheckSignature verifies the signature of the token.
+	CheckSignature = "signature"
+	// CheckAudience checks that the aud claim contains one of the expected audiences.
+	CheckAudience = "audience"
+	// CheckExpiry checks the exp, nbf and iat claims.
+	CheckExpiry = "expiry"
+)
+
+// MaxTokenSize is the maximum size of a token that is verified.
+const MaxTokenSize = 64 << 10
+
+// leeway is the clock skew tolerated when validating the time claims.
+const leeway = time.Minute
+
+var signatureAlgorithms = []jose.SignatureAlgorithm{
+	jose.RS256, jose.RS384, jose.RS512,
+	jose.PS256, jose.PS384, jose.PS512,
+	jose.ES256, jose.ES384, jose.ES512,
+	jose.EdDSA,
+}
+
+// Documents are the published discovery documents of an issuer.
+type Documents struct {
+	// Config is the OpenID configuration.
+	Config []byte
+	// JWKS is the JSON Web Key Set.
+	JWKS []byte
+}
+
+// Resolver resolves an issuer URL to its published documents.
+type Resolver interface {
+	// Resolve returns the documents of the issuer or an error describing why they are not published.
+	Resolve(issuer string) (Documents, error)
+}
+
+// Verifier diagnoses tokens against the published documents of their issuers.
+type Verifier struct {
+	Resolver Resolver
+	// Clock is used to validate the time claims. It defaults to the real clock.
+	Clock clock.PassiveClock
+}
+
+// Check is the outcome of a single check of the diagnosis.
+type Check struct {
+	Name    string `json:"name"`
+	Passed  bool   `json:"passed"`
+	Message string `json:"message"`
+}
+
+// Diagnosis describes why a token is valid or not.
+type Diagnosis struct {
+	Valid     bool       `json:"valid"`
+	Issuer    string     `json:"issuer,omitempty"`
+	Subject   string     `json:"subject,omitempty"`
+	Audience  []string   `json:"audience,omitempty"`
+	KeyID     string     `json:"keyID,omitempty"`
+	Algorithm string     `json:"algorithm,omitempty"`
+	IssuedAt  *time.Time `json:"issuedAt,omitempty"`
+	NotBefore *time.Time `json:"notBefore,omitempty"`
+	Expiry    *time.Time `json:"expiry,omitempty"`
+	Checks    []Check    `json:"checks"`
+}
+
+func (d *Diagnosis) pass(name, format string, args ...any) {
+	d.Checks = append(d.Checks, Check{Name: name, Passed: true, Message: fmt.Sprintf(format, args...)})
+}
+
+func (d *Diagnosis) fail(name, format string, args ...any) {
+	d.Checks = append(d.Checks, Check{Name: name, Message: fmt.Sprintf(format, args...)})
+}
+
+// Verify diagnoses the token. The token is valid for any audience if no audiences are given.
+// The claims are reported even if the signature cannot be verified, they must not be trusted in that case.
+func (v *Verifier) Verify(rawToken string, audiences []string) (d Diagnosis) {
+	now := time.Now()
+	if v.Clock != nil {
+		now = v.Clock.Now()
+	}
+	defer func() {
+		d.Valid = len(d.Checks) > 0 && !slices.ContainsFunc(d.Checks, func(c Check) bool { return !c.Passed })
+	}()
+
+	rawToken = strings.TrimSpace(rawToken)
+	if len(rawToken) > MaxTokenSize {
+		d.fail(CheckFormat, "token is larger than %d bytes", MaxTokenSize)
+		return d
+	}
+	token, err := jwt.ParseSigned(rawToken, signatureAlgorithms)
+	if err != nil {
+		d.fail(CheckFormat, "token is not a JWT signed with an asymmetric algorithm: %v", err)
+		return d
+	}
+	if len(token.Headers) != 1 {
+		d.fail(CheckFormat, "token must have exactly one signature, it has %d", len(token.Headers))
+		return d
+	}
+	var claims jwt.Claims
+	if err := token.UnsafeClaimsWithoutVerification(&claims); err != nil {
+		d.fail(CheckFormat, "claims of the token cannot be decoded: %v", err)
+		return d
+	}
+	header := token.Headers[0]
+	d.Issuer, d.Subject, d.Audience = claims.Issuer, claims.Subject, claims.Audience
+	d.KeyID, d.Algorithm = header.KeyID, header.Algorithm
+	d.IssuedAt, d.NotBefore, d.Expiry = timeOf(claims.IssuedAt), timeOf(claims.NotBefore), timeOf(claims.Expiry)
+	d.pass(CheckFormat, "token is a JWT signed with %s", header.Algorithm)
+
+	if keys, ok := v.checkIssuer(&d, claims.Issuer); ok {
+		checkSignature(&d, token, header, keys)
+	}
+	checkAudience(&d, claims.Audience, audiences)
+	checkExpiry(&d, claims, now)
+	return d
+}
+
+// checkIssuer resolves the issuer and returns its published keys.
+func (v *Verifier) checkIssuer(d *Diagnosis, issuer string) (*jose.JSONWebKeySet, bool) {
+	if issuer == "" {
+		d.fail(CheckIssuer, "token does not have an iss claim")
+		return nil, false
+	}
+	docs, err := v.Resolver.Resolve(issuer)
+	if err != nil {
+		d.fail(CheckIssuer, "issuer %q is not published by the discovery server: %v", issuer, err)
+		return nil, false
+	}
+
+	var config struct {
+		Issuer string `json:"issuer"`
+	}
+	if err := json.Unmarshal(docs.Config, &config); err != nil {
+		d.fail(CheckIssuer, "published discovery document of the issuer cannot be decoded: %v", err)
+		return nil, false
+	}
+	if config.Issuer != issuer {
+		d.fail(CheckIssuer, "iss claim %q does not match the issuer %q of the published discovery document", issuer, config.Issuer)
+		return nil, false
+	}
+	keySet, err := utils.LoadKeySet(docs.JWKS)
+	if err != nil {
+		d.fail(CheckIssuer, "published JWKS of the issuer cannot be decoded: %v", err)
+		return nil, false
+	}
+	d.pass(CheckIssuer, "issuer is published by the discovery server")
+	return keySet, true
+}
+
+// checkSignature looks up the key of the token in the key set and verifies the signature with it.
+func checkSignature(d *Diagnosis, token *jwt.JSONWebToken, header jose.Header, keySet *jose.JSONWebKeySet) {
+	candidates := keySet.Keys
+	if header.KeyID != "" {
+		candidates = keySet.Key(header.KeyID)
+	}
+	if len(candidates) == 0 {
+		kids := make([]string, 0, len(keySet.Keys))
+		for _, k := range keySet.Keys {
+			kids = append(kids, k.KeyID)
+		}
+		d.fail(CheckKey, "key ID %q is not present in the current JWKS of the issuer, it contains the key IDs [%s]. "+
+			"The key may have been rotated out since the token was issued, request a new token.", header.KeyID, strings.Join(kids, ", "))
+		return
+	}
+	if header.KeyID == "" {
+		d.pass(CheckKey, "token does not specify a key ID, all %d keys of the JWKS are tried", len(candidates))
+	} else {
+		d.pass(CheckKey, "key ID %q is present in the JWKS of the issuer", header.KeyID)
+	}
+
+	var errs []error
+	for _, k := range candidates {
+		if k.Algorithm != "" && k.Algorithm != header.Algorithm {
+			errs = append(errs, fmt.Errorf("key %q is published for algorithm %s, the token is signed with %s", k.KeyID, k.Algorithm, header.Algorithm))
+			continue
+		}
+		if err := token.Claims(k.Key); err != nil {
+			errs = append(errs, fmt.Errorf("key %q: %w", k.KeyID, err))
+			continue
+		}
+		d.pass(CheckSignature, "signature is valid for key %q", k.KeyID)
+		return
+	}
+	d.fail(CheckSignature, "signature cannot be verified with the published keys: %v", errors.Join(errs...))
+}
+
+func checkAudience(d *Diagnosis, aud jwt.Audience, expected []string) {
+	switch {
+	case len(aud) == 0:
+		d.fail(CheckAudience, "token does not have an aud claim")
+	case len(expected) == 0:
+		d.pass(CheckAudience, "token is issued for [%s], no audience was expected", strings.Join(aud, ", "))
+	case slices.ContainsFunc(expected, aud.Contains):
+		d.pass(CheckAudience, "token is issued for [%s]", strings.Join(aud, ", "))
+	default:
+		d.fail(CheckAudience, "token is issued for [%s], expected one of [%s]", strings.Join(aud, ", "), strings.Join(expected, ", "))
+	}
+}
+
+func checkExpiry(d *Diagnosis, claims jwt.Claims, now time.Time) {
+	switch {
+	case claims.Expiry == nil:
+		d.fail(CheckExpiry, "token does not have an exp claim")
+	case now.After(claims.Expiry.Time().Add(leeway)):
+		d.fail(CheckExpiry, "token expired at %s, %s ago", claims.Expiry.Time().UTC().Format(time.RFC3339), now.Sub(claims.Expiry.Time()).Round(time.Second))
+	case claims.NotBefore != nil && now.Add(leeway).Before(claims.NotBefore.Time()):
+		d.fail(CheckExpiry, "token is not valid before %s", claims.NotBefore.Time().UTC().Format(time.RFC3339))
+	case now.After(claims.Expiry.Time()):
+		d.pass(CheckExpiry, "token expired at %s, %s ago, which is within the tolerated clock skew of %s",
+			claims.Expiry.Time().UTC().Format(time.RFC3339), now.Sub(claims.Expiry.Time()).Round(time.Second), leeway)
+	case claims.IssuedAt != nil && now.Add(leeway).Before(claims.IssuedAt.Time()):
+		d.fail(CheckExpiry, "token is issued in the future at %s, check the clocks", claims.IssuedAt.Time().UTC().Format(time.RFC3339))
+	default:
+		d.pass(CheckExpiry, "token expires at %s, in %s", claims.Expiry.Time().UTC().Format(time.RFC3339), claims.Expiry.Time().Sub(now).Round(time.Second))
+	}
+}
+
+func timeOf(d *jwt.NumericDate) *time.Time {
+	if d == nil {
+		return nil
+	}
+	t := d.Time().UTC()
+	return &t
+}
@@ -0,0 +1,204 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package tokenverify_test
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/go-jose/go-jose/v4"
+	"github.com/go-jose/go-jose/v4/jwt"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	testclock "k8s.io/utils/clock/testing"
+
+	"github.com/gardener/gardener-discovery-server/internal/tokenverify"
+)
+
+type fakeResolver map[string]tokenverify.Documents
+
+func (r fakeResolver) Resolve(issuer string) (tokenverify.Documents, error) {
+	docs, ok := r[issuer]
+	if !ok {
+		return tokenverify.Documents{}, errors.New("no entry found")
+	}
+	return docs, nil
+}
+
+var _ = Describe("Verifier", func() {
+	const issuer = "https://discovery.example.com/projects/foo/shoots/a8c33bc6-36b1-4d4c-a8b1-5e5cb4e1d4a9/issuer"
+
+	var (
+		now      = time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+		key      *ecdsa.PrivateKey
+		keyID    string
+		claims   jwt.Claims
+		resolver fakeResolver
+		verifier *tokenverify.Verifier
+	)
+
+	sign := func() string {
+		GinkgoHelper()
+		signer, err := jose.NewSigner(jose.SigningKey{Algorithm: jose.ES256, Key: key},
+			(&jose.SignerOptions{}).WithType("JWT").WithHeader(jose.HeaderKey("kid"), keyID))
+		Expect(err).NotTo(HaveOccurred())
+		token, err := jwt.Signed(signer).Claims(claims).Serialize()
+		Expect(err).NotTo(HaveOccurred())
+		return token
+	}
+
+	publish := func(keys ...jose.JSONWebKey) {
+		GinkgoHelper()
+		config, err := json.Marshal(map[string]string{"issuer": issuer, "jwks_uri": issuer + "/jwks"})
+		Expect(err).NotTo(HaveOccurred())
+		jwks, err := json.Marshal(jose.JSONWebKeySet{Keys: keys})
+		Expect(err).NotTo(HaveOccurred())
+		resolver[issuer] = tokenverify.Documents{Config: config, JWKS: jwks}
+	}
+
+	check := func(d tokenverify.Diagnosis, name string) tokenverify.Check {
+		for _, c := range d.Checks {
+			if c.Name == name {
+				return c
+			}
+		}
+		return tokenverify.Check{}
+	}
+
+	BeforeEach(func() {
+		var err error
+		key, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		Expect(err).NotTo(HaveOccurred())
+		keyID = "key-1"
+		claims = jwt.Claims{
+			Issuer:    issuer,
+			Subject:   "system:serviceaccount:default:foo",
+			Audience:  jwt.Audience{"sts.amazonaws.com"},
+			IssuedAt:  jwt.NewNumericDate(now.Add(-time.Minute)),
+			NotBefore: jwt.NewNumericDate(now.Add(-time.Minute)),
+			Expiry:    jwt.NewNumericDate(now.Add(time.Hour)),
+		}
+		resolver = fakeResolver{}
+		publish(jose.JSONWebKey{Key: &key.PublicKey, KeyID: keyID, Algorithm: string(jose.ES256), Use: "sig"})
+		verifier = &tokenverify.Verifier{Resolver: resolver, Clock: testclock.NewFakePassiveClock(now)}
+	})
+
+	It("should diagnose a valid token", func() {
+		d := verifier.Verify(sign()+"\n", []string{"other", "sts.amazonaws.com"})
+		Expect(d.Valid).To(BeTrue())
+		Expect(d.Issuer).To(Equal(issuer))
+		Expect(d.Subject).To(Equal("system:serviceaccount:default:foo"))
+		Expect(d.Audience).To(ConsistOf("sts.amazonaws.com"))
+		Expect(d.KeyID).To(Equal(keyID))
+		Expect(d.Algorithm).To(Equal("ES256"))
+		Expect(d.Expiry).To(PointTo(Equal(now.Add(time.Hour))))
+		Expect(d.Checks).To(HaveEach(HaveField("Passed", BeTrue())))
+		Expect(d.Checks).To(HaveLen(6))
+	})
+
+	It("should reject a malformed token", func() {
+		d := verifier.Verify("not-a-token", nil)
+		Expect(d.Valid).To(BeFalse())
+		Expect(d.Checks).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+			"Name":   Equal(tokenverify.CheckFormat),
+			"Passed": BeFalse(),
+		})))
+	})
+
+	It("should reject a token that is too large", func() {
+		d := verifier.Verify(strings.Repeat("a", tokenverify.MaxTokenSize+1), nil)
+		Expect(check(d, tokenverify.CheckFormat).Message).To(ContainSubstring("larger than"))
+	})
+
+	It("should report an issuer that is not published", func() {
+		claims.Issuer = "https://discovery.example.com/projects/foo/shoots/unknown/issuer"
+		d := verifier.Verify(sign(), nil)
+		Expect(d.Valid).To(BeFalse())
+		Expect(check(d, tokenverify.CheckIssuer).Message).To(ContainSubstring("is not published by the discovery server: no entry found"))
+		Expect(check(d, tokenverify.CheckSignature).Name).To(BeEmpty())
+		Expect(check(d, tokenverify.CheckExpiry).Passed).To(BeTrue())
+	})
+
+	It("should report an issuer that does not match the discovery document", func() {
+		resolver[issuer] = tokenverify.Documents{Config: []byte(`{"issuer":"https://other.example.com"}`), JWKS: resolver[issuer].JWKS}
+		d := verifier.Verify(sign(), nil)
+		Expect(check(d, tokenverify.CheckIssuer)).To(MatchFields(IgnoreExtras, Fields{
+			"Passed":  BeFalse(),
+			"Message": ContainSubstring("does not match the issuer"),
+		}))
+	})
+
+	It("should report a key ID that is not present in the current JWKS", func() {
+		rotated, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		Expect(err).NotTo(HaveOccurred())
+		publish(jose.JSONWebKey{Key: &rotated.PublicKey, KeyID: "key-2", Algorithm: string(jose.ES256), Use: "sig"})
+
+		d := verifier.Verify(sign(), nil)
+		Expect(d.Valid).To(BeFalse())
+		Expect(check(d, tokenverify.CheckKey)).To(MatchFields(IgnoreExtras, Fields{
+			"Passed":  BeFalse(),
+			"Message": And(ContainSubstring(`key ID "key-1" is not present`), ContainSubstring("[key-2]"), ContainSubstring("rotated out")),
+		}))
+	})
+
+	It("should report an invalid signature", func() {
+		other, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		Expect(err).NotTo(HaveOccurred())
+		publish(jose.JSONWebKey{Key: &other.PublicKey, KeyID: keyID, Algorithm: string(jose.ES256), Use: "sig"})
+
+		d := verifier.Verify(sign(), nil)
+		Expect(check(d, tokenverify.CheckKey).Passed).To(BeTrue())
+		Expect(check(d, tokenverify.CheckSignature)).To(MatchFields(IgnoreExtras, Fields{
+			"Passed":  BeFalse(),
+			"Message": ContainSubstring("cannot be verified"),
+		}))
+	})
+
+	It("should try all keys if the token does not specify a key ID", func() {
+		keyID = ""
+		d := verifier.Verify(sign(), nil)
+		Expect(d.Valid).To(BeTrue())
+		Expect(check(d, tokenverify.CheckKey).Message).To(ContainSubstring("does not specify a key ID"))
+	})
+
+	It("should report an unexpected audience", func() {
+		d := verifier.Verify(sign(), []string{"api://AzureADTokenExchange"})
+		Expect(d.Valid).To(BeFalse())
+		Expect(check(d, tokenverify.CheckAudience).Message).To(Equal("token is issued for [sts.amazonaws.com], expected one of [api://AzureADTokenExchange]"))
+	})
+
+	DescribeTable("should check the expiry",
+		func(mutate func(), passed bool, message string) {
+			mutate()
+			Expect(check(verifier.Verify(sign(), nil), tokenverify.CheckExpiry)).To(MatchFields(IgnoreExtras, Fields{
+				"Passed":  Equal(passed),
+				"Message": ContainSubstring(message),
+			}))
+		},
+		Entry("expired", func() { claims.Expiry = jwt.NewNumericDate(now.Add(-time.Hour)) }, false, "token expired at 2026-01-01T11:00:00Z, 1h0m0s ago"),
+		Entry("expired within the leeway", func() { claims.Expiry = jwt.NewNumericDate(now.Add(-30 * time.Second)) }, true, "within the tolerated clock skew"),
+		Entry("no exp claim", func() { claims.Expiry = nil }, false, "does not have an exp claim"),
+		Entry("not yet valid", func() { claims.NotBefore = jwt.NewNumericDate(now.Add(time.Hour)) }, false, "not valid before"),
+		Entry("issued in the future", func() {
+			claims.NotBefore = nil
+			claims.IssuedAt = jwt.NewNumericDate(now.Add(time.Hour))
+		}, false, "issued in the future"),
+	)
+
+	It("should write the diagnosis as text", func() {
+		var buf bytes.Buffer
+		Expect(tokenverify.WriteText(&buf, verifier.Verify(sign(), []string{"other"}))).To(Succeed())
+		Expect(buf.String()).To(HavePrefix("Token is NOT valid.\n\nIssuer:     " + issuer + "\n"))
+		Expect(buf.String()).To(ContainSubstring("[PASS] signature: signature is valid for key \"key-1\"\n"))
+		Expect(buf.String()).To(ContainSubstring("[FAIL] audience:  token is issued for [sts.amazonaws.com], expected one of [other]\n"))
+	})
+})
@@ -0,0 +1,48 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package tracing
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+)
+
+// WrapClient returns a client that records a span for every Get call.
+func WrapClient(c client.Client) client.Client {
+	return &tracedClient{Client: c}
+}
+
+type tracedClient struct {
+	client.Client
+}
+
+// Get retrieves an object and records the call as child span of the span in ctx.
+func (c *tracedClient) Get(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+	kind := "Object"
+	if gvk, err := apiutil.GVKForObject(obj, c.Scheme()); err == nil {
+		kind = gvk.Kind
+	}
+
+	ctx, span := Tracer().Start(ctx, "Get "+kind,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			AttributeObjectKind.String(kind),
+			AttributeObjectNamespace.String(key.Namespace),
+			AttributeObjectName.String(key.Name),
+		),
+	)
+	defer span.End()
+
+	err := c.Client.Get(ctx, key, obj, opts...)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
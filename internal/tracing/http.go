@@ -0,0 +1,21 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package tracing
+
+import (
+	"net/http"
+
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+)
+
+// InstrumentHandler wraps the http handler so that a server span is recorded for every request.
+// The W3C trace context of inbound requests is used as parent of the span.
+func InstrumentHandler(route string, handler http.Handler) http.Handler {
+	return otelhttp.NewHandler(handler, route,
+		otelhttp.WithSpanNameFormatter(func(_ string, r *http.Request) string {
+			return r.Method + " " + route
+		}),
+	)
+}
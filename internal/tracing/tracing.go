@@ -0,0 +1,83 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package tracing
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	// InstrumentationName is the name of the tracer used by the discovery server.
+	InstrumentationName = "github.com/gardener/gardener-discovery-server"
+
+	// AttributeProjectName is the span attribute holding the Gardener project name.
+	AttributeProjectName = attribute.Key("gardener.project.name")
+	// AttributeShootUID is the span attribute holding the shoot UID.
+	AttributeShootUID = attribute.Key("gardener.shoot.uid")
+	// AttributeObjectNamespace is the span attribute holding the namespace of a Kubernetes object.
+	AttributeObjectNamespace = attribute.Key("k8s.object.namespace")
+	// AttributeObjectName is the span attribute holding the name of a Kubernetes object.
+	AttributeObjectName = attribute.Key("k8s.object.name")
+	// AttributeObjectKind is the span attribute holding the kind of a Kubernetes object.
+	AttributeObjectKind = attribute.Key("k8s.object.kind")
+	// AttributeRequestID is the span attribute holding the ID of an http request.
+	AttributeRequestID = attribute.Key("http.request.id")
+)
+
+// Config holds the configuration of the trace exporter.
+type Config struct {
+	// Endpoint is the URL of the OTLP/HTTP traces endpoint, e.g. https://collector:4318/v1/traces.
+	Endpoint string
+	// SamplingRatio is the fraction of root traces that are sampled.
+	// Child spans follow the decision of their parent.
+	SamplingRatio float64
+	// ServiceName is reported as "service.name" resource attribute.
+	ServiceName string
+	// ServiceVersion is reported as "service.version" resource attribute.
+	ServiceVersion string
+}
+
+// Tracer returns the tracer used by the discovery server.
+// Spans are dropped unless [Setup] installed an exporting tracer provider.
+func Tracer() trace.Tracer {
+	return otel.Tracer(InstrumentationName)
+}
+
+// Setup registers the W3C trace context propagator and, if an endpoint is configured,
+// a global tracer provider exporting spans via OTLP/HTTP.
+// The returned function flushes pending spans and must be called on shutdown.
+func Setup(ctx context.Context, conf Config) (func(context.Context) error, error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	if conf.Endpoint == "" {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	exporter, err := otlptracehttp.New(ctx, otlptracehttp.WithEndpointURL(conf.Endpoint))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create OTLP trace exporter: %w", err)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(conf.SamplingRatio))),
+		sdktrace.WithResource(resource.NewSchemaless(
+			attribute.String("service.name", conf.ServiceName),
+			attribute.String("service.version", conf.ServiceVersion),
+		)),
+	)
+	otel.SetTracerProvider(provider)
+
+	return provider.Shutdown, nil
+}
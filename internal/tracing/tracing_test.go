@@ -0,0 +1,127 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package tracing_test
+
+import (
+	"context"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	"github.com/gardener/gardener/pkg/client/kubernetes"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	collectortracev1 "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	"google.golang.org/protobuf/proto"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/gardener/gardener-discovery-server/internal/tracing"
+)
+
+// collector is a minimal stand-in for an OTLP/HTTP collector.
+type collector struct {
+	mutex    sync.Mutex
+	requests []*collectortracev1.ExportTraceServiceRequest
+}
+
+func (c *collector) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	defer GinkgoRecover()
+	Expect(r.URL.Path).To(Equal("/v1/traces"))
+	body, err := io.ReadAll(r.Body)
+	Expect(err).ToNot(HaveOccurred())
+
+	req := &collectortracev1.ExportTraceServiceRequest{}
+	Expect(proto.Unmarshal(body, req)).To(Succeed())
+
+	c.mutex.Lock()
+	c.requests = append(c.requests, req)
+	c.mutex.Unlock()
+
+	w.Header().Set("Content-Type", "application/x-protobuf")
+	w.WriteHeader(http.StatusOK)
+}
+
+var _ = Describe("Tracing", func() {
+	AfterEach(func() {
+		otel.SetTracerProvider(sdktrace.NewTracerProvider())
+	})
+
+	Describe("#Setup", func() {
+		It("should export server spans continuing the inbound trace context", func() {
+			c := &collector{}
+			srv := httptest.NewServer(c)
+			DeferCleanup(srv.Close)
+
+			shutdown, err := tracing.Setup(context.Background(), tracing.Config{
+				Endpoint:      srv.URL + "/v1/traces",
+				SamplingRatio: 0,
+				ServiceName:   "test",
+			})
+			Expect(err).ToNot(HaveOccurred())
+
+			h := tracing.InstrumentHandler("/test", http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			}))
+
+			const (
+				traceID  = "4bf92f3577b34da6a3ce929d0e0e4736"
+				parentID = "00f067aa0ba902b7"
+			)
+			req := httptest.NewRequest(http.MethodGet, "/test", nil)
+			req.Header.Set("traceparent", "00-"+traceID+"-"+parentID+"-01")
+			h.ServeHTTP(httptest.NewRecorder(), req)
+
+			Expect(shutdown(context.Background())).To(Succeed())
+
+			c.mutex.Lock()
+			defer c.mutex.Unlock()
+			Expect(c.requests).To(HaveLen(1))
+			Expect(c.requests[0].ResourceSpans).To(HaveLen(1))
+			Expect(c.requests[0].ResourceSpans[0].ScopeSpans).To(HaveLen(1))
+			spans := c.requests[0].ResourceSpans[0].ScopeSpans[0].Spans
+			Expect(spans).To(HaveLen(1))
+			Expect(spans[0].Name).To(Equal("GET /test"))
+			Expect(hex.EncodeToString(spans[0].TraceId)).To(Equal(traceID))
+			Expect(hex.EncodeToString(spans[0].ParentSpanId)).To(Equal(parentID))
+		})
+
+		It("should not export spans if endpoint is not set", func() {
+			shutdown, err := tracing.Setup(context.Background(), tracing.Config{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(shutdown(context.Background())).To(Succeed())
+		})
+	})
+
+	Describe("#WrapClient", func() {
+		It("should record a child span for Get calls", func() {
+			exporter := tracetest.NewInMemoryExporter()
+			otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter)))
+
+			project := &gardencorev1beta1.Project{ObjectMeta: metav1.ObjectMeta{Name: "foo"}}
+			c := tracing.WrapClient(fake.NewClientBuilder().WithScheme(kubernetes.GardenScheme).WithObjects(project).Build())
+
+			ctx, parent := tracing.Tracer().Start(context.Background(), "parent")
+			Expect(c.Get(ctx, client.ObjectKeyFromObject(project), &gardencorev1beta1.Project{})).To(Succeed())
+			Expect(c.Get(ctx, client.ObjectKey{Name: "bar"}, &gardencorev1beta1.Project{})).ToNot(Succeed())
+			parent.End()
+
+			spans := exporter.GetSpans()
+			Expect(spans).To(HaveLen(3))
+			Expect(spans[0].Name).To(Equal("Get Project"))
+			Expect(spans[0].Parent.SpanID()).To(Equal(parent.SpanContext().SpanID()))
+			Expect(spans[0].Attributes).To(ContainElement(tracing.AttributeObjectName.String("foo")))
+			Expect(spans[1].Events).To(HaveLen(1))
+			Expect(spans[1].Events[0].Name).To(Equal("exception"))
+		})
+	})
+})
@@ -0,0 +1,85 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package utils_test
+
+import (
+	"testing"
+
+	"github.com/gardener/gardener-discovery-server/internal/utils"
+)
+
+func FuzzSplitProjectNameAndShootUID(f *testing.F) {
+	f.Add("test--a6475c90-d533-43c4-bbb0-d99200b491b1")
+	f.Add("test--A6475C90-D533-43C4-BBB0-D99200B491B1")
+	f.Add("test--{a6475c90-d533-43c4-bbb0-d99200b491b1}")
+	f.Add(" --a6475c90-d533-43c4-bbb0-d99200b491b1")
+	f.Add("a--b--c")
+	f.Add("--")
+	f.Add("")
+
+	f.Fuzz(func(t *testing.T, key string) {
+		projectName, shootUID, err := utils.SplitProjectNameAndShootUID(key)
+		if err != nil {
+			if projectName != "" || shootUID != "" {
+				t.Fatalf("returned parts %q and %q together with an error", projectName, shootUID)
+			}
+			return
+		}
+		if projectName+"--"+shootUID != key {
+			t.Fatalf("parts %q and %q do not form the key %q", projectName, shootUID, key)
+		}
+		if !utils.IsProjectName(projectName) || !utils.IsShootUID(shootUID) {
+			t.Fatalf("accepted invalid project name %q or shoot UID %q", projectName, shootUID)
+		}
+	})
+}
+
+func FuzzLoadKeySet(f *testing.F) {
+	f.Add([]byte(`{"keys":[{"use":"sig","kty":"EC","kid":"foo","crv":"P-256","alg":"ES256","x":"Z2aMaJaDl_0ZLaJ3aPtgqDmxyi6brnSq2fH8IYd9BWE","y":"Gv9VDUjcFLtnxOEUyZ3yfEWDgfdNNomBy1ahufwf1Mo"}]}`))
+	f.Add([]byte(`{"keys":[]}`))
+	f.Add([]byte(`{"keys":[{"kty":"RSA"}]}`))
+	f.Add([]byte(`null`))
+	f.Add([]byte(`{} trailing`))
+	f.Add([]byte(``))
+
+	f.Fuzz(func(t *testing.T, jwks []byte) {
+		keySet, err := utils.LoadKeySet(jwks)
+		if err != nil {
+			return
+		}
+		if keySet == nil {
+			t.Fatal("returned nil key set without an error")
+		}
+		if len(jwks) > utils.MaxDocumentSize {
+			t.Fatalf("accepted key set of %d bytes", len(jwks))
+		}
+		for _, k := range keySet.Keys {
+			_ = k.IsPublic()
+			_ = k.Valid()
+		}
+		_ = utils.KeySetExpiration(keySet)
+	})
+}
+
+func FuzzLoadOpenIDConfig(f *testing.F) {
+	f.Add([]byte(`{"issuer":"https://foo","jwks_uri":"https://foo/jwks"}`))
+	f.Add([]byte(`{"issuer":1}`))
+	f.Add([]byte(`null`))
+	f.Add([]byte(`{} trailing`))
+	f.Add([]byte(``))
+
+	f.Fuzz(func(t *testing.T, config []byte) {
+		openIDConfig, err := utils.LoadOpenIDConfig(config)
+		if err != nil {
+			return
+		}
+		if openIDConfig == nil {
+			t.Fatal("returned nil configuration without an error")
+		}
+		if len(config) > utils.MaxDocumentSize {
+			t.Fatalf("accepted configuration of %d bytes", len(config))
+		}
+	})
+}
@@ -5,37 +5,91 @@
 package utils
 
 import (
+	"crypto/x509"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
 	"github.com/go-jose/go-jose/v4"
+	"github.com/google/uuid"
+	"k8s.io/apimachinery/pkg/util/validation"
 )
 
-// ErrProjShootUIDInvalidFormat is an error that is returned if
-// an issuer metadata shoot secret name is not in the correct format.
-var ErrProjShootUIDInvalidFormat = errors.New("input not in the correct format: projectName--shootUID")
+// AnnotationShootNameAlias is the annotation of a project which disables the resolution of its shoots by name
+// if it is set to "disabled".
+const AnnotationShootNameAlias = "discovery.gardener.cloud/shoot-name-alias"
+
+// MaxDocumentSize is the maximum size in bytes of the documents read from secrets and configmaps.
+const MaxDocumentSize = 256 << 10
+
+var (
+	// ErrProjShootUIDInvalidFormat is an error that is returned if
+	// an issuer metadata shoot secret name is not in the correct format.
+	ErrProjShootUIDInvalidFormat = errors.New("input not in the correct format: projectName--shootUID")
+	// ErrDocumentTooLarge is returned if a document exceeds [MaxDocumentSize].
+	ErrDocumentTooLarge = fmt.Errorf("document exceeds the maximum size of %d bytes", MaxDocumentSize)
+)
 
 // SplitProjectNameAndShootUID splits the key by '--' in two parts.
+// The project name has to be a DNS label and the shoot UID has to be a UUID in its canonical form.
 func SplitProjectNameAndShootUID(key string) (string, string, error) {
 	split := strings.Split(key, "--")
-	if len(split) != 2 || strings.TrimSpace(split[0]) == "" || strings.TrimSpace(split[1]) == "" {
+	if len(split) != 2 || !IsProjectName(split[0]) || !IsShootUID(split[1]) {
 		return "", "", ErrProjShootUIDInvalidFormat
 	}
 	return split[0], split[1], nil
 }
 
+// IsProjectName reports whether the name is a valid project name, i.e. a DNS label.
+func IsProjectName(name string) bool {
+	return len(validation.IsDNS1123Label(name)) == 0
+}
+
+// IsShootUID reports whether the uid is a UUID in its canonical form, e.g. a6475c90-d533-43c4-bbb0-d99200b491b1.
+// Other forms accepted by [uuid.Parse], e.g. with braces or in upper case, are rejected.
+func IsShootUID(uid string) bool {
+	parsed, err := uuid.Parse(uid)
+	return err == nil && parsed.String() == uid
+}
+
 // LoadKeySet parses the jwks key set.
 func LoadKeySet(jwks []byte) (*jose.JSONWebKeySet, error) {
+	if len(jwks) > MaxDocumentSize {
+		return nil, ErrDocumentTooLarge
+	}
 	keySet := &jose.JSONWebKeySet{}
-	if err := json.Unmarshal(jwks, &keySet); err != nil {
+	if err := json.Unmarshal(jwks, keySet); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal JWKS: %w", err)
 	}
 
 	return keySet, nil
 }
 
+// KeySetExpiration returns the earliest expiration of the certificates of the keys.
+// It returns the zero time if none of the keys has certificates.
+func KeySetExpiration(keySet *jose.JSONWebKeySet) time.Time {
+	var certs []*x509.Certificate
+	for _, k := range keySet.Keys {
+		certs = append(certs, k.Certificates...)
+	}
+	return CertificatesExpiration(certs)
+}
+
+// CertificatesExpiration returns the earliest expiration of the certificates.
+// It returns the zero time if there are no certificates.
+func CertificatesExpiration(certs []*x509.Certificate) time.Time {
+	var expires time.Time
+	for _, cert := range certs {
+		if expires.IsZero() || cert.NotAfter.Before(expires) {
+			expires = cert.NotAfter
+		}
+	}
+	return expires
+}
+
 // OpenIDMetadata is a minimal struct allowing to parse the issuer and jwks URIs
 // from the OIDC discovery page.
 type OpenIDMetadata struct {
@@ -45,9 +99,35 @@ type OpenIDMetadata struct {
 
 // LoadOpenIDConfig parses the openid configuration page.
 func LoadOpenIDConfig(config []byte) (*OpenIDMetadata, error) {
+	if len(config) > MaxDocumentSize {
+		return nil, ErrDocumentTooLarge
+	}
 	openIDConfig := &OpenIDMetadata{}
 	if err := json.Unmarshal(config, openIDConfig); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal openid configuration: %w", err)
 	}
 	return openIDConfig, nil
 }
+
+// ShootNameAlias returns the alias a shoot is resolved by its name, i.e. projectName--shootName.
+// It returns false if the project opted out of the resolution by name.
+func ShootNameAlias(project *gardencorev1beta1.Project, shootName string) (string, bool) {
+	if project.Annotations[AnnotationShootNameAlias] == "disabled" {
+		return "", false
+	}
+	return project.Name + "--" + shootName, true
+}
+
+// RejectionReason formats the reason for removing an entry from a store
+// together with the error and the key value pairs that were logged.
+func RejectionReason(reason string, err error, keysAndValues ...any) string {
+	var b strings.Builder
+	b.WriteString(reason)
+	for i := 0; i+1 < len(keysAndValues); i += 2 {
+		fmt.Fprintf(&b, ", %v=%v", keysAndValues[i], keysAndValues[i+1])
+	}
+	if err != nil {
+		b.WriteString(": " + err.Error())
+	}
+	return b.String()
+}
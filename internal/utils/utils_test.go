@@ -5,11 +5,16 @@
 package utils_test
 
 import (
+	"crypto/x509"
 	"errors"
+	"time"
 
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	"github.com/go-jose/go-jose/v4"
 	"github.com/google/uuid"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/gardener/gardener-discovery-server/internal/utils"
 )
@@ -106,4 +111,47 @@ var _ = Describe("Utils", func() {
 		})
 
 	})
+
+	Describe("#RejectionReason", func() {
+		It("should return the reason", func() {
+			Expect(utils.RejectionReason("shoot not found", nil)).To(Equal("shoot not found"))
+		})
+
+		It("should append key value pairs and the error", func() {
+			Expect(utils.RejectionReason("secret is missing data key", errors.New("boom"), "key", "jwks", "dangling")).
+				To(Equal("secret is missing data key, key=jwks: boom"))
+		})
+	})
+
+	Describe("#ShootNameAlias", func() {
+		It("should return the alias", func() {
+			alias, ok := utils.ShootNameAlias(&gardencorev1beta1.Project{ObjectMeta: metav1.ObjectMeta{Name: "foo"}}, "bar")
+			Expect(ok).To(BeTrue())
+			Expect(alias).To(Equal("foo--bar"))
+		})
+
+		It("should not return an alias if the project opted out", func() {
+			_, ok := utils.ShootNameAlias(&gardencorev1beta1.Project{ObjectMeta: metav1.ObjectMeta{
+				Name:        "foo",
+				Annotations: map[string]string{utils.AnnotationShootNameAlias: "disabled"},
+			}}, "bar")
+			Expect(ok).To(BeFalse())
+		})
+	})
+
+	Describe("#CertificatesExpiration", func() {
+		It("should return the earliest expiration", func() {
+			now := time.Now()
+			Expect(utils.CertificatesExpiration([]*x509.Certificate{
+				{NotAfter: now.Add(2 * time.Hour)},
+				{NotAfter: now.Add(time.Hour)},
+				{NotAfter: now.Add(3 * time.Hour)},
+			})).To(Equal(now.Add(time.Hour)))
+		})
+
+		It("should return the zero time without certificates", func() {
+			Expect(utils.CertificatesExpiration(nil)).To(BeZero())
+			Expect(utils.KeySetExpiration(&jose.JSONWebKeySet{Keys: []jose.JSONWebKey{{KeyID: "foo"}}})).To(BeZero())
+		})
+	})
 })
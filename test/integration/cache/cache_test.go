@@ -0,0 +1,156 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cache_test
+
+import (
+	"encoding/json"
+
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	"github.com/gardener/gardener/pkg/utils"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	discoverycache "github.com/gardener/gardener-discovery-server/internal/cache"
+)
+
+var _ = Describe("Cache", func() {
+	var namespace *corev1.Namespace
+
+	BeforeEach(func() {
+		name := "test-" + utils.ComputeSHA256Hex([]byte(CurrentSpecReport().LeafNodeLocation.String()))[:5]
+
+		By("Create project namespace")
+		namespace = &corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   "garden-" + name,
+				Labels: map[string]string{"project.gardener.cloud/name": name},
+			},
+		}
+		Expect(testClient.Create(ctx, namespace)).To(Succeed())
+		DeferCleanup(func() { Expect(client.IgnoreNotFound(testClient.Delete(ctx, namespace))).To(Succeed()) })
+	})
+
+	It("should only cache the configmaps labeled as public shoot CA", func() {
+		public := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{
+			Name:      "public",
+			Namespace: namespace.Name,
+			Labels:    map[string]string{"discovery.gardener.cloud/public": "shoot-ca"},
+		}}
+		private := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{
+			Name:      "private",
+			Namespace: namespace.Name,
+		}}
+		Expect(testClient.Create(ctx, public)).To(Succeed())
+		Expect(testClient.Create(ctx, private)).To(Succeed())
+
+		Eventually(testCache.Get).WithArguments(ctx, client.ObjectKeyFromObject(public), &corev1.ConfigMap{}).Should(Succeed())
+		Consistently(func() bool {
+			return apierrors.IsNotFound(testCache.Get(ctx, client.ObjectKeyFromObject(private), &corev1.ConfigMap{}))
+		}).Should(BeTrue())
+
+		list := &corev1.ConfigMapList{}
+		Expect(testCache.List(ctx, list, client.InNamespace(namespace.Name))).To(Succeed())
+		Expect(list.Items).To(ConsistOf(HaveField("Name", public.Name)))
+
+		By("Label the private configmap")
+		private.Labels = map[string]string{"discovery.gardener.cloud/public": "shoot-ca"}
+		Expect(testClient.Update(ctx, private)).To(Succeed())
+		Eventually(testCache.Get).WithArguments(ctx, client.ObjectKeyFromObject(private), &corev1.ConfigMap{}).Should(Succeed())
+
+		By("Remove the label of the public configmap")
+		public.Labels = nil
+		Expect(testClient.Update(ctx, public)).To(Succeed())
+		Eventually(func() bool {
+			return apierrors.IsNotFound(testCache.Get(ctx, client.ObjectKeyFromObject(public), &corev1.ConfigMap{}))
+		}).Should(BeTrue())
+	})
+
+	It("should only cache the secrets of the shoot issuer namespace", func() {
+		issuer := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "issuer", Namespace: discoverycache.ShootIssuerNamespace}}
+		other := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "issuer", Namespace: namespace.Name}}
+		Expect(testClient.Create(ctx, issuer)).To(Succeed())
+		DeferCleanup(func() { Expect(client.IgnoreNotFound(testClient.Delete(ctx, issuer))).To(Succeed()) })
+		Expect(testClient.Create(ctx, other)).To(Succeed())
+
+		Eventually(testCache.Get).WithArguments(ctx, client.ObjectKeyFromObject(issuer), &corev1.Secret{}).Should(Succeed())
+		Expect(testCache.Get(ctx, client.ObjectKeyFromObject(other), &corev1.Secret{})).To(MatchError(ContainSubstring("unknown namespace")))
+	})
+
+	It("should strip the cached shoots, projects and namespaces", func() {
+		project := &gardencorev1beta1.Project{
+			ObjectMeta: metav1.ObjectMeta{Name: namespace.Labels["project.gardener.cloud/name"]},
+			Spec:       gardencorev1beta1.ProjectSpec{Namespace: &namespace.Name, Description: ptr.To("stripped from the cache")},
+		}
+		Expect(testClient.Create(ctx, project)).To(Succeed())
+		DeferCleanup(func() { Expect(client.IgnoreNotFound(testClient.Delete(ctx, project))).To(Succeed()) })
+
+		shoot := &gardencorev1beta1.Shoot{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "foo",
+				Namespace:   namespace.Name,
+				Annotations: map[string]string{"authentication.gardener.cloud/issuer": "managed"},
+			},
+			Spec: gardencorev1beta1.ShootSpec{
+				SecretBindingName: ptr.To("my-provider-account"),
+				CloudProfileName:  ptr.To("cloudprofile1"),
+				Region:            "europe-central-1",
+				Provider: gardencorev1beta1.Provider{
+					Type: "foo-provider",
+					Workers: []gardencorev1beta1.Worker{{
+						Name:    "cpu-worker",
+						Minimum: 3,
+						Maximum: 3,
+						Machine: gardencorev1beta1.Machine{
+							Type:  "large",
+							Image: &gardencorev1beta1.ShootMachineImage{Name: "some-image", Version: ptr.To("1.0.0")},
+						},
+					}},
+				},
+				DNS:        &gardencorev1beta1.DNS{Domain: ptr.To("some-domain.example.com")},
+				Kubernetes: gardencorev1beta1.Kubernetes{Version: "1.31.1"},
+				Networking: &gardencorev1beta1.Networking{Type: ptr.To("foo-networking")},
+			},
+		}
+		Expect(testClient.Create(ctx, shoot)).To(Succeed())
+		DeferCleanup(func() { Expect(client.IgnoreNotFound(testClient.Delete(ctx, shoot))).To(Succeed()) })
+
+		By("Compare the cached shoot with the one of the API server")
+		cachedShoot := &gardencorev1beta1.Shoot{}
+		Eventually(testCache.Get).WithArguments(ctx, client.ObjectKeyFromObject(shoot), cachedShoot).Should(Succeed())
+		Expect(shoot.ManagedFields).ToNot(BeEmpty())
+		Expect(cachedShoot.ManagedFields).To(BeEmpty())
+		Expect(cachedShoot.UID).To(Equal(shoot.UID))
+		Expect(cachedShoot.Annotations).To(Equal(shoot.Annotations))
+		Expect(cachedShoot.Spec).To(BeZero())
+		Expect(jsonSize(cachedShoot)).To(BeNumerically("<", jsonSize(shoot)/2))
+
+		By("Compare the cached project with the one of the API server")
+		cachedProject := &gardencorev1beta1.Project{}
+		Eventually(testCache.Get).WithArguments(ctx, client.ObjectKeyFromObject(project), cachedProject).Should(Succeed())
+		Expect(cachedProject.ManagedFields).To(BeEmpty())
+		Expect(cachedProject.Spec).To(Equal(gardencorev1beta1.ProjectSpec{Namespace: &namespace.Name}))
+		Expect(jsonSize(cachedProject)).To(BeNumerically("<", jsonSize(project)))
+
+		By("Compare the cached namespace with the one of the API server")
+		cachedNamespace := &corev1.Namespace{}
+		Eventually(testCache.Get).WithArguments(ctx, client.ObjectKeyFromObject(namespace), cachedNamespace).Should(Succeed())
+		Expect(cachedNamespace.ManagedFields).To(BeEmpty())
+		Expect(cachedNamespace.Labels).To(Equal(namespace.Labels))
+		Expect(cachedNamespace.Spec).To(BeZero())
+		Expect(cachedNamespace.Status).To(BeZero())
+	})
+})
+
+func jsonSize(obj client.Object) int {
+	GinkgoHelper()
+	data, err := json.Marshal(obj)
+	Expect(err).NotTo(HaveOccurred())
+	return len(data)
+}
@@ -0,0 +1,197 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package discoveryserver_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/gardener/gardener/pkg/client/kubernetes"
+	"github.com/gardener/gardener/pkg/logger"
+	gardenerenvtest "github.com/gardener/gardener/test/envtest"
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gexec"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+
+	discoverycache "github.com/gardener/gardener-discovery-server/internal/cache"
+)
+
+func TestDiscoveryServer(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Test Integration Discovery Server Suite")
+}
+
+const testID = "discovery-server-test"
+
+var (
+	ctx = context.Background()
+	log logr.Logger
+
+	testEnv    *gardenerenvtest.GardenerTestEnvironment
+	testClient client.Client
+
+	binary     string
+	serverArgs []string
+	serverURL  string
+	// healthAddress is the address of the health probes.
+	healthAddress string
+	caFile        string
+	httpClient    *http.Client
+	session       *gexec.Session
+)
+
+var _ = BeforeSuite(func() {
+	logf.SetLogger(logger.MustNewZapLogger(logger.DebugLevel, logger.FormatJSON, zap.WriteTo(GinkgoWriter)))
+	log = logf.Log.WithName(testID)
+
+	By("Start test environment")
+	testEnv = &gardenerenvtest.GardenerTestEnvironment{
+		GardenerAPIServer: &gardenerenvtest.GardenerAPIServer{
+			Args: []string{"--disable-admission-plugins=DeletionConfirmation,ResourceReferenceManager,ExtensionValidator,ShootQuotaValidator,ShootValidator,ShootTolerationRestriction,ManagedSeedShoot,ManagedSeed,ShootManagedSeed,ShootDNS,ShootMutator"},
+		},
+	}
+
+	restConfig, err := testEnv.Start()
+	Expect(err).NotTo(HaveOccurred())
+	Expect(restConfig).NotTo(BeNil())
+
+	DeferCleanup(func() {
+		By("Stop test environment")
+		Expect(testEnv.Stop()).To(Succeed())
+	})
+
+	By("Create test client")
+	testClient, err = client.New(restConfig, client.Options{Scheme: kubernetes.GardenScheme})
+	Expect(err).NotTo(HaveOccurred())
+
+	By("Create shoot issuer namespace")
+	Expect(testClient.Create(ctx, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: discoverycache.ShootIssuerNamespace}})).To(Succeed())
+
+	By("Build discovery server")
+	binary, err = gexec.Build("github.com/gardener/gardener-discovery-server/cmd/discovery-server")
+	Expect(err).NotTo(HaveOccurred())
+	DeferCleanup(gexec.CleanupBuildArtifacts)
+
+	By("Write kubeconfig and serving certificate")
+	dir := GinkgoT().TempDir()
+	kubeconfig := filepath.Join(dir, "kubeconfig")
+	Expect(os.WriteFile(kubeconfig, testEnv.KubeConfig, 0o600)).To(Succeed())
+
+	certPEM, keyPEM, err := generateServingCertificate()
+	Expect(err).NotTo(HaveOccurred())
+	certFile, keyFile := filepath.Join(dir, "tls.crt"), filepath.Join(dir, "tls.key")
+	Expect(os.WriteFile(certFile, certPEM, 0o600)).To(Succeed())
+	Expect(os.WriteFile(keyFile, keyPEM, 0o600)).To(Succeed())
+	// The serving certificate is self-signed, clients trust it as CA.
+	caFile = certFile
+
+	port, err := freePort()
+	Expect(err).NotTo(HaveOccurred())
+	serverURL = "https://127.0.0.1:" + strconv.Itoa(port)
+	healthPort, err := freePort()
+	Expect(err).NotTo(HaveOccurred())
+	healthAddress = "127.0.0.1:" + strconv.Itoa(healthPort)
+	metricsPort, err := freePort()
+	Expect(err).NotTo(HaveOccurred())
+	serverArgs = []string{
+		"--garden=default=" + kubeconfig,
+		"--tls-cert-file=" + certFile,
+		"--tls-private-key-file=" + keyFile,
+		"--address=127.0.0.1",
+		"--port=" + strconv.Itoa(port),
+		"--health-probe-bind-address=" + healthAddress,
+		"--metrics-bind-address=127.0.0.1:" + strconv.Itoa(metricsPort),
+		// Changes have to be picked up by watches, resyncs do not happen within the tests.
+		"--resync-period=1h",
+	}
+
+	pool := x509.NewCertPool()
+	Expect(pool.AppendCertsFromPEM(certPEM)).To(BeTrue())
+	httpClient = &http.Client{
+		Timeout:   5 * time.Second,
+		Transport: &http.Transport{TLSClientConfig: &tls.Config{RootCAs: pool, MinVersion: tls.VersionTLS12}},
+	}
+
+	startDiscoveryServer()
+	DeferCleanup(stopDiscoveryServer)
+})
+
+// startDiscoveryServer starts the discovery server and waits until it is ready.
+func startDiscoveryServer() {
+	By("Start discovery server")
+	var err error
+	session, err = gexec.Start(exec.Command(binary, serverArgs...), GinkgoWriter, GinkgoWriter) // #nosec: G204 -- Test only.
+	Expect(err).NotTo(HaveOccurred())
+
+	Eventually(func(g Gomega) {
+		resp, err := http.Get("http://" + healthAddress + "/readyz")
+		g.Expect(err).NotTo(HaveOccurred())
+		defer resp.Body.Close()
+		g.Expect(resp.StatusCode).To(Equal(http.StatusOK))
+	}).WithTimeout(time.Minute).Should(Succeed())
+}
+
+// stopDiscoveryServer terminates the discovery server and waits until it exited.
+func stopDiscoveryServer() {
+	By("Stop discovery server")
+	Eventually(session.Terminate()).WithTimeout(30 * time.Second).Should(gexec.Exit())
+}
+
+func freePort() (int, error) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return 0, err
+	}
+	defer l.Close()
+	return l.Addr().(*net.TCPAddr).Port, nil
+}
+
+func generateServingCertificate() ([]byte, []byte, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, nil, err
+	}
+	cert := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "discovery-server"},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, cert, cert, &key.PublicKey, key)
+	if err != nil {
+		return nil, nil, err
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to marshal key: %w", err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), nil
+}
@@ -0,0 +1,333 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package discoveryserver_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"encoding/pem"
+	"io"
+	"math/big"
+	"net/http"
+	"os/exec"
+	"time"
+
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	"github.com/gardener/gardener/pkg/utils"
+	"github.com/go-jose/go-jose/v4"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+	"github.com/onsi/gomega/gexec"
+	"github.com/onsi/gomega/types"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	discoverycache "github.com/gardener/gardener-discovery-server/internal/cache"
+)
+
+var _ = Describe("Discovery server", func() {
+	var (
+		namespace *corev1.Namespace
+		project   *gardencorev1beta1.Project
+		shoot     *gardencorev1beta1.Shoot
+		secret    *corev1.Secret
+		configMap *corev1.ConfigMap
+
+		shootPath string
+	)
+
+	BeforeEach(func() {
+		name := "test-" + utils.ComputeSHA256Hex([]byte(CurrentSpecReport().LeafNodeLocation.String()))[:5]
+
+		By("Create project namespace")
+		namespace = &corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   "garden-" + name,
+				Labels: map[string]string{"project.gardener.cloud/name": name},
+			},
+		}
+		Expect(testClient.Create(ctx, namespace)).To(Succeed())
+
+		By("Create Project")
+		project = &gardencorev1beta1.Project{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec:       gardencorev1beta1.ProjectSpec{Namespace: &namespace.Name},
+		}
+		Expect(testClient.Create(ctx, project)).To(Succeed())
+
+		By("Create Shoot")
+		shoot = &gardencorev1beta1.Shoot{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "foo",
+				Namespace:   namespace.Name,
+				Annotations: map[string]string{"authentication.gardener.cloud/issuer": "managed"},
+			},
+			Spec: gardencorev1beta1.ShootSpec{
+				SecretBindingName: ptr.To("my-provider-account"),
+				CloudProfileName:  ptr.To("cloudprofile1"),
+				Region:            "europe-central-1",
+				Provider: gardencorev1beta1.Provider{
+					Type: "foo-provider",
+					Workers: []gardencorev1beta1.Worker{{
+						Name:    "cpu-worker",
+						Minimum: 3,
+						Maximum: 3,
+						Machine: gardencorev1beta1.Machine{
+							Type:  "large",
+							Image: &gardencorev1beta1.ShootMachineImage{Name: "some-image", Version: ptr.To("1.0.0")},
+						},
+					}},
+				},
+				DNS:        &gardencorev1beta1.DNS{Domain: ptr.To("some-domain.example.com")},
+				Kubernetes: gardencorev1beta1.Kubernetes{Version: "1.31.1"},
+				Networking: &gardencorev1beta1.Networking{Type: ptr.To("foo-networking")},
+			},
+		}
+		Expect(testClient.Create(ctx, shoot)).To(Succeed())
+		log.Info("Created Shoot", "shoot", client.ObjectKeyFromObject(shoot), "uid", shoot.UID)
+
+		DeferCleanup(func() {
+			By("Delete Shoot, Project and namespace")
+			Expect(client.IgnoreNotFound(testClient.Delete(ctx, shoot))).To(Succeed())
+			Expect(client.IgnoreNotFound(testClient.Delete(ctx, project))).To(Succeed())
+			Expect(client.IgnoreNotFound(testClient.Delete(ctx, namespace))).To(Succeed())
+		})
+
+		shootPath = "/projects/" + project.Name + "/shoots/" + string(shoot.UID)
+
+		secret = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      project.Name + "--" + string(shoot.UID),
+				Namespace: discoverycache.ShootIssuerNamespace,
+				Labels: map[string]string{
+					"discovery.gardener.cloud/public": "serviceaccount",
+					"project.gardener.cloud/name":     project.Name,
+					"shoot.gardener.cloud/name":       shoot.Name,
+					"shoot.gardener.cloud/namespace":  shoot.Namespace,
+				},
+			},
+			Data: map[string][]byte{
+				"openid-config": []byte(`{"issuer":"https://foo` + shootPath + `/issuer","jwks_uri":"https://foo` + shootPath + `/issuer/jwks"}`),
+				"jwks":          generateJWKS(),
+			},
+		}
+
+		configMap = &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      shoot.Name + ".ca-cluster",
+				Namespace: namespace.Name,
+				Labels: map[string]string{
+					"discovery.gardener.cloud/public":   "shoot-ca",
+					"gardener.cloud/update-restriction": "true",
+					"shoot.gardener.cloud/name":         shoot.Name,
+					"shoot.gardener.cloud/uid":          string(shoot.UID),
+				},
+			},
+			Data: map[string]string{"ca.crt": string(generateCA())},
+		}
+	})
+
+	It("should publish, update and revoke the issuer metadata of a shoot", func() {
+		By("Create issuer secret")
+		Expect(testClient.Create(ctx, secret)).To(Succeed())
+		DeferCleanup(func() { Expect(client.IgnoreNotFound(testClient.Delete(ctx, secret))).To(Succeed()) })
+
+		Eventually(get).WithArguments(shootPath + "/issuer/jwks").Should(Equal(served(secret.Data["jwks"])))
+		Expect(get(shootPath + "/issuer/.well-known/openid-configuration")).To(Equal(served(secret.Data["openid-config"])))
+		Expect(get("/gardens/default" + shootPath + "/issuer/jwks")).To(Equal(served(secret.Data["jwks"])))
+
+		By("Rotate the keys")
+		secret.Data["jwks"] = generateJWKS()
+		Expect(testClient.Update(ctx, secret)).To(Succeed())
+		Eventually(get).WithArguments(shootPath + "/issuer/jwks").Should(Equal(served(secret.Data["jwks"])))
+
+		By("Remove the discovery label")
+		delete(secret.Labels, "discovery.gardener.cloud/public")
+		Expect(testClient.Update(ctx, secret)).To(Succeed())
+		Eventually(get).WithArguments(shootPath + "/issuer/jwks").Should(beNotFound())
+
+		By("Restore the discovery label")
+		secret.Labels["discovery.gardener.cloud/public"] = "serviceaccount"
+		Expect(testClient.Update(ctx, secret)).To(Succeed())
+		Eventually(get).WithArguments(shootPath + "/issuer/jwks").Should(Equal(served(secret.Data["jwks"])))
+
+		By("Delete issuer secret")
+		Expect(testClient.Delete(ctx, secret)).To(Succeed())
+		Eventually(get).WithArguments(shootPath + "/issuer/jwks").Should(beNotFound())
+		Expect(get(shootPath + "/issuer/.well-known/openid-configuration")).To(beNotFound())
+	})
+
+	It("should pass the conformance check of the published issuer", func() {
+		By("Create issuer secret with a conformant discovery document")
+		issuer := serverURL + shootPath + "/issuer"
+		secret.Data["openid-config"] = []byte(`{"issuer":"` + issuer + `","jwks_uri":"` + issuer + `/jwks",` +
+			`"response_types_supported":["id_token"],"subject_types_supported":["public"],"id_token_signing_alg_values_supported":["ES256"]}`)
+		Expect(testClient.Create(ctx, secret)).To(Succeed())
+		DeferCleanup(func() { Expect(client.IgnoreNotFound(testClient.Delete(ctx, secret))).To(Succeed()) })
+		Eventually(get).WithArguments(shootPath + "/issuer/jwks").Should(Equal(served(secret.Data["jwks"])))
+
+		By("Run conformance check")
+		check, err := gexec.Start(exec.Command(binary, "check", // #nosec: G204 -- Test only.
+			"--issuer="+issuer,
+			"--ca-file="+caFile,
+			"--output-format=json",
+		), GinkgoWriter, GinkgoWriter)
+		Expect(err).NotTo(HaveOccurred())
+		Eventually(check).WithTimeout(time.Minute).Should(gexec.Exit(0))
+		Expect(check.Out).To(gbytes.Say(`"failed": 0`))
+	})
+
+	It("should publish and revoke the CA bundle of a shoot", func() {
+		By("Create CA configmap")
+		Expect(testClient.Create(ctx, configMap)).To(Succeed())
+		DeferCleanup(func() { Expect(client.IgnoreNotFound(testClient.Delete(ctx, configMap))).To(Succeed()) })
+
+		Eventually(func(g Gomega) {
+			resp := get(shootPath + "/cluster-ca")
+			g.Expect(resp.Status).To(Equal(http.StatusOK))
+			bundle := struct {
+				Certs string `json:"certs"`
+			}{}
+			g.Expect(json.Unmarshal([]byte(resp.Body), &bundle)).To(Succeed())
+			g.Expect(bundle.Certs).To(Equal(configMap.Data["ca.crt"]))
+		}).Should(Succeed())
+
+		By("Delete CA configmap")
+		Expect(testClient.Delete(ctx, configMap)).To(Succeed())
+		Eventually(get).WithArguments(shootPath + "/cluster-ca").Should(beNotFound())
+	})
+
+	It("should not publish objects outside of the cache scope", func() {
+		By("Create issuer secret in the project namespace")
+		secret.Namespace = namespace.Name
+		Expect(testClient.Create(ctx, secret)).To(Succeed())
+
+		By("Create CA configmap without discovery label")
+		delete(configMap.Labels, "discovery.gardener.cloud/public")
+		Expect(testClient.Create(ctx, configMap)).To(Succeed())
+
+		Consistently(get).WithArguments(shootPath + "/issuer/jwks").WithTimeout(5 * time.Second).Should(beNotFound())
+		Consistently(get).WithArguments(shootPath + "/cluster-ca").WithTimeout(5 * time.Second).Should(beNotFound())
+
+		By("Add the discovery label to the CA configmap")
+		// The label scoped informer has to pick up the configmap once it enters the scope.
+		configMap.Labels["discovery.gardener.cloud/public"] = "shoot-ca"
+		Expect(testClient.Update(ctx, configMap)).To(Succeed())
+		Eventually(get).WithArguments(shootPath + "/cluster-ca").Should(HaveField("Status", http.StatusOK))
+	})
+
+	It("should not publish the issuer metadata of a shoot with a different UID", func() {
+		By("Create issuer secret for another shoot UID")
+		secret.Name = project.Name + "--7f46e3b4-7d1e-4b5e-9a8c-3f0d2c1b0a99"
+		Expect(testClient.Create(ctx, secret)).To(Succeed())
+		DeferCleanup(func() { Expect(client.IgnoreNotFound(testClient.Delete(ctx, secret))).To(Succeed()) })
+
+		Consistently(get).WithArguments("/projects/" + project.Name + "/shoots/7f46e3b4-7d1e-4b5e-9a8c-3f0d2c1b0a99/issuer/jwks").WithTimeout(5 * time.Second).Should(beNotFound())
+	})
+
+	It("should reply with a problem carrying the request ID", func() {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, serverURL+shootPath+"/cluster-ca", nil)
+		Expect(err).NotTo(HaveOccurred())
+		req.Header.Set("X-Request-ID", "integration-test")
+		resp, err := httpClient.Do(req)
+		Expect(err).NotTo(HaveOccurred())
+		defer resp.Body.Close()
+
+		Expect(resp.StatusCode).To(Equal(http.StatusNotFound))
+		Expect(resp.Header.Get("Content-Type")).To(Equal("application/problem+json"))
+		Expect(resp.Header.Get("X-Request-ID")).To(Equal("integration-test"))
+		var problem map[string]any
+		Expect(json.NewDecoder(resp.Body).Decode(&problem)).To(Succeed())
+		Expect(problem).To(HaveKeyWithValue("status", BeNumerically("==", http.StatusNotFound)))
+		Expect(problem).To(HaveKeyWithValue("instance", shootPath+"/cluster-ca"))
+		Expect(problem).To(HaveKeyWithValue("code", BeNumerically("==", http.StatusNotFound)))
+	})
+
+	It("should rebuild the published documents after a restart", func() {
+		By("Create issuer secret and CA configmap")
+		Expect(testClient.Create(ctx, secret)).To(Succeed())
+		DeferCleanup(func() { Expect(client.IgnoreNotFound(testClient.Delete(ctx, secret))).To(Succeed()) })
+		Expect(testClient.Create(ctx, configMap)).To(Succeed())
+		DeferCleanup(func() { Expect(client.IgnoreNotFound(testClient.Delete(ctx, configMap))).To(Succeed()) })
+
+		Eventually(get).WithArguments(shootPath + "/issuer/jwks").Should(Equal(served(secret.Data["jwks"])))
+		Eventually(get).WithArguments(shootPath + "/cluster-ca").Should(HaveField("Status", http.StatusOK))
+
+		stopDiscoveryServer()
+
+		By("Rotate the keys and delete the CA configmap while the discovery server is down")
+		secret.Data["jwks"] = generateJWKS()
+		Expect(testClient.Update(ctx, secret)).To(Succeed())
+		Expect(testClient.Delete(ctx, configMap)).To(Succeed())
+
+		startDiscoveryServer()
+
+		// The readiness requires the caches to be synced, the documents are published shortly after.
+		Eventually(get).WithArguments(shootPath + "/issuer/jwks").Should(Equal(served(secret.Data["jwks"])))
+		Expect(get(shootPath + "/cluster-ca")).To(beNotFound())
+	})
+})
+
+type response struct {
+	Status int
+	Body   string
+}
+
+func served(body []byte) response {
+	return response{Status: http.StatusOK, Body: string(body)}
+}
+
+func beNotFound() types.GomegaMatcher {
+	return HaveField("Status", http.StatusNotFound)
+}
+
+func get(path string) response {
+	GinkgoHelper()
+	resp, err := httpClient.Get(serverURL + path)
+	Expect(err).NotTo(HaveOccurred())
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	Expect(err).NotTo(HaveOccurred())
+	return response{Status: resp.StatusCode, Body: string(body)}
+}
+
+func generateJWKS() []byte {
+	GinkgoHelper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	Expect(err).NotTo(HaveOccurred())
+	jwks, err := json.Marshal(jose.JSONWebKeySet{Keys: []jose.JSONWebKey{{
+		Key:       key.Public(),
+		KeyID:     utils.ComputeSHA256Hex([]byte(key.X.String()))[:16],
+		Algorithm: string(jose.ES256),
+		Use:       "sig",
+	}}})
+	Expect(err).NotTo(HaveOccurred())
+	return jwks
+}
+
+func generateCA() []byte {
+	GinkgoHelper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	Expect(err).NotTo(HaveOccurred())
+	cert := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "ca"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, cert, cert, &key.PublicKey, key)
+	Expect(err).NotTo(HaveOccurred())
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+}